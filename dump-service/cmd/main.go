@@ -1,19 +1,66 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
 	"sort"
 	"strconv"
+	"sync"
 	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 const maxDumps = 20
 
+// metricsAddr is address of /metrics for prometheus and /status, can be changed by METRICS_ADDR
+const metricsAddr = ":8007"
+
+var (
+	lastSuccess = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "dumper_last_success_timestamp_seconds",
+		Help: "Unix time of last successful dump.",
+	})
+	lastSize = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "dumper_last_dump_size_bytes",
+		Help: "Size of last successful dump.",
+	})
+	dumps = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "dumper_dumps_total",
+		Help: "Dumps by result (success, failure).",
+	}, []string{"result"})
+)
+
+// dumpStatus is answer of /status, last dump and when next one will be
+type dumpStatus struct {
+	Result      string    `json:"result"`
+	LastDump    time.Time `json:"last_dump"`
+	LastSuccess time.Time `json:"last_success"`
+	File        string    `json:"file,omitempty"`
+	Size        int64     `json:"size,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	NextDump    time.Time `json:"next_dump"`
+}
+
+var (
+	statusMu sync.Mutex
+	status   = dumpStatus{Result: "none"}
+)
+
+func setStatus(f func(s *dumpStatus)) {
+	statusMu.Lock()
+	defer statusMu.Unlock()
+	f(&status)
+}
+
 var dumpRegexp = regexp.MustCompile(`^dump\.\d{8}_\d{6}\.sql$`)
 
 func cleanupDumps(dir string) {
@@ -56,6 +103,10 @@ func dump(user, password, host, db string, port int) {
 	f, err := os.Create(filename)
 	if err != nil {
 		log.Println("Error creating dump file:", err)
+		dumps.WithLabelValues("failure").Inc()
+		setStatus(func(s *dumpStatus) {
+			s.Result, s.LastDump, s.File, s.Size, s.Error = "failure", time.Now(), filename, 0, err.Error()
+		})
 		return
 	}
 	defer f.Close()
@@ -74,14 +125,48 @@ func dump(user, password, host, db string, port int) {
 
 	if err := cmd.Run(); err != nil {
 		log.Println("Error dumping database:", err)
+		dumps.WithLabelValues("failure").Inc()
+		setStatus(func(s *dumpStatus) {
+			s.Result, s.LastDump, s.File, s.Size, s.Error = "failure", time.Now(), filename, 0, err.Error()
+		})
 	} else {
 		log.Println("Dump success:", filename)
+		dumps.WithLabelValues("success").Inc()
+		lastSuccess.SetToCurrentTime()
+		var size int64
+		if st, err := f.Stat(); err == nil {
+			size = st.Size()
+			lastSize.Set(float64(size))
+		}
+		setStatus(func(s *dumpStatus) {
+			now := time.Now()
+			s.Result, s.LastDump, s.LastSuccess, s.File, s.Size, s.Error = "success", now, now, filename, size, ""
+		})
 	}
 
 	// очищаем старые дампы
 	cleanupDumps("/dumps")
 }
 
+func serveMetrics(addr string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+		statusMu.Lock()
+		st := status
+		statusMu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(st); err != nil {
+			log.Println("Error writing status:", err)
+		}
+	})
+	srv := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+	log.Println("Metrics on", addr)
+	if err := srv.ListenAndServe(); err != nil {
+		log.Println("Metrics server stopped:", err)
+	}
+}
+
 func main() {
 	user := os.Getenv("POSTGRES_USER")
 	password := os.Getenv("POSTGRES_PASSWORD")
@@ -103,12 +188,24 @@ func main() {
 		log.Fatalln("Cannot create /dumps:", err)
 	}
 
+	addr := os.Getenv("METRICS_ADDR")
+	if addr == "" {
+		addr = metricsAddr
+	}
+	go serveMetrics(addr)
+
 	//dump(user, password, host, db, port)
-	log.Printf("next dump in %s", time.Now().Local().Add(time.Hour).Format("20060102_150405"))
+	scheduleNext()
 	ticker := time.NewTicker(time.Hour)
 	defer ticker.Stop()
 	for range ticker.C {
 		dump(user, password, host, db, port)
-		log.Printf("next dump in %s", time.Now().Local().Add(time.Hour).Format("20060102_150405"))
+		scheduleNext()
 	}
 }
+
+func scheduleNext() {
+	next := time.Now().Local().Add(time.Hour)
+	setStatus(func(s *dumpStatus) { s.NextDump = next })
+	log.Printf("next dump in %s", next.Format("20060102_150405"))
+}
@@ -1,16 +1,19 @@
 package main
 
 import (
+	"context"
 	"gateway/config"
 	"gateway/copyrights"
 	_ "gateway/docs"
 	"gateway/internal/grpc/products"
+	"gateway/internal/metrics"
 	"gateway/internal/net/echo"
+	"gateway/internal/pkg/lifecycle"
 	"gateway/internal/pkg/redis"
+	"gateway/internal/pkg/tracing"
 	"log"
+	"log/slog"
 	"os"
-	"os/signal"
-	"syscall"
 )
 
 // @title Volha gateway REST API
@@ -22,11 +25,17 @@ import (
 
 // @host localhost:8080
 // @BasePath /
-// @schemes http
+// @schemes http https
 func main() {
 	const op = "main"
 
 	cfg := config.MustSetup()
+	config.Watch(cfg)
+	shutdown, err := tracing.Setup(context.Background(), cfg.Tracing, "gateway")
+	if err != nil {
+		log.Panic(err)
+	}
+
 	p, err := products.New(cfg)
 	if err != nil {
 		log.Panic(err)
@@ -35,21 +44,25 @@ func main() {
 	rds := redis.New(cfg)
 
 	e := echo.New(rds, p, cfg)
-	go e.MustRun()
+
+	// stopped in reverse order: http server drain first, then connections it use
+	lc := lifecycle.New(cfg.ShutdownTimeout)
+	lc.Add(lifecycle.Component{Name: "tracing", Stop: shutdown})
+	lc.Add(lifecycle.Component{Name: "redis", Stop: func(context.Context) error { return rds.Close() }})
+	lc.Add(lifecycle.Component{Name: "product-service", Stop: func(context.Context) error { return p.Close() }})
+	m := metrics.New(cfg.MetricsPort)
+	lc.Add(lifecycle.Component{Name: "metrics", Run: m.Run, Stop: m.Stop})
+	lc.Add(lifecycle.Component{Name: "http", Run: e.Run, Stop: e.Stop})
 
 	if cfg.Mode != "DEV" {
 		if err := copyrights.Info(); err != nil {
-			log.Println(err)
+			slog.Error("copyrights", "err", err)
 		}
 	}
 
-	stop := make(chan os.Signal, 1)
-	signal.Notify(stop, syscall.SIGTERM, syscall.SIGINT)
-	sign := <-stop
-
-	if err := e.Stop(); err != nil {
-		log.Println(err)
+	if err := lc.Run(context.Background()); err != nil {
+		slog.Error("stopped with error", "op", op, "err", err)
+		os.Exit(1)
 	}
-
-	log.Println(op, "stop signal", sign)
+	slog.Info("stopped", "op", op)
 }
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"gateway/internal/pkg/logger"
+	"log/slog"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Live is settings which are applied without restart when config file is changed. Others, like
+// addresses and secrets, need restart
+type Live struct {
+	Timeout        time.Duration
+	Backoff        time.Duration
+	RetriesCount   int
+	RateLimits     map[string]RateLimit
+	CacheControl   map[string]string
+	CacheTTL       map[string]CacheTTL
+	IdempotencyTTL time.Duration
+	SessionTTL     time.Duration
+}
+
+func (cfg *Config) newLive() *Live {
+	return &Live{
+		Timeout:        cfg.Timeout,
+		Backoff:        cfg.Backoff,
+		RetriesCount:   cfg.RetriesCount,
+		RateLimits:     cfg.RateLimits,
+		CacheControl:   cfg.CacheControl,
+		CacheTTL:       cfg.CacheTTL,
+		IdempotencyTTL: cfg.IdempotencyTTL,
+		SessionTTL:     cfg.SessionTTL,
+	}
+}
+
+// Live return current live settings. Do not keep result, read it on every request
+func (cfg *Config) Live() *Live {
+	if cfg.live == nil {
+		return cfg.newLive()
+	}
+	return cfg.live.Load()
+}
+
+// Watch reload live settings and log level when config file is changed. Bad new config is
+// logged and ignored, old settings stay
+func Watch(cfg *Config) {
+	if cfg.live == nil || v == nil || v.ConfigFileUsed() == "" {
+		return
+	}
+	v.OnConfigChange(func(e fsnotify.Event) {
+		reload(cfg)
+	})
+	v.WatchConfig()
+}
+
+func reload(cfg *Config) {
+	const op = "config.reload"
+
+	next, err := unmarshal(v)
+	if err == nil {
+		// secrets are not reloaded, but required ones must pass validation
+		next.SessionSecret = cfg.SessionSecret
+		err = next.Validate()
+	}
+	if err != nil {
+		slog.Error("config is not reloaded", "op", op, "err", err)
+		return
+	}
+
+	lvl, _ := logger.ParseLevel(next.LogLevel, cfg.Mode)
+	logger.Level.Set(lvl)
+	cfg.live.Store(next.newLive())
+	slog.Info("config reloaded", "op", op, "log_level", lvl.String(), "live", next.newLive())
+}
@@ -1,10 +1,19 @@
 package config
 
 import (
+	"errors"
 	"flag"
+	"gateway/internal/pkg/certs"
+	"gateway/internal/pkg/logger"
+	"gateway/internal/pkg/tracing"
 	"gateway/internal/utils/format"
 	"github.com/spf13/viper"
+	"io/fs"
 	"log"
+	"log/slog"
+	"reflect"
+	"strings"
+	"sync/atomic"
 	"time"
 )
 
@@ -14,38 +23,288 @@ type Config struct {
 	Backoff      time.Duration `mapstructure:"backoff"`
 	RetriesCount int           `mapstructure:"retries_count"`
 	Port         int           `mapstructure:"port"`
-	Mode         string        `mapstructure:"mode"`
-	RedisPw      string        `mapstructure:"redis_pw"`
-	RedisAddr    string        `mapstructure:"redis_addr"`
-	AdminPW      string        `mapstructure:"admin_pw"`
+	// MetricsPort is internal port of /metrics for prometheus, it must not be published
+	MetricsPort int    `mapstructure:"metrics_port"`
+	Mode        string `mapstructure:"mode"`
+	RedisPw     string `mapstructure:"redis_pw"`
+	RedisAddr   string `mapstructure:"redis_addr"`
+	// AdminPW and AdminLogin create first admin when there are no admins yet
+	AdminPW    string `mapstructure:"admin_pw"`
+	AdminLogin string `mapstructure:"admin_login"`
+	// SessionSecret sign admin session cookies. It is required and must be same on all replicas,
+	// so sessions survive restart and work behind balancer
+	SessionSecret string        `mapstructure:"session_secret"`
+	SessionTTL    time.Duration `mapstructure:"session_ttl"`
+	// RateLimits is token bucket settings by route group name. See DefaultRateLimits
+	RateLimits map[string]RateLimit `mapstructure:"rate_limits"`
+	// BreakerFailures is how many product-service failures in row open circuit breaker,
+	// BreakerCooldown is how long it stay open before trying again
+	BreakerFailures int           `mapstructure:"breaker_failures"`
+	BreakerCooldown time.Duration `mapstructure:"breaker_cooldown"`
+	// CacheControl is Cache-Control header of catalog reads by route path. See DefaultCacheControl
+	CacheControl map[string]string `mapstructure:"cache_control"`
+	// CacheTTL is ttl of values cached in redis by family. See DefaultCacheTTL
+	CacheTTL map[string]CacheTTL `mapstructure:"cache_ttl"`
+	// HTTPS is TLS listener of gateway. Port stay plain http and redirect to it
+	HTTPS HTTPS `mapstructure:"https"`
+	// ContentSecurityPolicy is CSP header of responses. See DefaultContentSecurityPolicy
+	ContentSecurityPolicy string `mapstructure:"content_security_policy"`
+	// TrustedProxies is ips or CIDRs of proxies in front of gateway. X-Forwarded-For and
+	// X-Forwarded-Proto are read only from them, without proxies address of connection is used
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
+	// GRPCTLS is TLS of connection to product-service, with client certificate for mTLS
+	GRPCTLS certs.Config `mapstructure:"grpc_tls"`
+	// ActorSecret sign acting admin sent to product-service, it must be same as actor_secret there.
+	// It may be empty when product-service trust client certificate of gateway
+	ActorSecret string `mapstructure:"actor_secret"`
+	// Tracing is export of OpenTelemetry spans
+	Tracing tracing.Config `mapstructure:"tracing"`
+	// LogLevel is debug, info, warn or error. Default is debug in DEV mode and info in others
+	LogLevel string `mapstructure:"log_level"`
+	// IdempotencyTTL is how long response of request with Idempotency-Key is kept for retries
+	IdempotencyTTL time.Duration `mapstructure:"idempotency_ttl"`
+	// ShutdownTimeout is time to drain requests in flight and close connections after SIGTERM
+	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
+	// ShutdownDelay is time between SIGTERM and stop of http server when /readyz already answer 503,
+	// so balancer stop sending new requests. Zero by default
+	ShutdownDelay time.Duration `mapstructure:"shutdown_delay"`
+
+	// live is settings changed by Watch without restart, read them by Live
+	live *atomic.Pointer[Live]
+}
+
+// HTTPS listener serve h2 too, so storefront load many images over one connection
+type HTTPS struct {
+	Enabled  bool   `mapstructure:"enabled"`
+	Port     int    `mapstructure:"port"`
+	CertFile string `mapstructure:"cert_file"`
+	KeyFile  string `mapstructure:"key_file"`
+	// HSTSMaxAge is max-age of Strict-Transport-Security in seconds. Default is one year, negative
+	// disable header
+	HSTSMaxAge int `mapstructure:"hsts_max_age"`
+}
+
+// DefaultContentSecurityPolicy allow only own resources. Inline scripts and styles are for swagger UI
+const DefaultContentSecurityPolicy = "default-src 'self'; img-src 'self' data:; style-src 'self' 'unsafe-inline'; " +
+	"script-src 'self' 'unsafe-inline'; frame-ancestors 'none'"
+
+// RateLimit allow Burst requests at once and Rate requests per second after that
+type RateLimit struct {
+	Rate  float64 `mapstructure:"rate"`
+	Burst int     `mapstructure:"burst"`
+}
+
+// CacheTTL of cached value. After Soft value is stale: it is still served, but one request refresh
+// it in background. After Hard value is dropped by redis
+type CacheTTL struct {
+	Soft time.Duration `mapstructure:"soft"`
+	Hard time.Duration `mapstructure:"hard"`
+}
+
+// Families of cached values
+const (
+	CacheProduct      = "product"
+	CacheList         = "list"
+	CacheDictionaries = "dictionaries"
+)
+
+// DefaultCacheTTL is used for families missing in config
+var DefaultCacheTTL = map[string]CacheTTL{
+	CacheProduct:      {Soft: 10 * time.Minute, Hard: 30 * time.Minute},
+	CacheList:         {Soft: 5 * time.Minute, Hard: 15 * time.Minute},
+	CacheDictionaries: {Soft: 1 * time.Hour, Hard: 3 * time.Hour},
+}
+
+// Route groups with rate limits
+const (
+	LimitPublic = "public"
+	LimitSearch = "search"
+	LimitAuth   = "auth"
+	LimitAdmin  = "admin"
+)
+
+// DefaultRateLimits is used for groups missing in config
+var DefaultRateLimits = map[string]RateLimit{
+	LimitPublic: {Rate: 20, Burst: 40},
+	LimitSearch: {Rate: 2, Burst: 10},
+	LimitAuth:   {Rate: 0.1, Burst: 5},
+	LimitAdmin:  {Rate: 20, Burst: 40},
+}
+
+// DefaultCacheControl is used for routes missing in config. Responses carry ETag, so
+// "no-cache" still let clients revalidate with 304 instead of download
+var DefaultCacheControl = map[string]string{
+	"/api/product/get":                  "public, max-age=60",
+	"/api/product/getall":               "public, max-age=30",
+	"/api/product/filter":               "no-cache",
+	"/api/product/search":               "no-cache",
+	"/api/dictionaries/getall":          "public, max-age=300",
+	"/api/dictionaries/getall/category": "public, max-age=300",
 }
 
-// MustSetup return config and panic if error
+// EnvPrefix is prefix of env variables which override config file: GATEWAY_REDIS_PW, GATEWAY_TRACING_ENDPOINT
+const EnvPrefix = "GATEWAY"
+
+// v is viper of loaded config, Watch reread file with it
+var v *viper.Viper
+
+// MustSetup return config and exit with error if it is bad
 func MustSetup() *Config {
 	cfg, err := setup()
 	if err != nil {
-		log.Panic(err)
+		log.Fatalln("bad config:", err)
 	}
 	return cfg
 }
 
-// setup create config structure
+// setup create config structure from file and env variables
 func setup() (*Config, error) {
 	const op = "config.setup"
 	configPath := flag.String("config", "./config/local.yaml", "path to config file")
 	flag.Parse()
 
-	viper.SetConfigFile(*configPath)
-	var cfg Config
-	if err := viper.ReadInConfig(); err != nil {
+	v = newViper(*configPath)
+	if err := v.ReadInConfig(); err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, format.Error(op, err)
+		}
+		// all settings can come from env, in docker secrets are passed so
+		log.Println("config file is not found, using env only:", *configPath)
+	}
+	cfg, err := unmarshal(v)
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	if err := logger.Setup(cfg.Mode, cfg.LogLevel); err != nil {
 		return nil, format.Error(op, err)
 	}
-	if err := viper.Unmarshal(&cfg); err != nil {
+	if err := cfg.Validate(); err != nil {
 		return nil, format.Error(op, err)
 	}
+	cfg.live = new(atomic.Pointer[Live])
+	cfg.live.Store(cfg.newLive())
+
 	if cfg.Mode == "DEV" {
-		log.Println(format.Struct(cfg))
+		slog.Debug("config", "cfg", cfg)
 	}
 
+	return cfg, nil
+}
+
+func newViper(path string) *viper.Viper {
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetEnvPrefix(EnvPrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	bindEnv(v, reflect.TypeOf(Config{}), "")
+	// groups of rate limits are map keys, only known groups can be set by env
+	for group := range DefaultRateLimits {
+		_ = v.BindEnv("rate_limits." + group + ".rate")
+		_ = v.BindEnv("rate_limits." + group + ".burst")
+	}
+	for family := range DefaultCacheTTL {
+		_ = v.BindEnv("cache_ttl." + family + ".soft")
+		_ = v.BindEnv("cache_ttl." + family + ".hard")
+	}
+	return v
+}
+
+// bindEnv bind env variable to every field with mapstructure tag, so it override file even when
+// key is missing there. Maps are skipped, their keys are not known before file is read
+func bindEnv(v *viper.Viper, t reflect.Type, prefix string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		key := f.Tag.Get("mapstructure")
+		if key == "" || key == "-" {
+			continue
+		}
+		switch {
+		case f.Type.Kind() == reflect.Map:
+		case f.Type.Kind() == reflect.Struct && f.Type != reflect.TypeOf(time.Duration(0)):
+			bindEnv(v, f.Type, prefix+key+".")
+		default:
+			_ = v.BindEnv(prefix + key)
+		}
+	}
+}
+
+// unmarshal read config from viper and fill defaults
+func unmarshal(v *viper.Viper) (*Config, error) {
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, err
+	}
+	cfg.setDefaults()
 	return &cfg, nil
 }
+
+func (cfg *Config) setDefaults() {
+	if cfg.AdminLogin == "" {
+		cfg.AdminLogin = "admin"
+	}
+	if cfg.SessionTTL <= 0 {
+		cfg.SessionTTL = 12 * time.Hour
+	}
+	if cfg.BreakerFailures <= 0 {
+		cfg.BreakerFailures = 5
+	}
+	if cfg.BreakerCooldown <= 0 {
+		cfg.BreakerCooldown = 10 * time.Second
+	}
+	if cfg.IdempotencyTTL <= 0 {
+		cfg.IdempotencyTTL = 24 * time.Hour
+	}
+	if cfg.ShutdownTimeout <= 0 {
+		cfg.ShutdownTimeout = 15 * time.Second
+	}
+	if cfg.MetricsPort == 0 {
+		cfg.MetricsPort = 9090
+	}
+	if cfg.HTTPS.Port == 0 {
+		cfg.HTTPS.Port = 8443
+	}
+	switch {
+	case cfg.HTTPS.HSTSMaxAge == 0:
+		cfg.HTTPS.HSTSMaxAge = 365 * 24 * 60 * 60
+	case cfg.HTTPS.HSTSMaxAge < 0:
+		cfg.HTTPS.HSTSMaxAge = 0
+	}
+	if cfg.ContentSecurityPolicy == "" {
+		cfg.ContentSecurityPolicy = DefaultContentSecurityPolicy
+	}
+	if cfg.RateLimits == nil {
+		cfg.RateLimits = make(map[string]RateLimit)
+	}
+	// missing fields are taken from default one, so env can change only burst of group
+	for name, l := range DefaultRateLimits {
+		got := cfg.RateLimits[name]
+		if got.Rate <= 0 {
+			got.Rate = l.Rate
+		}
+		if got.Burst <= 0 {
+			got.Burst = l.Burst
+		}
+		cfg.RateLimits[name] = got
+	}
+	if cfg.CacheTTL == nil {
+		cfg.CacheTTL = make(map[string]CacheTTL)
+	}
+	for family, ttl := range DefaultCacheTTL {
+		got := cfg.CacheTTL[family]
+		if got.Soft <= 0 {
+			got.Soft = ttl.Soft
+		}
+		if got.Hard <= 0 {
+			got.Hard = max(ttl.Hard, got.Soft)
+		}
+		cfg.CacheTTL[family] = got
+	}
+	if cfg.CacheControl == nil {
+		cfg.CacheControl = make(map[string]string)
+	}
+	for route, cc := range DefaultCacheControl {
+		if _, ok := cfg.CacheControl[route]; !ok {
+			cfg.CacheControl[route] = cc
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEnvOverride(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cfg.yaml")
+	file := "addr_products: products:8008\nredis_addr: redis:6379\nredis_pw: from-file\nport: 8080\n"
+	if err := os.WriteFile(path, []byte(file), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GATEWAY_REDIS_PW", "from-env")
+	t.Setenv("GATEWAY_TIMEOUT", "3s")
+	t.Setenv("GATEWAY_TRACING_ENDPOINT", "jaeger:4317")
+	t.Setenv("GATEWAY_RATE_LIMITS_AUTH_BURST", "7")
+	t.Setenv("GATEWAY_CACHE_TTL_PRODUCT_SOFT", "1m")
+
+	v := newViper(path)
+	if err := v.ReadInConfig(); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := unmarshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.RedisPw != "from-env" || cfg.Timeout != 3*time.Second || cfg.Tracing.Endpoint != "jaeger:4317" {
+		t.Fatalf("env is not applied: %+v", cfg)
+	}
+	if cfg.AddrProducts != "products:8008" {
+		t.Fatalf("file is not applied: %q", cfg.AddrProducts)
+	}
+	if l := cfg.RateLimits[LimitAuth]; l.Burst != 7 {
+		t.Fatalf("rate limit = %+v", l)
+	}
+	if ttl := cfg.CacheTTL[CacheProduct]; ttl.Soft != time.Minute || ttl.Hard != DefaultCacheTTL[CacheProduct].Hard {
+		t.Fatalf("cache ttl = %+v", ttl)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cfg := &Config{Port: 70000, RetriesCount: -1}
+	cfg.setDefaults()
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("want error")
+	}
+	for _, want := range []string{"addr_products", "redis_addr", "session_secret", "port", "retries_count"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error does not mention %s: %v", want, err)
+		}
+	}
+
+	cfg = &Config{AddrProducts: "products:8008", RedisAddr: "redis:6379", Port: 8080, SessionSecret: "0123456789abcdef"}
+	cfg.setDefaults()
+	if err := cfg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg.SessionSecret = "short"
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "session_secret") {
+		t.Fatalf("short session_secret: %v", err)
+	}
+}
+
+func TestLogValueRedact(t *testing.T) {
+	var buf bytes.Buffer
+	cfg := &Config{RedisPw: "redis-secret", AdminPW: "admin-secret", SessionSecret: "session-secret", RedisAddr: "redis:6379"}
+	slog.New(slog.NewJSONHandler(&buf, nil)).Info("config", "cfg", cfg)
+
+	if strings.Contains(buf.String(), "secret") {
+		t.Fatalf("secret in log: %s", buf.String())
+	}
+	if !strings.Contains(buf.String(), "redis:6379") {
+		t.Fatalf("config is not logged: %s", buf.String())
+	}
+}
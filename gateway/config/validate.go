@@ -0,0 +1,125 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"gateway/internal/pkg/logger"
+	"log/slog"
+	"net"
+	"os"
+	"strings"
+)
+
+// minSessionSecret is min length of session_secret, shorter one is easy to brute force
+const minSessionSecret = 16
+
+// Validate check required settings and ranges. Error has all bad settings, one per line
+func (cfg *Config) Validate() error {
+	var errs []error
+	bad := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	if cfg.AddrProducts == "" {
+		bad("addr_products is required")
+	}
+	if cfg.RedisAddr == "" {
+		bad("redis_addr is required")
+	}
+	if len(cfg.SessionSecret) < minSessionSecret {
+		bad("session_secret is required, at least %d characters", minSessionSecret)
+	}
+	if cfg.Port < 1 || cfg.Port > 65535 {
+		bad("port must be from 1 to 65535, got %d", cfg.Port)
+	}
+	if cfg.MetricsPort < 1 || cfg.MetricsPort > 65535 || cfg.MetricsPort == cfg.Port {
+		bad("metrics_port must be from 1 to 65535 and differ from port, got %d", cfg.MetricsPort)
+	}
+	if cfg.Timeout < 0 || cfg.Backoff < 0 {
+		bad("timeout and backoff must not be negative")
+	}
+	if cfg.RetriesCount < 0 {
+		bad("retries_count must not be negative, got %d", cfg.RetriesCount)
+	}
+	if _, err := logger.ParseLevel(cfg.LogLevel, cfg.Mode); err != nil {
+		bad("log_level: %v", err)
+	}
+	for name, l := range cfg.RateLimits {
+		if l.Rate <= 0 || l.Burst <= 0 {
+			bad("rate_limits.%s: rate and burst must be positive", name)
+		}
+	}
+	for family, ttl := range cfg.CacheTTL {
+		if ttl.Soft <= 0 || ttl.Hard < ttl.Soft {
+			bad("cache_ttl.%s: soft must be positive and not longer than hard", family)
+		}
+	}
+	if cfg.HTTPS.Enabled {
+		if cfg.HTTPS.Port < 1 || cfg.HTTPS.Port > 65535 || cfg.HTTPS.Port == cfg.Port {
+			bad("https.port must be from 1 to 65535 and differ from port, got %d", cfg.HTTPS.Port)
+		}
+		for _, f := range []struct{ key, name string }{{"cert_file", cfg.HTTPS.CertFile}, {"key_file", cfg.HTTPS.KeyFile}} {
+			if f.name == "" {
+				bad("https.%s is required", f.key)
+			} else if _, err := os.Stat(f.name); err != nil {
+				bad("https.%s: %v", f.key, err)
+			}
+		}
+	}
+	if _, err := ParseProxies(cfg.TrustedProxies); err != nil {
+		bad("trusted_proxies: %v", err)
+	}
+	if err := cfg.GRPCTLS.Validate(false); err != nil {
+		bad("grpc_tls: %v", err)
+	}
+	if r := cfg.Tracing.SampleRatio; r < 0 || r > 1 {
+		bad("tracing.sample_ratio must be from 0 to 1, got %g", r)
+	}
+	if cfg.ShutdownDelay < 0 || cfg.ShutdownDelay >= cfg.ShutdownTimeout {
+		bad("shutdown_delay must be less than shutdown_timeout (%s), got %s", cfg.ShutdownTimeout, cfg.ShutdownDelay)
+	}
+	return errors.Join(errs...)
+}
+
+// ParseProxies parse ips and CIDRs of trusted_proxies, single ip is network of one address
+func ParseProxies(list []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(list))
+	for _, s := range list {
+		if !strings.Contains(s, "/") {
+			ip := net.ParseIP(s)
+			if ip == nil {
+				return nil, fmt.Errorf("bad ip %q", s)
+			}
+			bits := 8 * len(ip)
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 32
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, n, err := net.ParseCIDR(s)
+		if err != nil {
+			return nil, err
+		}
+		nets = append(nets, n)
+	}
+	return nets, nil
+}
+
+// LogValue hide secrets when config is logged
+func (cfg *Config) LogValue() slog.Value {
+	type plain Config
+	c := plain(*cfg)
+	c.RedisPw = redact(c.RedisPw)
+	c.AdminPW = redact(c.AdminPW)
+	c.SessionSecret = redact(c.SessionSecret)
+	c.ActorSecret = redact(c.ActorSecret)
+	return slog.AnyValue(c)
+}
+
+func redact(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return "***"
+}
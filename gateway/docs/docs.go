@@ -21,29 +21,23 @@ const docTemplate = `{
     "paths": {
         "/api/auth/check": {
             "get": {
-                "description": "проверяет пароль из cookie",
+                "description": "Проверяет cookie сессии и возвращает логин и роль администратора",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "auth"
                 ],
-                "summary": "Проверить пароль",
+                "summary": "Проверить сессию",
                 "responses": {
                     "200": {
-                        "description": "Успешный запрос",
-                        "schema": {
-                            "$ref": "#/definitions/views.SWGSuccessResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Ошибка на сервере",
+                        "description": "Сессия действительна",
                         "schema": {
-                            "$ref": "#/definitions/views.SWGErrorResponse"
+                            "$ref": "#/definitions/views.AdminUser"
                         }
                     },
-                    "502": {
-                        "description": "Ошибка взаимодействия с сервисом",
+                    "401": {
+                        "description": "Нет сессии",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
@@ -51,9 +45,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/brand/create": {
+        "/api/auth/keys/create": {
             "post": {
-                "description": "Добавляет новый бренд",
+                "description": "Создаёт ключ для внешних систем. Ключ возвращается только один раз, хранится лишь его хеш.\nScopes: catalog:read (чтение каталога), products:write (изменение продуктов и цен, включает чтение), files:upload (загрузка файлов).\nКлюч передаётся в заголовке X-API-Key",
                 "consumes": [
                     "application/json"
                 ],
@@ -61,41 +55,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "brands"
+                    "auth"
                 ],
-                "summary": "Создать новый бренд",
+                "summary": "Выпустить API ключ",
                 "parameters": [
                     {
-                        "description": "Данные нового бренда",
-                        "name": "brand",
+                        "description": "Название и scopes ключа",
+                        "name": "key",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/views.Brand"
+                            "$ref": "#/definitions/views.APIKeyCreate"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Ключ создан",
                         "schema": {
-                            "$ref": "#/definitions/views.SWGSuccessResponse"
+                            "$ref": "#/definitions/views.APIKeyIssued"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Неверный формат данных",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/views.SWGErrorResponse"
-                        }
-                    },
-                    "502": {
-                        "description": "Bad Gateway",
+                        "description": "Ошибка на сервере",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
@@ -103,20 +91,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/brand/delete": {
+        "/api/auth/keys/delete": {
             "delete": {
-                "description": "Удаляет бренд по ID",
+                "description": "Удаляет ключ, после чего запросы с ним отклоняются",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "brands"
+                    "auth"
                 ],
-                "summary": "Удалить бренд",
+                "summary": "Отозвать API ключ",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID бренда",
+                        "description": "ID ключа",
                         "name": "id",
                         "in": "query",
                         "required": true
@@ -124,25 +112,25 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Ключ отозван",
                         "schema": {
                             "$ref": "#/definitions/views.SWGSuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Неверный ID",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Ключ не найден",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
                     },
-                    "502": {
-                        "description": "Bad Gateway",
+                    "500": {
+                        "description": "Ошибка на сервере",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
@@ -150,31 +138,86 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/brand/getall": {
+        "/api/auth/keys/getall": {
             "get": {
-                "description": "Возвращает список всех брендов",
+                "description": "Возвращает ключи со scopes, числом запросов и временем последнего использования",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "brands"
+                    "auth"
                 ],
-                "summary": "Получить все бренды",
+                "summary": "Получить все API ключи",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/views.SWGBrandListResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/views.APIKey"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Ошибка на сервере",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/login": {
+            "post": {
+                "description": "Проверяет логин и пароль и выдаёт cookie сессии. После нескольких неверных попыток вход блокируется на время",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Вход администратора",
+                "parameters": [
+                    {
+                        "description": "Логин и пароль",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/views.AdminCredentials"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешный вход",
+                        "schema": {
+                            "$ref": "#/definitions/views.AdminUser"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
                     },
-                    "502": {
-                        "description": "Bad Gateway",
+                    "401": {
+                        "description": "Неверный логин или пароль",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Слишком много попыток",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка на сервере",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
@@ -182,9 +225,29 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/brand/update": {
+        "/api/auth/logout": {
+            "post": {
+                "description": "Завершает текущую сессию и удаляет cookie",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Выход администратора",
+                "responses": {
+                    "200": {
+                        "description": "Успешный выход",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGSuccessResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/password": {
             "put": {
-                "description": "Обновляет информацию о бренде по ID",
+                "description": "Меняет пароль текущего администратора. Все остальные сессии завершаются",
                 "consumes": [
                     "application/json"
                 ],
@@ -192,48 +255,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "brands"
+                    "auth"
                 ],
-                "summary": "Обновить бренд",
+                "summary": "Сменить пароль",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "ID бренда",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "description": "Данные бренда",
-                        "name": "brand",
+                        "description": "Старый и новый пароль",
+                        "name": "passwords",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/views.Brand"
+                            "$ref": "#/definitions/views.AdminPasswordChange"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Пароль изменён",
                         "schema": {
                             "$ref": "#/definitions/views.SWGSuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Неверный формат данных",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Неверный старый пароль",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
                     },
-                    "502": {
-                        "description": "Bad Gateway",
+                    "403": {
+                        "description": "Запрос с API ключом",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка на сервере",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
@@ -241,9 +303,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/category/create": {
+        "/api/auth/users/create": {
             "post": {
-                "description": "Добавляет новую категорию",
+                "description": "Добавляет администратора. Пароль не короче 8 символов. Роль: owner, content или pricing (по умолчанию content)",
                 "consumes": [
                     "application/json"
                 ],
@@ -251,23 +313,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "category"
+                    "auth"
                 ],
-                "summary": "Создать категорию",
+                "summary": "Создать администратора",
                 "parameters": [
                     {
-                        "description": "Новая категория",
-                        "name": "category",
+                        "description": "Логин и пароль",
+                        "name": "credentials",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/views.Category"
+                            "$ref": "#/definitions/views.AdminCredentials"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор с тем же ключом вернёт первый ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Категория успешно создана",
+                        "description": "Администратор создан",
                         "schema": {
                             "$ref": "#/definitions/views.SWGSuccessResponse"
                         }
@@ -278,14 +346,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Ошибка на сервере",
+                    "409": {
+                        "description": "Логин занят или запрос с этим ключом ещё выполняется",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
                     },
-                    "502": {
-                        "description": "Ошибка взаимодействия с сервисом",
+                    "422": {
+                        "description": "Ключ уже использован с другим запросом",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка на сервере",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
@@ -293,46 +367,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/category/delete": {
+        "/api/auth/users/delete": {
             "delete": {
-                "description": "Удаляет категорию по ID",
+                "description": "Удаляет администратора и все его сессии. Нельзя удалить самого себя",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "category"
+                    "auth"
                 ],
-                "summary": "Удалить категорию",
+                "summary": "Удалить администратора",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID категории",
-                        "name": "id",
+                        "description": "Логин администратора",
+                        "name": "login",
                         "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Категория успешно удалена",
+                        "description": "Администратор удалён",
                         "schema": {
                             "$ref": "#/definitions/views.SWGSuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Неверный ID",
+                        "description": "Неверный логин",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Ошибка на сервере",
+                    "404": {
+                        "description": "Администратор не найден",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
                     },
-                    "502": {
-                        "description": "Ошибка взаимодействия с сервисом",
+                    "500": {
+                        "description": "Ошибка на сервере",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
@@ -340,31 +414,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/category/getall": {
+        "/api/auth/users/getall": {
             "get": {
-                "description": "Возвращает список всех категорий",
+                "description": "Возвращает список администраторов без хешей паролей",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "category"
+                    "auth"
                 ],
-                "summary": "Получить все категории",
+                "summary": "Получить всех администраторов",
                 "responses": {
                     "200": {
-                        "description": "Успешный запрос",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/views.SWGCategoryListResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/views.AdminUser"
+                            }
                         }
                     },
-                    "500": {
-                        "description": "Ошибка на сервере",
+                    "401": {
+                        "description": "Нет сессии",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
                     },
-                    "502": {
-                        "description": "Ошибка взаимодействия с сервисом",
+                    "500": {
+                        "description": "Ошибка на сервере",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
@@ -372,9 +449,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/category/update": {
+        "/api/auth/users/role": {
             "put": {
-                "description": "Обновляет информацию о категории",
+                "description": "Меняет роль администратора: owner, content или pricing. Нельзя менять свою роль",
                 "consumes": [
                     "application/json"
                 ],
@@ -382,48 +459,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "category"
+                    "auth"
                 ],
-                "summary": "Обновить категорию",
+                "summary": "Изменить роль администратора",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID категории",
-                        "name": "id",
+                        "description": "Логин администратора",
+                        "name": "login",
                         "in": "query",
                         "required": true
                     },
                     {
-                        "description": "Обновлённая категория",
-                        "name": "category",
+                        "description": "Новая роль",
+                        "name": "role",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/views.Category"
+                            "$ref": "#/definitions/views.AdminRole"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Категория успешно обновлена",
+                        "description": "Роль изменена",
                         "schema": {
                             "$ref": "#/definitions/views.SWGSuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Неверный ID или формат",
+                        "description": "Неверный логин или роль",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Ошибка на сервере",
+                    "404": {
+                        "description": "Администратор не найден",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
                     },
-                    "502": {
-                        "description": "Ошибка взаимодействия с сервисом",
+                    "500": {
+                        "description": "Ошибка на сервере",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
@@ -431,9 +508,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/color/create": {
+        "/api/brand/create": {
             "post": {
-                "description": "Добавляет новый цвет",
+                "description": "Добавляет новый бренд",
                 "consumes": [
                     "application/json"
                 ],
@@ -441,41 +518,59 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "color"
+                    "brands"
                 ],
-                "summary": "Создать цвет",
+                "summary": "Создать новый бренд",
                 "parameters": [
                     {
-                        "description": "Новый цвет",
-                        "name": "color",
+                        "description": "Данные нового бренда",
+                        "name": "brand",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/views.Color"
+                            "$ref": "#/definitions/views.Brand"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор с тем же ключом вернёт первый ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Цвет успешно создан",
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/views.SWGSuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Неверный формат данных",
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Запрос с этим ключом ещё выполняется или его результат неизвестен",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ уже использован с другим запросом",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Ошибка на сервере",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
                     },
                     "502": {
-                        "description": "Ошибка взаимодействия с сервисом",
+                        "description": "Bad Gateway",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
@@ -483,20 +578,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/color/delete": {
+        "/api/brand/delete": {
             "delete": {
-                "description": "Удаляет цвет по ID",
+                "description": "Удаляет бренд по ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "color"
+                    "brands"
                 ],
-                "summary": "Удалить цвет",
+                "summary": "Удалить бренд",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID цвета",
+                        "description": "ID бренда",
                         "name": "id",
                         "in": "query",
                         "required": true
@@ -504,25 +599,25 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Цвет успешно удалён",
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/views.SWGSuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Неверный ID",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Ошибка на сервере",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
                     },
                     "502": {
-                        "description": "Ошибка взаимодействия с сервисом",
+                        "description": "Bad Gateway",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
@@ -530,31 +625,31 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/color/getall": {
+        "/api/brand/getall": {
             "get": {
-                "description": "Возвращает список всех доступных цветов",
+                "description": "Возвращает список всех брендов",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "color"
+                    "brands"
                 ],
-                "summary": "Получить все цвета",
+                "summary": "Получить все бренды",
                 "responses": {
                     "200": {
-                        "description": "Успешный запрос",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/views.SWGColorListResponse"
+                            "$ref": "#/definitions/views.SWGBrandListResponse"
                         }
                     },
                     "500": {
-                        "description": "Ошибка на сервере",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
                     },
                     "502": {
-                        "description": "Ошибка взаимодействия с сервисом",
+                        "description": "Bad Gateway",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
@@ -562,9 +657,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/color/update": {
+        "/api/brand/update": {
             "put": {
-                "description": "Обновляет данные существующего цвета",
+                "description": "Обновляет информацию о бренде по ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -572,48 +667,72 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "color"
+                    "brands"
                 ],
-                "summary": "Обновить цвет",
+                "summary": "Обновить бренд",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID цвета",
+                        "description": "ID бренда",
                         "name": "id",
                         "in": "query",
                         "required": true
                     },
                     {
-                        "description": "Обновлённый цвет",
-                        "name": "color",
+                        "description": "Данные бренда",
+                        "name": "brand",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/views.Color"
+                            "$ref": "#/definitions/views.Brand"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag объекта из GET, если версии нет в теле",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Цвет успешно обновлён",
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/views.SWGSuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Неверный ID или данные",
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Объект уже изменён, в details.current_version текущая версия",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "If-Match другого объекта",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Не передана версия",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Ошибка на сервере",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
                     },
                     "502": {
-                        "description": "Ошибка взаимодействия с сервисом",
+                        "description": "Bad Gateway",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
@@ -621,9 +740,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/country/create": {
+        "/api/category/create": {
             "post": {
-                "description": "Добавляет новую страну",
+                "description": "Добавляет новую категорию",
                 "consumes": [
                     "application/json"
                 ],
@@ -631,23 +750,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "country"
+                    "category"
                 ],
-                "summary": "Создать страну",
+                "summary": "Создать категорию",
                 "parameters": [
                     {
-                        "description": "Новая страна",
-                        "name": "country",
+                        "description": "Новая категория",
+                        "name": "category",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/views.Country"
+                            "$ref": "#/definitions/views.Category"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор с тем же ключом вернёт первый ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Страна успешно создана",
+                        "description": "Категория успешно создана",
                         "schema": {
                             "$ref": "#/definitions/views.SWGSuccessResponse"
                         }
@@ -658,6 +783,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Запрос с этим ключом ещё выполняется или его результат неизвестен",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ уже использован с другим запросом",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Ошибка на сервере",
                         "schema": {
@@ -673,20 +810,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/country/delete": {
+        "/api/category/delete": {
             "delete": {
-                "description": "Удаляет страну по ID",
+                "description": "Удаляет категорию по ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "country"
+                    "category"
                 ],
-                "summary": "Удалить страну",
+                "summary": "Удалить категорию",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID страны",
+                        "description": "ID категории",
                         "name": "id",
                         "in": "query",
                         "required": true
@@ -694,7 +831,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Страна успешно удалена",
+                        "description": "Категория успешно удалена",
                         "schema": {
                             "$ref": "#/definitions/views.SWGSuccessResponse"
                         }
@@ -720,21 +857,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/country/getall": {
+        "/api/category/getall": {
             "get": {
-                "description": "Возвращает список всех стран",
+                "description": "Возвращает список всех категорий",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "country"
+                    "category"
                 ],
-                "summary": "Получить все страны",
+                "summary": "Получить все категории",
                 "responses": {
                     "200": {
                         "description": "Успешный запрос",
                         "schema": {
-                            "$ref": "#/definitions/views.SWGCountryListResponse"
+                            "$ref": "#/definitions/views.SWGCategoryListResponse"
                         }
                     },
                     "500": {
@@ -752,9 +889,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/country/update": {
+        "/api/category/update": {
             "put": {
-                "description": "Обновляет данные существующей страны",
+                "description": "Обновляет информацию о категории",
                 "consumes": [
                     "application/json"
                 ],
@@ -762,70 +899,62 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "country"
+                    "category"
                 ],
-                "summary": "Обновить страну",
+                "summary": "Обновить категорию",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID страны",
+                        "description": "ID категории",
                         "name": "id",
                         "in": "query",
                         "required": true
                     },
                     {
-                        "description": "Обновлённые данные страны",
-                        "name": "country",
+                        "description": "Обновлённая категория",
+                        "name": "category",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/views.Country"
+                            "$ref": "#/definitions/views.Category"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag объекта из GET, если версии нет в теле",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Страна успешно обновлена",
+                        "description": "Категория успешно обновлена",
                         "schema": {
                             "$ref": "#/definitions/views.SWGSuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Неверный ID или данные",
+                        "description": "Неверный ID или формат",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Ошибка на сервере",
+                    "409": {
+                        "description": "Объект уже изменён, в details.current_version текущая версия",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
                     },
-                    "502": {
-                        "description": "Ошибка взаимодействия с сервисом",
+                    "412": {
+                        "description": "If-Match другого объекта",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/api/dictionaries/getall": {
-            "get": {
-                "description": "Возвращает список всех доступных справочных данных: бренды, категории, материалы, страны и цвета",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "dictionaries"
-                ],
-                "summary": "Получить все справочники",
-                "responses": {
-                    "200": {
-                        "description": "Успешный запрос",
+                    },
+                    "428": {
+                        "description": "Не передана версия",
                         "schema": {
-                            "$ref": "#/definitions/views.Dictionaries"
+                            "$ref": "#/definitions/views.SWGErrorResponse"
                         }
                     },
                     "500": {
@@ -843,30 +972,59 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/dictionaries/getall/category": {
-            "get": {
-                "description": "Возвращает список всех доступных справочных данных: бренды, материалы, страны и цвета и размеры по определенной категории",
+        "/api/color/create": {
+            "post": {
+                "description": "Добавляет новый цвет",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "dictionaries"
+                    "color"
                 ],
-                "summary": "Получить все справочники по категории",
+                "summary": "Создать цвет",
                 "parameters": [
+                    {
+                        "description": "Новый цвет",
+                        "name": "color",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/views.Color"
+                        }
+                    },
                     {
                         "type": "string",
-                        "description": "ID категории",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
+                        "description": "Ключ идемпотентности: повтор с тем же ключом вернёт первый ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Успешный запрос",
+                        "description": "Цвет успешно создан",
                         "schema": {
-                            "$ref": "#/definitions/views.Dictionaries"
+                            "$ref": "#/definitions/views.SWGSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Запрос с этим ключом ещё выполняется или его результат неизвестен",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ уже использован с другим запросом",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
                         }
                     },
                     "500": {
@@ -884,34 +1042,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/files/delete": {
+        "/api/color/delete": {
             "delete": {
-                "description": "Удаляет файл. Требуется передовать только имя. Пример: example.gif",
+                "description": "Удаляет цвет по ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "files"
+                    "color"
                 ],
-                "summary": "Удалить файл",
+                "summary": "Удалить цвет",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "название файла",
-                        "name": "title",
+                        "description": "ID цвета",
+                        "name": "id",
                         "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "файл успешно удалён",
+                        "description": "Цвет успешно удалён",
                         "schema": {
                             "$ref": "#/definitions/views.SWGSuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Неверное название",
+                        "description": "Неверный ID",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
@@ -921,38 +1079,41 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
+                    },
+                    "502": {
+                        "description": "Ошибка взаимодействия с сервисом",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/api/files/upload": {
-            "post": {
-                "description": "Сохраняет изображение в папке сервера images/. В поле \"img\" передается файл с расширениями jpg, png, webp, gif",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/color/getall": {
+            "get": {
+                "description": "Возвращает список всех доступных цветов",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "files"
+                    "color"
                 ],
-                "summary": "Загрузить изображение",
+                "summary": "Получить все цвета",
                 "responses": {
                     "200": {
-                        "description": "Цвет успешно создан",
+                        "description": "Успешный запрос",
                         "schema": {
-                            "$ref": "#/definitions/views.SWGFileUploadResponse"
+                            "$ref": "#/definitions/views.SWGColorListResponse"
                         }
                     },
-                    "400": {
-                        "description": "Неверный формат данных",
+                    "500": {
+                        "description": "Ошибка на сервере",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Ошибка на сервере",
+                    "502": {
+                        "description": "Ошибка взаимодействия с сервисом",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
@@ -960,33 +1121,122 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/material/create": {
-            "post": {
-                "description": "Добавляет новый материал",
-                "consumes": [
-                    "application/json"
+        "/api/color/update": {
+            "put": {
+                "description": "Обновляет данные существующего цвета",
+                "consumes": [
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "material"
+                    "color"
                 ],
-                "summary": "Создать материал",
+                "summary": "Обновить цвет",
                 "parameters": [
                     {
-                        "description": "Новый материал",
-                        "name": "material",
+                        "type": "string",
+                        "description": "ID цвета",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Обновлённый цвет",
+                        "name": "color",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/views.Material"
+                            "$ref": "#/definitions/views.Color"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag объекта из GET, если версии нет в теле",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Материал успешно создан",
+                        "description": "Цвет успешно обновлён",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID или данные",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Объект уже изменён, в details.current_version текущая версия",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "If-Match другого объекта",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Не передана версия",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка на сервере",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Ошибка взаимодействия с сервисом",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/country/create": {
+            "post": {
+                "description": "Добавляет новую страну",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "country"
+                ],
+                "summary": "Создать страну",
+                "parameters": [
+                    {
+                        "description": "Новая страна",
+                        "name": "country",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/views.Country"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор с тем же ключом вернёт первый ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Страна успешно создана",
                         "schema": {
                             "$ref": "#/definitions/views.SWGSuccessResponse"
                         }
@@ -997,6 +1247,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Запрос с этим ключом ещё выполняется или его результат неизвестен",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ уже использован с другим запросом",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Ошибка на сервере",
                         "schema": {
@@ -1012,20 +1274,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/material/delete": {
+        "/api/country/delete": {
             "delete": {
-                "description": "Удаляет материал по ID",
+                "description": "Удаляет страну по ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "material"
+                    "country"
                 ],
-                "summary": "Удалить материал",
+                "summary": "Удалить страну",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID материала",
+                        "description": "ID страны",
                         "name": "id",
                         "in": "query",
                         "required": true
@@ -1033,7 +1295,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Материал успешно удалён",
+                        "description": "Страна успешно удалена",
                         "schema": {
                             "$ref": "#/definitions/views.SWGSuccessResponse"
                         }
@@ -1059,21 +1321,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/material/getall": {
+        "/api/country/getall": {
             "get": {
-                "description": "Возвращает список всех материалов",
+                "description": "Возвращает список всех стран",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "material"
+                    "country"
                 ],
-                "summary": "Получить все материалы",
+                "summary": "Получить все страны",
                 "responses": {
                     "200": {
                         "description": "Успешный запрос",
                         "schema": {
-                            "$ref": "#/definitions/views.SWGMaterialListResponse"
+                            "$ref": "#/definitions/views.SWGCountryListResponse"
                         }
                     },
                     "500": {
@@ -1091,9 +1353,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/material/update": {
+        "/api/country/update": {
             "put": {
-                "description": "Обновляет данные существующего материала",
+                "description": "Обновляет данные существующей страны",
                 "consumes": [
                     "application/json"
                 ],
@@ -1101,30 +1363,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "material"
+                    "country"
                 ],
-                "summary": "Обновить материал",
+                "summary": "Обновить страну",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID материала",
+                        "description": "ID страны",
                         "name": "id",
                         "in": "query",
                         "required": true
                     },
                     {
-                        "description": "Обновлённые данные материала",
-                        "name": "material",
+                        "description": "Обновлённые данные страны",
+                        "name": "country",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/views.Material"
+                            "$ref": "#/definitions/views.Country"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag объекта из GET, если версии нет в теле",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Материал успешно обновлён",
+                        "description": "Страна успешно обновлена",
                         "schema": {
                             "$ref": "#/definitions/views.SWGSuccessResponse"
                         }
@@ -1135,6 +1403,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Объект уже изменён, в details.current_version текущая версия",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "If-Match другого объекта",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Не передана версия",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Ошибка на сервере",
                         "schema": {
@@ -1150,42 +1436,83 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/product/create": {
-            "post": {
-                "description": "Добавляет новый продукт",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/dictionaries/getall": {
+            "get": {
+                "description": "Возвращает список всех доступных справочных данных: бренды, категории, материалы, страны и цвета",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "product"
+                    "dictionaries"
                 ],
-                "summary": "Создать продукт",
+                "summary": "Получить все справочники",
                 "parameters": [
                     {
-                        "description": "Новый продукт",
-                        "name": "product",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/views.ProductId"
-                        }
+                        "type": "string",
+                        "description": "ETag из прошлого ответа",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Продукт успешно создан",
+                        "description": "Успешный запрос",
                         "schema": {
-                            "$ref": "#/definitions/views.SWGIdResponse"
+                            "$ref": "#/definitions/views.Dictionaries"
                         }
                     },
-                    "400": {
-                        "description": "Неверный формат данных",
+                    "304": {
+                        "description": "Данные не изменились"
+                    },
+                    "500": {
+                        "description": "Ошибка на сервере",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Ошибка взаимодействия с сервисом",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
+                    }
+                }
+            }
+        },
+        "/api/dictionaries/getall/category": {
+            "get": {
+                "description": "Возвращает список всех доступных справочных данных: бренды, материалы, страны и цвета и размеры по определенной категории",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "dictionaries"
+                ],
+                "summary": "Получить все справочники по категории",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID категории",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag из прошлого ответа",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/views.Dictionaries"
+                        }
+                    },
+                    "304": {
+                        "description": "Данные не изменились"
                     },
                     "500": {
                         "description": "Ошибка на сервере",
@@ -1202,46 +1529,52 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/product/delete": {
+        "/api/files/delete": {
             "delete": {
-                "description": "Удаляет продукт по ID",
+                "description": "Удаляет файл. Требуется передовать только имя. Пример: example.gif\nОдинаковые загрузки хранятся одним файлом. Файл, который указан в фото продуктов, не удаляется",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "product"
+                    "files"
                 ],
-                "summary": "Удалить продукт",
+                "summary": "Удалить файл",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID продукта",
-                        "name": "id",
+                        "description": "название файла",
+                        "name": "title",
                         "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Продукт успешно удалён",
+                        "description": "файл успешно удалён",
                         "schema": {
                             "$ref": "#/definitions/views.SWGSuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Неверный ID",
+                        "description": "Неверное название",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Ошибка на сервере",
+                    "404": {
+                        "description": "Файл не найден",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
                     },
-                    "502": {
-                        "description": "Ошибка взаимодействия с сервисом",
+                    "409": {
+                        "description": "Файл используется продуктами",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка на сервере",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
@@ -1249,9 +1582,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/product/filter": {
+        "/api/files/upload": {
             "post": {
-                "description": "Возвращает список продуктов, соответствующих заданным критериям фильтрации",
+                "description": "Сохраняет изображение в папке сервера images/. В поле \"img\" передается файл с расширениями jpg, png, webp, gif.\nИмя файла вычисляется по содержимому: повторная загрузка тех же байт вернет уже существующее имя.\nФайл считается занятым, когда он указан в фото продукта",
                 "consumes": [
                     "application/json"
                 ],
@@ -1259,29 +1592,1101 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "product"
+                    "files"
                 ],
-                "summary": "Получить продукты по фильтру",
+                "summary": "Загрузить изображение",
+                "responses": {
+                    "200": {
+                        "description": "Цвет успешно создан",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGFileUploadResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка на сервере",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/files/upload/batch": {
+            "post": {
+                "description": "Сохраняет все файлы из поля \"img\" (можно передать несколько раз) в папке сервера images/.\nОшибка одного файла не прерывает загрузку остальных: результат возвращается по каждому файлу в порядке передачи",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Загрузить несколько изображений",
+                "responses": {
+                    "200": {
+                        "description": "Результаты загрузки по каждому файлу",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/views.FileUploadResult"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/files/uploads": {
+            "post": {
+                "description": "Создает загрузку большого изображения по частям (протокол по мотивам tus). В заголовке Upload-Length передается полный размер файла в байтах.\nАдрес для отправки частей возвращается в заголовке Location",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Начать возобновляемую загрузку",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Размер файла в байтах",
+                        "name": "Upload-Length",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор с тем же ключом вернёт первый ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Загрузка создана",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGIdResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный размер",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Запрос с этим ключом ещё выполняется или его результат неизвестен",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Файл слишком большой",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ уже использован с другим запросом",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка на сервере",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/files/uploads/{id}": {
+            "get": {
+                "description": "Возвращает в заголовке Upload-Offset сколько байт уже принято сервером. С этого места клиент продолжает отправку после обрыва.\nВ теле возвращается состояние загрузки, после завершения в нем есть имя сохраненного файла",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Состояние возобновляемой загрузки",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID загрузки",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Состояние загрузки",
+                        "schema": {
+                            "$ref": "#/definitions/views.FileUpload"
+                        }
+                    },
+                    "404": {
+                        "description": "Загрузка не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка на сервере",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Удаляет незавершенную загрузку и принятые части",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Отменить возобновляемую загрузку",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID загрузки",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Загрузка отменена",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGSuccessResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Загрузка не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка на сервере",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    }
+                }
+            },
+            "head": {
+                "description": "Возвращает в заголовке Upload-Offset сколько байт уже принято сервером. С этого места клиент продолжает отправку после обрыва.\nВ теле возвращается состояние загрузки, после завершения в нем есть имя сохраненного файла",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Состояние возобновляемой загрузки",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID загрузки",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Состояние загрузки",
+                        "schema": {
+                            "$ref": "#/definitions/views.FileUpload"
+                        }
+                    },
+                    "404": {
+                        "description": "Загрузка не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка на сервере",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Дописывает тело запроса (Content-Type: application/offset+octet-stream) в загрузку начиная с Upload-Offset, который должен совпадать с текущим смещением на сервере.\nЕсли соединение оборвалось, принятые байты сохраняются. После последней части файл собирается, проверяется и сохраняется как при обычной загрузке",
+                "consumes": [
+                    "application/octet-stream"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "files"
+                ],
+                "summary": "Отправить часть файла",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID загрузки",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение части в байтах",
+                        "name": "Upload-Offset",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Файл загружен полностью",
+                        "schema": {
+                            "$ref": "#/definitions/views.FileUpload"
+                        }
+                    },
+                    "204": {
+                        "description": "Часть принята, новое смещение в заголовке Upload-Offset"
+                    },
+                    "400": {
+                        "description": "Неверные данные",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Загрузка не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Смещение не совпадает с сервером",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Неверный Content-Type",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка на сервере",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/material/create": {
+            "post": {
+                "description": "Добавляет новый материал",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "material"
+                ],
+                "summary": "Создать материал",
+                "parameters": [
+                    {
+                        "description": "Новый материал",
+                        "name": "material",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/views.Material"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор с тем же ключом вернёт первый ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Материал успешно создан",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Запрос с этим ключом ещё выполняется или его результат неизвестен",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ уже использован с другим запросом",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка на сервере",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Ошибка взаимодействия с сервисом",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/material/delete": {
+            "delete": {
+                "description": "Удаляет материал по ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "material"
+                ],
+                "summary": "Удалить материал",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID материала",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Материал успешно удалён",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка на сервере",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Ошибка взаимодействия с сервисом",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/material/getall": {
+            "get": {
+                "description": "Возвращает список всех материалов",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "material"
+                ],
+                "summary": "Получить все материалы",
+                "responses": {
+                    "200": {
+                        "description": "Успешный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGMaterialListResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка на сервере",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Ошибка взаимодействия с сервисом",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/material/update": {
+            "put": {
+                "description": "Обновляет данные существующего материала",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "material"
+                ],
+                "summary": "Обновить материал",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID материала",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Обновлённые данные материала",
+                        "name": "material",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/views.Material"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag объекта из GET, если версии нет в теле",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Материал успешно обновлён",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID или данные",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Объект уже изменён, в details.current_version текущая версия",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "If-Match другого объекта",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Не передана версия",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка на сервере",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Ошибка взаимодействия с сервисом",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/product/create": {
+            "post": {
+                "description": "Добавляет новый продукт. Файлы из photos должны быть уже загружены через /api/files",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Создать продукт",
+                "parameters": [
+                    {
+                        "description": "Новый продукт",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/views.ProductId"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор с тем же ключом вернёт первый ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Продукт успешно создан",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGIdResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Запрос с этим ключом ещё выполняется или его результат неизвестен",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ уже использован с другим запросом",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка на сервере",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Ошибка взаимодействия с сервисом",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/product/delete": {
+            "delete": {
+                "description": "Удаляет продукт по ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Удалить продукт",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID продукта",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Продукт успешно удалён",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка на сервере",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Ошибка взаимодействия с сервисом",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/product/filter": {
+            "post": {
+                "description": "Возвращает список продуктов, соответствующих заданным критериям фильтрации",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Получить продукты по фильтру",
+                "parameters": [
+                    {
+                        "description": "Параметры фильтрации",
+                        "name": "filter",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/views.ProductFilter"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag из прошлого ответа",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGProductListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат запроса",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Данные не изменились (If-None-Match совпал)",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка на сервере",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Ошибка взаимодействия с сервисом",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/product/get": {
+            "get": {
+                "description": "Возвращает продукт",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Получить продукт по id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID продукта",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag из прошлого ответа",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/views.Product"
+                        }
+                    },
+                    "304": {
+                        "description": "Данные не изменились"
+                    },
+                    "500": {
+                        "description": "Ошибка на сервере",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Ошибка взаимодействия с сервисом",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/product/getall": {
+            "get": {
+                "description": "Возвращает список всех продуктов",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Получить все продукты",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "start \u003e 0",
+                        "name": "start",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "end",
+                        "name": "end",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag из прошлого ответа",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGProductListResponse"
+                        }
+                    },
+                    "304": {
+                        "description": "Данные не изменились"
+                    },
+                    "500": {
+                        "description": "Ошибка на сервере",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Ошибка взаимодействия с сервисом",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/product/price": {
+            "put": {
+                "description": "Меняет только цену продукта, остальные поля не трогаются",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Обновить цену продукта",
                 "parameters": [
                     {
-                        "description": "Параметры фильтрации",
-                        "name": "filter",
+                        "type": "string",
+                        "description": "ID продукта",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Новая цена",
+                        "name": "price",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/views.ProductPrice"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag объекта из GET, если версии нет в теле",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Цена обновлена",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID или данные",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Объект уже изменён, в details.current_version текущая версия",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Ошибка взаимодействия с сервисом",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/product/search": {
+            "get": {
+                "description": "Ищет продукт по ID (UUID), article (8 цифр) или названию (частичное совпадение)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Поиск продуктов",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Поисковый запрос",
+                        "name": "query",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag из прошлого ответа",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/views.Product"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Данные не изменились"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/product/update": {
+            "put": {
+                "description": "Обновляет данные существующего продукта. Без поля photos галерея не меняется, пустой photos очищает её",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product"
+                ],
+                "summary": "Обновить продукт",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID продукта",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Обновлённые данные продукта",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/views.ProductId"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag объекта из GET, если версии нет в теле",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Продукт успешно обновлён",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID или данные",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Роль не может менять эти поля: content - цену, pricing - всё кроме цены",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Объект уже изменён, в details.current_version текущая версия",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "If-Match другого объекта",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Не передана версия",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка на сервере",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Ошибка взаимодействия с сервисом",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/productcolorphotos/create": {
+            "post": {
+                "description": "Добавляет массив фотографий для конкретного продукта и цвета",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product_color_photos"
+                ],
+                "summary": "Создать фотографии продукта для цвета",
+                "parameters": [
+                    {
+                        "description": "Новый набор фотографий",
+                        "name": "photos",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/views.ProductFilter"
+                            "$ref": "#/definitions/views.ProductColorPhotos"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор с тем же ключом вернёт первый ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Фотографии успешно добавлены",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
                         }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Успешный запрос",
+                    },
+                    "409": {
+                        "description": "Запрос с этим ключом ещё выполняется или его результат неизвестен",
                         "schema": {
-                            "$ref": "#/definitions/views.SWGProductListResponse"
+                            "$ref": "#/definitions/views.SWGErrorResponse"
                         }
                     },
-                    "400": {
-                        "description": "Неверный формат запроса",
+                    "422": {
+                        "description": "Ключ уже использован с другим запросом",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
@@ -1301,30 +2706,41 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/product/get": {
-            "get": {
-                "description": "Возвращает продукт",
+        "/api/productcolorphotos/delete": {
+            "delete": {
+                "description": "Удаляет записи фотографий продукта для указанного цвета",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "product"
+                    "product_color_photos"
                 ],
-                "summary": "Получить продукт по id",
+                "summary": "Удалить фотографии продукта для цвета",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "ID продукта",
+                        "description": "ID продукта и цвета",
                         "name": "id",
-                        "in": "query",
-                        "required": true
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/views.ProductColorPhotosId"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Успешный запрос",
+                        "description": "Фотографии успешно удалены",
                         "schema": {
-                            "$ref": "#/definitions/views.Product"
+                            "$ref": "#/definitions/views.SWGSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные данные",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
                         }
                     },
                     "500": {
@@ -1342,37 +2758,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/product/getall": {
+        "/api/productcolorphotos/getall": {
             "get": {
-                "description": "Возвращает список всех продуктов",
+                "description": "Возвращает все записи product_color_photos",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "product"
-                ],
-                "summary": "Получить все продукты",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "start \u003e 0",
-                        "name": "start",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "end",
-                        "name": "end",
-                        "in": "query",
-                        "required": true
-                    }
+                    "product_color_photos"
                 ],
+                "summary": "Получить все фотографии продуктов по цветам",
                 "responses": {
                     "200": {
                         "description": "Успешный запрос",
                         "schema": {
-                            "$ref": "#/definitions/views.SWGProductListResponse"
+                            "$ref": "#/definitions/views.SWGProductColorPhotosListResponse"
                         }
                     },
                     "500": {
@@ -1390,43 +2790,54 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/product/search": {
-            "get": {
-                "description": "Ищет продукт по ID (UUID), article (8 цифр) или названию (частичное совпадение)",
+        "/api/productcolorphotos/getphotos": {
+            "post": {
+                "description": "Возвращает массив фотографий для указанного продукта и цвета",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "product"
+                    "product_color_photos"
                 ],
-                "summary": "Поиск продуктов",
+                "summary": "Получить фотографии продукта по ID продукта и цвета",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Поисковый запрос",
-                        "name": "query",
-                        "in": "query",
-                        "required": true
+                        "description": "ID продукта и цвета",
+                        "name": "id",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/views.ProductColorPhotosId"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Список фотографий",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/views.Product"
+                                "type": "string"
                             }
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Неверные данные",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка на сервере",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
                     },
                     "502": {
-                        "description": "Bad Gateway",
+                        "description": "Ошибка взаимодействия с сервисом",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
@@ -1434,9 +2845,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/product/update": {
+        "/api/productcolorphotos/update": {
             "put": {
-                "description": "Обновляет данные существующего продукта",
+                "description": "Обновляет массив фотографий для конкретного продукта и цвета",
                 "consumes": [
                     "application/json"
                 ],
@@ -1444,36 +2855,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "product"
+                    "product_color_photos"
                 ],
-                "summary": "Обновить продукт",
+                "summary": "Обновить фотографии продукта для цвета",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "ID продукта",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "description": "Обновлённые данные продукта",
-                        "name": "product",
+                        "description": "Обновлённые фотографии",
+                        "name": "photos",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/views.ProductId"
+                            "$ref": "#/definitions/views.ProductColorPhotos"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Продукт успешно обновлён",
+                        "description": "Фотографии успешно обновлены",
                         "schema": {
                             "$ref": "#/definitions/views.SWGSuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Неверный ID или данные",
+                        "description": "Неверные данные",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
@@ -1493,9 +2897,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/productcolorphotos/create": {
+        "/api/productphotos/add": {
             "post": {
-                "description": "Добавляет массив фотографий для конкретного продукта и цвета",
+                "description": "Добавляет фото в конец галереи продукта. Первое фото продукта становится главным.\nФайл должен быть уже загружен через /api/files",
                 "consumes": [
                     "application/json"
                 ],
@@ -1503,25 +2907,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "product_color_photos"
+                    "productphotos"
                 ],
-                "summary": "Создать фотографии продукта для цвета",
+                "summary": "Добавить фото в галерею",
                 "parameters": [
                     {
-                        "description": "Новый набор фотографий",
-                        "name": "photos",
+                        "description": "Фото (id и position игнорируются)",
+                        "name": "photo",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/views.ProductColorPhotos"
+                            "$ref": "#/definitions/views.ProductPhoto"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности: повтор с тем же ключом вернёт первый ответ",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Фотографии успешно добавлены",
+                        "description": "Фото добавлено",
                         "schema": {
-                            "$ref": "#/definitions/views.SWGSuccessResponse"
+                            "$ref": "#/definitions/views.SWGIdResponse"
                         }
                     },
                     "400": {
@@ -1530,8 +2940,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Ошибка на сервере",
+                    "409": {
+                        "description": "Запрос с этим ключом ещё выполняется или его результат неизвестен",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Ключ уже использован с другим запросом",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
@@ -1545,45 +2961,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/productcolorphotos/delete": {
+        "/api/productphotos/delete": {
             "delete": {
-                "description": "Удаляет записи фотографий продукта для указанного цвета",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Удаляет фото из галереи продукта и освобождает файл изображения",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "product_color_photos"
+                    "productphotos"
                 ],
-                "summary": "Удалить фотографии продукта для цвета",
+                "summary": "Удалить фото из галереи",
                 "parameters": [
                     {
-                        "description": "ID продукта и цвета",
+                        "type": "string",
+                        "description": "ID фото",
                         "name": "id",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/views.ProductColorPhotosId"
-                        }
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Фотографии успешно удалены",
+                        "description": "Фото удалено",
                         "schema": {
                             "$ref": "#/definitions/views.SWGSuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Неверные данные",
-                        "schema": {
-                            "$ref": "#/definitions/views.SWGErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Ошибка на сервере",
+                        "description": "Неверный ID",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
@@ -1597,25 +3002,37 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/productcolorphotos/getall": {
+        "/api/productphotos/get": {
             "get": {
-                "description": "Возвращает все записи product_color_photos",
+                "description": "Возвращает фотографии продукта в порядке отображения с подписями и цветом",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "product_color_photos"
+                    "productphotos"
+                ],
+                "summary": "Получить галерею продукта",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID продукта",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
                 ],
-                "summary": "Получить все фотографии продуктов по цветам",
                 "responses": {
                     "200": {
-                        "description": "Успешный запрос",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/views.SWGProductColorPhotosListResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/views.ProductPhoto"
+                            }
                         }
                     },
-                    "500": {
-                        "description": "Ошибка на сервере",
+                    "400": {
+                        "description": "Неверный ID",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
@@ -1629,9 +3046,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/productcolorphotos/getphotos": {
-            "post": {
-                "description": "Возвращает массив фотографий для указанного продукта и цвета",
+        "/api/productphotos/reorder": {
+            "put": {
+                "description": "Задаёт порядок галереи. В ids должны быть все фото продукта",
                 "consumes": [
                     "application/json"
                 ],
@@ -1639,38 +3056,82 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "product_color_photos"
+                    "productphotos"
                 ],
-                "summary": "Получить фотографии продукта по ID продукта и цвета",
+                "summary": "Изменить порядок фото",
                 "parameters": [
                     {
-                        "description": "ID продукта и цвета",
-                        "name": "id",
+                        "description": "ID продукта и новый порядок фото",
+                        "name": "order",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/views.ProductColorPhotosId"
+                            "$ref": "#/definitions/views.ProductPhotosOrder"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Список фотографий",
+                        "description": "Порядок обновлён",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/views.SWGSuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "Неверные данные",
+                        "description": "Неверный формат данных",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Ошибка взаимодействия с сервисом",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
+                    }
+                }
+            }
+        },
+        "/api/productphotos/update": {
+            "put": {
+                "description": "Меняет подпись, цвет и признак главного фото. Файл и позиция не меняются",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "productphotos"
+                ],
+                "summary": "Обновить фото галереи",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID фото",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Новые данные фото",
+                        "name": "photo",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/views.ProductPhoto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Фото обновлено",
+                        "schema": {
+                            "$ref": "#/definitions/views.SWGSuccessResponse"
+                        }
                     },
-                    "500": {
-                        "description": "Ошибка на сервере",
+                    "400": {
+                        "description": "Неверный ID или данные",
                         "schema": {
                             "$ref": "#/definitions/views.SWGErrorResponse"
                         }
@@ -1684,53 +3145,47 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/productcolorphotos/update": {
-            "put": {
-                "description": "Обновляет массив фотографий для конкретного продукта и цвета",
-                "consumes": [
-                    "application/json"
-                ],
+        "/healthz": {
+            "get": {
+                "description": "Отвечает 200, пока процесс жив. Зависимости не проверяются, для этого есть /readyz",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "product_color_photos"
+                    "health"
                 ],
-                "summary": "Обновить фотографии продукта для цвета",
-                "parameters": [
-                    {
-                        "description": "Обновлённые фотографии",
-                        "name": "photos",
-                        "in": "body",
-                        "required": true,
+                "summary": "Проверка жизни",
+                "responses": {
+                    "200": {
+                        "description": "Процесс жив",
                         "schema": {
-                            "$ref": "#/definitions/views.ProductColorPhotos"
+                            "$ref": "#/definitions/views.Health"
                         }
                     }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Проверяет Redis и соединение с product-service. Если что-то недоступно, отвечает 503 и\nв checks пишет ошибку этой зависимости. Во время остановки сервиса всегда отвечает 503",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
                 ],
+                "summary": "Проверка готовности",
                 "responses": {
                     "200": {
-                        "description": "Фотографии успешно обновлены",
+                        "description": "Готов принимать запросы",
                         "schema": {
-                            "$ref": "#/definitions/views.SWGSuccessResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Неверные данные",
-                        "schema": {
-                            "$ref": "#/definitions/views.SWGErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Ошибка на сервере",
-                        "schema": {
-                            "$ref": "#/definitions/views.SWGErrorResponse"
+                            "$ref": "#/definitions/views.Health"
                         }
                     },
-                    "502": {
-                        "description": "Ошибка взаимодействия с сервисом",
+                    "503": {
+                        "description": "Зависимость недоступна",
                         "schema": {
-                            "$ref": "#/definitions/views.SWGErrorResponse"
+                            "$ref": "#/definitions/views.Health"
                         }
                     }
                 }
@@ -1738,6 +3193,113 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "views.APIKey": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "integer"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "hash": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_used": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "uses": {
+                    "type": "integer"
+                }
+            }
+        },
+        "views.APIKeyCreate": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "views.APIKeyIssued": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                }
+            }
+        },
+        "views.AdminCredentials": {
+            "type": "object",
+            "properties": {
+                "login": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "views.AdminPasswordChange": {
+            "type": "object",
+            "properties": {
+                "new": {
+                    "type": "string"
+                },
+                "old": {
+                    "type": "string"
+                }
+            }
+        },
+        "views.AdminRole": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "views.AdminUser": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "integer"
+                },
+                "login": {
+                    "type": "string"
+                },
+                "password_hash": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
         "views.Brand": {
             "type": "object",
             "properties": {
@@ -1746,6 +3308,9 @@ const docTemplate = `{
                 },
                 "name": {
                     "type": "string"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
@@ -1763,6 +3328,9 @@ const docTemplate = `{
                 },
                 "uri": {
                     "type": "string"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
@@ -1777,6 +3345,9 @@ const docTemplate = `{
                 },
                 "name": {
                     "type": "string"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
@@ -1791,6 +3362,9 @@ const docTemplate = `{
                 },
                 "title": {
                     "type": "string"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
@@ -1850,6 +3424,76 @@ const docTemplate = `{
                 },
                 "min_width": {
                     "type": "integer"
+                },
+                "updated_at": {
+                    "type": "integer"
+                },
+                "version": {
+                    "description": "Version and UpdatedAt (unix seconds) of whole catalog",
+                    "type": "integer"
+                }
+            }
+        },
+        "views.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "views.FileUpload": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "length": {
+                    "type": "integer"
+                },
+                "mime": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer"
+                }
+            }
+        },
+        "views.FileUploadResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "file": {
+                    "type": "string"
+                },
+                "mime": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "views.Health": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ok"
                 }
             }
         },
@@ -1861,6 +3505,9 @@ const docTemplate = `{
                 },
                 "title": {
                     "type": "string"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
@@ -1891,6 +3538,12 @@ const docTemplate = `{
                 "description": {
                     "type": "string"
                 },
+                "gallery": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.ProductPhoto"
+                    }
+                },
                 "height": {
                     "type": "integer"
                 },
@@ -1921,6 +3574,13 @@ const docTemplate = `{
                 "title": {
                     "type": "string"
                 },
+                "updated_at": {
+                    "type": "integer"
+                },
+                "version": {
+                    "description": "Version grow on every change of product itself, UpdatedAt (unix seconds) on any change\nof what is returned with product",
+                    "type": "integer"
+                },
                 "width": {
                     "type": "integer"
                 }
@@ -2065,6 +3725,7 @@ const docTemplate = `{
                     }
                 },
                 "photos": {
+                    "description": "on update absent photos keep gallery, empty list clear it",
                     "type": "array",
                     "items": {
                         "type": "string"
@@ -2082,11 +3743,67 @@ const docTemplate = `{
                 "title": {
                     "type": "string"
                 },
+                "version": {
+                    "description": "Version is version of product update is based on. If-Match header may be used instead",
+                    "type": "integer"
+                },
                 "width": {
                     "type": "integer"
                 }
             }
         },
+        "views.ProductPhoto": {
+            "type": "object",
+            "properties": {
+                "alt": {
+                    "type": "string"
+                },
+                "color_id": {
+                    "type": "string"
+                },
+                "file": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_main": {
+                    "type": "boolean"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "views.ProductPhotosOrder": {
+            "type": "object",
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "product_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "views.ProductPrice": {
+            "type": "object",
+            "properties": {
+                "price": {
+                    "type": "integer"
+                },
+                "version": {
+                    "description": "Version is optional here (also If-Match). Without it price is applied to current product",
+                    "type": "integer"
+                }
+            }
+        },
         "views.SWGBrandListResponse": {
             "type": "object",
             "properties": {
@@ -2134,9 +3851,29 @@ const docTemplate = `{
         "views.SWGErrorResponse": {
             "type": "object",
             "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "NOT_FOUND"
+                },
+                "details": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
                 "error": {
                     "type": "string",
                     "example": "something went wrong"
+                },
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/views.FieldError"
+                    }
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "hYbqXWkAqkLzEoVxXlxBcfOQnxtfGJqZ"
                 }
             }
         },
@@ -2210,7 +3947,7 @@ var SwaggerInfo = &swag.Spec{
 	Version:          "0.1",
 	Host:             "localhost:8080",
 	BasePath:         "/",
-	Schemes:          []string{"http"},
+	Schemes:          []string{"http", "https"},
 	Title:            "Volha gateway REST API",
 	Description:      "",
 	InfoInstanceName: "swagger",
@@ -0,0 +1,84 @@
+package products
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"gateway/internal/pkg/auth"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Metadata keys read by product-service to check permissions of acting admin
+const (
+	ActorLoginKey = "x-actor-login"
+	ActorRoleKey  = "x-actor-role"
+	ActorPermsKey = "x-actor-perms"
+)
+
+// WithActor return context which send acting admin to product-service with every call.
+// perms are set for api keys, which have scopes instead of role
+func WithActor(ctx context.Context, login, role string, perms ...string) context.Context {
+	ctx = metadata.AppendToOutgoingContext(ctx, ActorLoginKey, login, ActorRoleKey, role)
+	if len(perms) > 0 {
+		ctx = metadata.AppendToOutgoingContext(ctx, ActorPermsKey, strings.Join(perms, ","))
+	}
+	return ctx
+}
+
+// GatewayActor is login of gateway when it act for itself, not for admin: it read admins on login
+// and session check, before anyone is logged in
+const GatewayActor = "gateway"
+
+// AsGateway return context in which gateway act for itself with users:manage permission.
+// Acting admin of ctx, if any, is replaced
+func AsGateway(ctx context.Context) context.Context {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+	md.Set(ActorLoginKey, GatewayActor)
+	md.Set(ActorRoleKey, "")
+	md.Set(ActorPermsKey, string(auth.PermUsers))
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// Metadata keys with signature of acting admin. product-service trust actor without mTLS only when it is signed
+const (
+	ActorTimeKey = "x-actor-ts"
+	ActorSigKey  = "x-actor-sig"
+)
+
+// signActor is unary interceptor that sign acting admin of call with secret. Without secret or
+// actor call go as is
+func signActor(secret string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		md, ok := metadata.FromOutgoingContext(ctx)
+		if secret == "" || !ok || (len(md.Get(ActorLoginKey)) == 0 && len(md.Get(ActorPermsKey)) == 0) {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		first := func(key string) string {
+			if v := md.Get(key); len(v) > 0 {
+				return v[0]
+			}
+			return ""
+		}
+		ts := strconv.FormatInt(time.Now().Unix(), 10)
+		sig := actorSignature([]byte(secret), method, first(ActorLoginKey), first(ActorRoleKey), first(ActorPermsKey), ts)
+		ctx = metadata.AppendToOutgoingContext(ctx, ActorTimeKey, ts, ActorSigKey, hex.EncodeToString(sig))
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// actorSignature is HMAC of method and actor fields, product-service check it same way
+func actorSignature(secret []byte, method, login, role, perms, ts string) []byte {
+	m := hmac.New(sha256.New, secret)
+	for _, s := range []string{method, login, role, perms, ts} {
+		m.Write([]byte(s))
+		m.Write([]byte{0})
+	}
+	return m.Sum(nil)
+}
@@ -0,0 +1,44 @@
+package products
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestSignActor(t *testing.T) {
+	const method = "/products.Products/DeleteProduct"
+	var got metadata.MD
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		got, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+
+	ctx := WithActor(context.Background(), "root", "owner")
+	if err := signActor("secret")(ctx, method, nil, nil, nil, invoker); err != nil {
+		t.Fatal(err)
+	}
+	ts := got.Get(ActorTimeKey)
+	if len(ts) != 1 {
+		t.Fatalf("no time: %v", got)
+	}
+	want := hex.EncodeToString(actorSignature([]byte("secret"), method, "root", "owner", "", ts[0]))
+	if sig := got.Get(ActorSigKey); len(sig) != 1 || sig[0] != want {
+		t.Fatalf("signature %v, want %s", sig, want)
+	}
+
+	// call without actor or secret is not signed
+	for _, tt := range []struct {
+		ctx    context.Context
+		secret string
+	}{{context.Background(), "secret"}, {ctx, ""}} {
+		got = nil
+		_ = signActor(tt.secret)(tt.ctx, method, nil, nil, nil, invoker)
+		if len(got.Get(ActorSigKey)) != 0 {
+			t.Fatal("call is signed")
+		}
+	}
+}
@@ -4,21 +4,27 @@ import (
 	"context"
 	"fmt"
 	"gateway/config"
+	"gateway/internal/pkg/breaker"
+	"gateway/internal/pkg/certs"
 	"gateway/internal/utils/format"
 	productsRPC "github.com/autumnterror/volha-proto/gen/products"
 	grpcretry "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/retry"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"log/slog"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/connectivity"
-	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/keepalive"
-	"log"
+	"google.golang.org/grpc/status"
 	"time"
 )
 
 type Client struct {
-	api productsRPC.ProductsClient
+	cc     *grpc.ClientConn
+	api    productsRPC.ProductsClient
+	health healthpb.HealthClient
 }
 
 func New(
@@ -27,23 +33,32 @@ func New(
 	const op = "grpc.products.New"
 
 	retryOpts := []grpcretry.CallOption{
-		grpcretry.WithCodes(codes.Aborted, codes.DeadlineExceeded),
-		grpcretry.WithMax(uint(cfg.RetriesCount)),
-		grpcretry.WithPerRetryTimeout(cfg.Timeout),
-		grpcretry.WithBackoff(grpcretry.BackoffExponential(cfg.Backoff)),
+		// Aborted is version conflict now, retry of it fail same way
+		grpcretry.WithCodes(codes.Unavailable, codes.DeadlineExceeded),
 	}
 
 	//logOpts := []grpclog.Option{
 	//	grpclog.WithLogOnEvents(grpclog.PayloadReceived, grpclog.PayloadSent),
 	//}
 
+	creds, err := certs.Client(cfg.GRPCTLS, "gateway")
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+
 	cc, err := grpc.NewClient(
 		cfg.AddrProducts,
 		grpc.WithChainUnaryInterceptor(
+			metrics,
+			signActor(cfg.ActorSecret),
+			breaker.New("products", cfg.BreakerFailures, cfg.BreakerCooldown).UnaryClientInterceptor(),
+			liveRetry(cfg),
 			grpcretry.UnaryClientInterceptor(retryOpts...),
 			//	grpclog.UnaryClientInterceptor(interceptorLogger(), logOpts...),
 		),
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(creds),
+		// trace context of http request go to product-service in metadata
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
 		grpc.WithKeepaliveParams(keepalive.ClientParameters{
 			Time:                5 * time.Minute,
 			Timeout:             11 * time.Second,
@@ -54,7 +69,7 @@ func New(
 		return nil, format.Error(op, err)
 	}
 
-	log.Println("start connection...")
+	slog.Info("start connection", "addr", cfg.AddrProducts)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -63,7 +78,7 @@ func New(
 	for {
 		state := cc.GetState()
 		if state == connectivity.Ready {
-			log.Println("CONNECT!!")
+			slog.Info("connected to product-service")
 			break
 		}
 
@@ -72,9 +87,46 @@ func New(
 		}
 	}
 
+	return NewFromConn(cc), nil
+}
+
+// NewFromConn make client over already created connection. Tests use it with bufconn
+func NewFromConn(cc *grpc.ClientConn) *Client {
 	return &Client{
-		api: productsRPC.NewProductsClient(cc),
-	}, nil
+		cc:     cc,
+		api:    productsRPC.NewProductsClient(cc),
+		health: healthpb.NewHealthClient(cc),
+	}
+}
+
+// liveRetry give retry interceptor count, timeout and backoff of current config, so they are
+// reloaded without restart. Options of call go after them, grpcretry.Disable() still work
+func liveRetry(cfg *config.Config) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		l := cfg.Live()
+		timedOut := false
+		opts = append([]grpc.CallOption{
+			grpcretry.WithMax(uint(l.RetriesCount)),
+			grpcretry.WithPerRetryTimeout(l.Timeout),
+			grpcretry.WithBackoff(grpcretry.BackoffExponential(l.Backoff)),
+			grpcretry.WithOnRetryCallback(func(_ context.Context, _ uint, err error) {
+				timedOut = timedOut || status.Code(err) == codes.DeadlineExceeded
+			}),
+		}, opts...)
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		// attempt which timed out could be applied, so Unavailable of later one do not mean
+		// that call did nothing. Callers treat Unavailable as not sent
+		if timedOut && status.Code(err) == codes.Unavailable {
+			return status.Error(codes.DeadlineExceeded, "attempt timed out, next one failed: "+status.Convert(err).Message())
+		}
+		return err
+	}
+}
+
+// Close close connection to product-service. Calls in flight are canceled
+func (c *Client) Close() error {
+	const op = "grpc.products.Close"
+	return format.Error(op, c.cc.Close())
 }
 
 //func interceptorLogger() grpclog.Logger {
@@ -0,0 +1,24 @@
+package products
+
+import (
+	"context"
+	"fmt"
+	"gateway/internal/utils/format"
+
+	grpcretry "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/retry"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Check ask product-service if it is serving. It is not retried, readiness probe is repeated anyway
+func (c *Client) Check(ctx context.Context) error {
+	const op = "grpc.client.Check"
+
+	resp, err := c.health.Check(ctx, &healthpb.HealthCheckRequest{}, grpcretry.Disable())
+	if err != nil {
+		return format.Error(op, err)
+	}
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		return format.Error(op, fmt.Errorf("product-service is %s", resp.GetStatus()))
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package products
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	handled = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_client_handled_total",
+		Help: "Total number of RPCs to product-service completed, by method and code.",
+	}, []string{"grpc_method", "grpc_code"})
+	handling = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_client_handling_seconds",
+		Help:    "Latency of RPCs to product-service with all retries, by method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"grpc_method"})
+)
+
+// metrics is first interceptor of client, so it see call as handler see it: with retries and
+// rejections of open breaker
+func metrics(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	start := time.Now()
+	err := invoker(ctx, method, req, reply, cc, opts...)
+
+	name := method[strings.LastIndex(method, "/")+1:]
+	handling.WithLabelValues(name).Observe(time.Since(start).Seconds())
+	handled.WithLabelValues(name, status.Code(err).String()).Inc()
+	return err
+}
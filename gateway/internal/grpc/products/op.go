@@ -2,14 +2,9 @@ package products
 
 import (
 	"context"
-	"errors"
 	"gateway/internal/utils/convert"
 	"gateway/internal/utils/format"
 	"gateway/internal/views"
-	"log"
-	"os"
-	"path/filepath"
-	"strings"
 
 	productsRPC "github.com/autumnterror/volha-proto/gen/products"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -58,49 +53,33 @@ func (c *Client) UpdateProduct(ctx context.Context, p *views.ProductId) error {
 	return nil
 }
 
-func (c *Client) DeleteProduct(ctx context.Context, id string) error {
-	const op = "grpc.client.DeleteProduct"
+// UpdateProductPrice change only price. Other fields are taken from current product, which is returned.
+// If version is 0 update is based on version just read, so it still fail if product change in between
+func (c *Client) UpdateProductPrice(ctx context.Context, id string, price int, version int64) (*views.Product, error) {
+	const op = "grpc.client.UpdateProductPrice"
 
-	exitCleanPhoto := make(chan bool, 1)
-	go func() {
-		resp, err := c.api.GetProduct(ctx, &productsRPC.Id{Id: id})
-		if err != nil {
-			exitCleanPhoto <- true
-			return
-		}
-		for _, path := range resp.Photos {
-			if err := deleteImage(path); err != nil {
-				log.Println(format.Error(op, err))
-			}
-		}
-		exitCleanPhoto <- true
-	}()
-
-	if _, err := c.api.DeleteProduct(ctx, &productsRPC.Id{Id: id}); err != nil {
-		return format.Error(op, err)
+	resp, err := c.api.GetProduct(ctx, &productsRPC.Id{Id: id})
+	if err != nil {
+		return nil, format.Error(op, err)
 	}
-	<-exitCleanPhoto
-	return nil
-}
-
-func deleteImage(relPath string) error {
-
-	path := filepath.Join("images", relPath)
-
-	cleanPath := strings.TrimPrefix(path, "./")
-	if !strings.HasPrefix(cleanPath, "images/") && !strings.HasPrefix(cleanPath, "images\\") {
-		return errors.New("invalid image path " + cleanPath)
+	pr := convert.ToProductView(resp)
+	pr.Price = price
+	if version != 0 {
+		pr.Version = version
 	}
 
-	fullPath := filepath.Join(".", cleanPath)
-	fullPath = filepath.Clean(fullPath)
-
-	if !strings.HasPrefix(fullPath, filepath.Clean("./images")) {
-		return errors.New("unsafe path")
+	if _, err := c.api.UpdateProduct(ctx, convert.ToProductIdRPCFromProduct(pr)); err != nil {
+		return nil, format.Error(op, err)
 	}
+	pr.Version++
+	return pr, nil
+}
 
-	if err := os.Remove(fullPath); err != nil {
-		return err
+func (c *Client) DeleteProduct(ctx context.Context, id string) error {
+	const op = "grpc.client.DeleteProduct"
+
+	if _, err := c.api.DeleteProduct(ctx, &productsRPC.Id{Id: id}); err != nil {
+		return format.Error(op, err)
 	}
 	return nil
 }
@@ -345,3 +324,91 @@ func (c *Client) GetPhotosByProductAndColor(ctx context.Context, productId, colo
 	}
 	return resp.GetPhotos(), nil
 }
+
+// Product photos
+
+func (c *Client) GetProductPhotos(ctx context.Context, productId string) ([]views.ProductPhoto, error) {
+	const op = "grpc.client.GetProductPhotos"
+	resp, err := c.api.GetProductPhotos(ctx, &productsRPC.Id{Id: productId})
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	return convert.ToPhotoViewList(resp.GetPhotos()), nil
+}
+
+func (c *Client) AddProductPhoto(ctx context.Context, p *views.ProductPhoto) error {
+	const op = "grpc.client.AddProductPhoto"
+	_, err := c.api.AddProductPhoto(ctx, convert.ToPhotoRPC(p))
+	return format.Error(op, err)
+}
+
+func (c *Client) UpdateProductPhoto(ctx context.Context, p *views.ProductPhoto) error {
+	const op = "grpc.client.UpdateProductPhoto"
+	_, err := c.api.UpdateProductPhoto(ctx, convert.ToPhotoRPC(p))
+	return format.Error(op, err)
+}
+
+func (c *Client) ReorderProductPhotos(ctx context.Context, o *views.ProductPhotosOrder) error {
+	const op = "grpc.client.ReorderProductPhotos"
+	_, err := c.api.ReorderProductPhotos(ctx, &productsRPC.ProductPhotosOrder{
+		ProductId: o.ProductId,
+		Ids:       o.Ids,
+	})
+	return format.Error(op, err)
+}
+
+func (c *Client) RemoveProductPhoto(ctx context.Context, id string) (*views.ProductPhoto, error) {
+	const op = "grpc.client.RemoveProductPhoto"
+	resp, err := c.api.RemoveProductPhoto(ctx, &productsRPC.Id{Id: id})
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	p := convert.ToPhotoView(resp)
+	return &p, nil
+}
+
+// Admins
+
+func (c *Client) GetAdmin(ctx context.Context, login string) (*views.AdminUser, error) {
+	const op = "grpc.client.GetAdmin"
+	resp, err := c.api.GetAdmin(ctx, &productsRPC.Id{Id: login})
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	u := convert.ToAdminView(resp)
+	return &u, nil
+}
+
+// GetAllAdmins return admins sorted by login without password hashes
+func (c *Client) GetAllAdmins(ctx context.Context) ([]views.AdminUser, error) {
+	const op = "grpc.client.GetAllAdmins"
+	resp, err := c.api.GetAllAdmins(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	out := make([]views.AdminUser, 0, len(resp.GetAdmins()))
+	for _, u := range resp.GetAdmins() {
+		out = append(out, convert.ToAdminView(u))
+	}
+	return out, nil
+}
+
+// CreateAdmin fail with AlreadyExists if login is busy
+func (c *Client) CreateAdmin(ctx context.Context, u *views.AdminUser) error {
+	const op = "grpc.client.CreateAdmin"
+	_, err := c.api.CreateAdmin(ctx, convert.ToAdminRPC(u))
+	return format.Error(op, err)
+}
+
+// UpdateAdmin change role and password hash of admin. Empty hash keep old password
+func (c *Client) UpdateAdmin(ctx context.Context, u *views.AdminUser) error {
+	const op = "grpc.client.UpdateAdmin"
+	_, err := c.api.UpdateAdmin(ctx, convert.ToAdminRPC(u))
+	return format.Error(op, err)
+}
+
+func (c *Client) DeleteAdmin(ctx context.Context, login string) error {
+	const op = "grpc.client.DeleteAdmin"
+	_, err := c.api.DeleteAdmin(ctx, &productsRPC.Id{Id: login})
+	return format.Error(op, err)
+}
@@ -0,0 +1,18 @@
+package products
+
+import (
+	"context"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// Metadata keys with http request of call, product-service write them in its logs
+const (
+	RequestIdKey = "x-request-id"
+	RouteKey     = "x-route"
+)
+
+// WithRequest return context which send id and route of http request to product-service with every call
+func WithRequest(ctx context.Context, id, route string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, RequestIdKey, id, RouteKey, route)
+}
@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gateway/internal/utils/format"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Server serve /metrics of default registry on internal port, it is not published with api
+type Server struct {
+	srv *http.Server
+}
+
+// New construct metrics server
+func New(port int) *Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	return &Server{
+		srv: &http.Server{
+			Addr:              fmt.Sprintf(":%d", port),
+			Handler:           mux,
+			ReadHeaderTimeout: 5 * time.Second,
+		},
+	}
+}
+
+func (s *Server) Run() error {
+	const op = "metrics.Run"
+
+	slog.Info("metrics server is running", "op", op, "addr", s.srv.Addr)
+	if err := s.srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return format.Error(op, err)
+	}
+	return nil
+}
+
+func (s *Server) Stop(ctx context.Context) error {
+	const op = "metrics.Stop"
+	return format.Error(op, s.srv.Shutdown(ctx))
+}
@@ -1,15 +1,21 @@
 package echo
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"gateway/config"
 	"gateway/internal/grpc/products"
 	"gateway/internal/net/handlers"
+	"gateway/internal/net/httperr"
 	"gateway/internal/net/mw"
+	"gateway/internal/pkg/auth"
 	"gateway/internal/pkg/redis"
 	"gateway/internal/utils/format"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
@@ -17,154 +23,273 @@ import (
 )
 
 type Echo struct {
-	e   *echo.Echo
-	cfg *config.Config
+	e      *echo.Echo
+	h      *handlers.Apis
+	cfg    *config.Config
+	cancel context.CancelFunc
 }
 
 const (
-	ImagesDir      = "./images"
-	MaxUploadBytes = 10 << 20 // 10 MB
+	ImagesDir           = "./images"
+	MaxUploadBytes      = 10 << 20 // 10 MB
+	MaxBatchUploadBytes = 200 << 20
 )
 
+const batchUploadPath = "/api/files/upload/batch"
+
 func New(rds *redis.Client, a *products.Client, cfg *config.Config) *Echo {
 	e := echo.New()
 
 	h := handlers.New(a, rds, cfg)
 
+	if err := h.EnsureAdmin(); err != nil {
+		slog.Error("ensure admin", "err", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go h.CleanUploads(ctx)
+
+	e.HTTPErrorHandler = httperr.Handler
+	e.IPExtractor = mw.IPExtractor(cfg)
+	e.Pre(mw.UntrustedForwarded(cfg))
+
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
-	e.Use(middleware.Logger(), middleware.Recover())
-	e.Use(middleware.BodyLimit(fmt.Sprintf("%d", MaxUploadBytes)))
-	e.Use(middleware.CORS())
-	e.Static("/images", ImagesDir)
+	e.GET("/healthz", h.Healthz)
+	e.GET("/readyz", h.Readyz)
+	e.Use(otelecho.Middleware("gateway", otelecho.WithSkipper(func(c echo.Context) bool {
+		switch c.Path() {
+		case "/healthz", "/readyz":
+			return true
+		}
+		return false
+	})))
+	e.Use(middleware.RequestIDWithConfig(middleware.RequestIDConfig{RequestIDHandler: mw.RequestFields}),
+		mw.Metrics(), mw.AccessLog(), middleware.Recover())
+	e.Use(mw.SecurityHeaders(cfg))
+	if cfg.HTTPS.Enabled {
+		e.Use(mw.HTTPSRedirect(cfg.HTTPS.Port))
+	}
+	e.Use(middleware.BodyLimitWithConfig(middleware.BodyLimitConfig{
+		Limit: fmt.Sprintf("%d", MaxUploadBytes),
+		Skipper: func(c echo.Context) bool {
+			return c.Path() == batchUploadPath
+		},
+	}))
+	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
+		ExposeHeaders: []string{echo.HeaderLocation, "Upload-Offset", "Upload-Length", "Tus-Resumable", mw.ReplayedHeader, echo.HeaderXRequestID},
+	}))
+	e.GET("/images*", echo.StaticDirectoryHandler(echo.MustSubFS(e.Filesystem, ImagesDir), false), mw.HideDotFiles())
 
-	userApi := e.Group("/api", mw.CheckId())
+	userApi := e.Group("/api", mw.CheckId(), mw.APIKey(rds), mw.RateLimit(cfg, rds, config.LimitPublic))
 	{
-		p := userApi.Group("/product")
+		search := mw.RateLimit(cfg, rds, config.LimitSearch)
+		// api key reading catalog must have catalog:read scope
+		read := mw.KeyRequire(auth.PermCatalogRead)
+
+		p := userApi.Group("/product", read)
 		{
-			p.GET("/search", h.SearchProducts)
-			p.POST("/filter", h.FilterProducts)
+			p.GET("/search", h.SearchProducts, search)
+			p.POST("/filter", h.FilterProducts, search)
 			p.GET("/getall", h.GetAllProducts)
 			p.GET("/get", h.GetProduct)
 		}
 
-		b := userApi.Group("/brand")
+		b := userApi.Group("/brand", read)
 		{
 			b.GET("/getall", h.GetAllBrands)
 		}
 
-		a := userApi.Group("/auth")
+		s := userApi.Group("/auth")
 		{
-			a.GET("/check", h.CheckPw)
+			s.POST("/login", h.Login, mw.RateLimit(cfg, rds, config.LimitAuth))
+			s.POST("/logout", h.Logout)
+			s.GET("/check", h.CheckPw, mw.AdminAuth(cfg, rds, a))
 		}
 
-		c := userApi.Group("/category")
+		c := userApi.Group("/category", read)
 		{
 			c.GET("/getall", h.GetAllCategories)
 		}
 
-		co := userApi.Group("/color")
+		co := userApi.Group("/color", read)
 		{
 			co.GET("/getall", h.GetAllColors)
 		}
 
-		m := userApi.Group("/material")
+		m := userApi.Group("/material", read)
 		{
 			m.GET("/getall", h.GetAllMaterials)
 		}
 
-		ct := userApi.Group("/country")
+		ct := userApi.Group("/country", read)
 		{
 			ct.GET("/getall", h.GetAllCountries)
 		}
 
-		dict := userApi.Group("/dictionaries")
+		dict := userApi.Group("/dictionaries", read)
 		{
 			dict.GET("/getall", h.GetAllDictionaries)
 			dict.GET("/getall/category", h.GetAllDictionariesByCategory)
 		}
 
-		cp := userApi.Group("/productcolorphotos")
+		cp := userApi.Group("/productcolorphotos", read)
 		{
 			cp.GET("/getall", h.GetAllProductColorPhotos)
 			cp.POST("/getphotos", h.GetPhotosByProductAndColor)
 		}
+
+		pp := userApi.Group("/productphotos", read)
+		{
+			pp.GET("/get", h.GetProductPhotos)
+		}
 	}
 
-	adminApi := e.Group("/api", mw.CheckId()) //, mw.AdminAuth(cfg)) //TODO ON IF COOKIE
+	adminApi := e.Group("/api", mw.CheckId(), mw.AdminAuth(cfg, rds, a), mw.RateLimit(cfg, rds, config.LimitAdmin))
 	{
-		f := adminApi.Group("/files")
+		write := mw.Require(auth.PermCatalogWrite)
+		prod := mw.Require(auth.PermProductsWrite)
+		del := mw.Require(auth.PermDelete)
+		// retries of create with same Idempotency-Key do not make duplicates
+		idem := mw.Idempotency(cfg, rds)
+
+		adminApi.PUT("/auth/password", h.ChangePassword, mw.RateLimit(cfg, rds, config.LimitAuth))
+
+		au := adminApi.Group("/auth/users", mw.Require(auth.PermUsers))
+		{
+			au.GET("/getall", h.GetAllAdmins)
+			au.POST("/create", h.CreateAdmin, idem)
+			au.PUT("/role", h.SetAdminRole)
+			au.DELETE("/delete", h.DeleteAdmin)
+		}
+
+		ak := adminApi.Group("/auth/keys", mw.Require(auth.PermUsers))
+		{
+			ak.GET("/getall", h.GetAllAPIKeys)
+			ak.POST("/create", h.CreateAPIKey)
+			ak.DELETE("/delete", h.DeleteAPIKey)
+		}
+
+		f := adminApi.Group("/files", mw.Require(auth.PermFilesUpload))
 		{
 			f.POST("/upload", h.UploadFile)
-			f.DELETE("/delete", h.DeleteFile)
+			f.POST("/upload/batch", h.UploadFiles, middleware.BodyLimit(fmt.Sprintf("%d", MaxBatchUploadBytes)))
+			f.DELETE("/delete", h.DeleteFile, del)
+
+			f.POST("/uploads", h.CreateUpload, idem)
+			f.HEAD("/uploads/:id", h.UploadStatus)
+			f.GET("/uploads/:id", h.UploadStatus)
+			f.PATCH("/uploads/:id", h.UploadChunk)
+			f.DELETE("/uploads/:id", h.CancelUpload)
 		}
 		p := adminApi.Group("/product")
 		{
-			p.POST("/create", h.CreateProduct)
-			p.PUT("/update", h.UpdateProduct)
-			p.DELETE("/delete", h.DeleteProduct)
+			p.POST("/create", h.CreateProduct, prod, idem)
+			p.PUT("/update", h.UpdateProduct, prod)
+			p.PUT("/price", h.UpdateProductPrice, mw.Require(auth.PermPriceWrite))
+			p.DELETE("/delete", h.DeleteProduct, del)
 		}
 
 		b := adminApi.Group("/brand")
 		{
-			b.POST("/create", h.CreateBrand)
-			b.PUT("/update", h.UpdateBrand)
-			b.DELETE("/delete", h.DeleteBrand)
+			b.POST("/create", h.CreateBrand, write, idem)
+			b.PUT("/update", h.UpdateBrand, write)
+			b.DELETE("/delete", h.DeleteBrand, del)
 		}
 
 		c := adminApi.Group("/category")
 		{
-			c.POST("/create", h.CreateCategory)
-			c.PUT("/update", h.UpdateCategory)
-			c.DELETE("/delete", h.DeleteCategory)
+			c.POST("/create", h.CreateCategory, write, idem)
+			c.PUT("/update", h.UpdateCategory, write)
+			c.DELETE("/delete", h.DeleteCategory, del)
 		}
 
 		co := adminApi.Group("/color")
 		{
-			co.POST("/create", h.CreateColor)
-			co.PUT("/update", h.UpdateColor)
-			co.DELETE("/delete", h.DeleteColor)
+			co.POST("/create", h.CreateColor, write, idem)
+			co.PUT("/update", h.UpdateColor, write)
+			co.DELETE("/delete", h.DeleteColor, del)
 		}
 
 		m := adminApi.Group("/material")
 		{
-			m.POST("/create", h.CreateMaterial)
-			m.PUT("/update", h.UpdateMaterial)
-			m.DELETE("/delete", h.DeleteMaterial)
+			m.POST("/create", h.CreateMaterial, write, idem)
+			m.PUT("/update", h.UpdateMaterial, write)
+			m.DELETE("/delete", h.DeleteMaterial, del)
 		}
 
 		ct := adminApi.Group("/country")
 		{
-			ct.POST("/create", h.CreateCountry)
-			ct.PUT("/update", h.UpdateCountry)
-			ct.DELETE("/delete", h.DeleteCountry)
+			ct.POST("/create", h.CreateCountry, write, idem)
+			ct.PUT("/update", h.UpdateCountry, write)
+			ct.DELETE("/delete", h.DeleteCountry, del)
 		}
 		cp := adminApi.Group("/productcolorphotos")
 		{
-			cp.POST("/create", h.CreateProductColorPhotos)
-			cp.PUT("/update", h.UpdateProductColorPhotos)
-			cp.DELETE("/delete", h.DeleteProductColorPhotos)
+			cp.POST("/create", h.CreateProductColorPhotos, write, idem)
+			cp.PUT("/update", h.UpdateProductColorPhotos, write)
+			cp.DELETE("/delete", h.DeleteProductColorPhotos, del)
+		}
+		pp := adminApi.Group("/productphotos", prod)
+		{
+			pp.POST("/add", h.AddProductPhoto, idem)
+			pp.PUT("/update", h.UpdateProductPhoto)
+			pp.PUT("/reorder", h.ReorderProductPhotos)
+			pp.DELETE("/delete", h.RemoveProductPhoto)
 		}
 	}
 
 	return &Echo{
-		e:   e,
-		cfg: cfg,
+		e:      e,
+		h:      h,
+		cfg:    cfg,
+		cancel: cancel,
 	}
 }
 
 func (e *Echo) MustRun() {
+	if err := e.Run(); err != nil {
+		e.e.Logger.Fatal(err)
+	}
+}
+
+// Run serve http on Port and, when it is enabled, https with h2 on HTTPS.Port. Plain http then only
+// redirect to https. It return when both servers are stopped or one of them fail
+func (e *Echo) Run() error {
 	const op = "echo.Run"
 
-	if err := e.e.Start(fmt.Sprintf(":%d", e.cfg.Port)); err != nil && !errors.Is(err, http.ErrServerClosed) {
-		e.e.Logger.Fatal(format.Error(op, err))
+	errs := make(chan error, 2)
+	go func() { errs <- e.e.Start(fmt.Sprintf(":%d", e.cfg.Port)) }()
+	servers := 1
+	if https := e.cfg.HTTPS; https.Enabled {
+		servers++
+		go func() { errs <- e.e.StartTLS(fmt.Sprintf(":%d", https.Port), https.CertFile, https.KeyFile) }()
+	}
+
+	for ; servers > 0; servers-- {
+		if err := <-errs; err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return format.Error(op, err)
+		}
 	}
+	return nil
 }
 
-func (e *Echo) Stop() error {
+// Stop make /readyz fail, wait ShutdownDelay and drain requests in flight until ctx is done.
+// Requests which are not finished by then are closed hard
+func (e *Echo) Stop(ctx context.Context) error {
 	const op = "echo.Stop"
 
-	if err := e.e.Close(); err != nil {
-		return format.Error(op, err)
+	e.h.Drain()
+	select {
+	case <-time.After(e.cfg.ShutdownDelay):
+	case <-ctx.Done():
+	}
+
+	e.cancel()
+	if err := e.e.Shutdown(ctx); err != nil {
+		slog.Warn("drain is not finished, closing", "op", op, "err", err)
+		if err := e.e.Close(); err != nil {
+			return format.Error(op, err)
+		}
 	}
 	return nil
 }
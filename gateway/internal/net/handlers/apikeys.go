@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"errors"
+	"gateway/internal/net/httperr"
+	"gateway/internal/pkg/auth"
+	"gateway/internal/pkg/redis"
+	"gateway/internal/views"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// CreateAPIKey godoc
+// @Summary Выпустить API ключ
+// @Description Создаёт ключ для внешних систем. Ключ возвращается только один раз, хранится лишь его хеш.
+// @Description Scopes: catalog:read (чтение каталога), products:write (изменение продуктов и цен, включает чтение), files:upload (загрузка файлов).
+// @Description Ключ передаётся в заголовке X-API-Key
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param key body views.APIKeyCreate true "Название и scopes ключа"
+// @Success 200 {object} views.APIKeyIssued "Ключ создан"
+// @Failure 400 {object} views.SWGErrorResponse "Неверный формат данных"
+// @Failure 500 {object} views.SWGErrorResponse "Ошибка на сервере"
+// @Router /api/auth/keys/create [post]
+func (a *Apis) CreateAPIKey(c echo.Context) error {
+	const op = "handlers.CreateAPIKey"
+
+	var req views.APIKeyCreate
+	if err := c.Bind(&req); err != nil {
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.Write(c, http.StatusBadRequest, "bad JSON")
+	}
+	if req.Name == "" {
+		return httperr.Write(c, http.StatusBadRequest, "missing name")
+	}
+	if len(req.Scopes) == 0 {
+		return httperr.Write(c, http.StatusBadRequest, "missing scopes")
+	}
+	for _, s := range req.Scopes {
+		if !auth.ValidScope(s) {
+			return httperr.Write(c, http.StatusBadRequest, "unknown scope "+s)
+		}
+	}
+
+	key, id, hash, err := auth.NewAPIKey()
+	if err != nil {
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.Write(c, http.StatusInternalServerError, "could not create key")
+	}
+
+	if err := a.rds.SetAPIKey(&views.APIKey{
+		Id:        id,
+		Name:      req.Name,
+		Scopes:    req.Scopes,
+		Hash:      hash,
+		CreatedBy: currentAdmin(c),
+		CreatedAt: time.Now().Unix(),
+	}); err != nil {
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.Write(c, http.StatusInternalServerError, "could not create key")
+	}
+
+	return c.JSON(http.StatusOK, views.APIKeyIssued{Id: id, Key: key})
+}
+
+// GetAllAPIKeys godoc
+// @Summary Получить все API ключи
+// @Description Возвращает ключи со scopes, числом запросов и временем последнего использования
+// @Tags auth
+// @Produce json
+// @Success 200 {object} []views.APIKey
+// @Failure 500 {object} views.SWGErrorResponse "Ошибка на сервере"
+// @Router /api/auth/keys/getall [get]
+func (a *Apis) GetAllAPIKeys(c echo.Context) error {
+	const op = "handlers.GetAllAPIKeys"
+
+	list, err := a.rds.GetAllAPIKeys()
+	if err != nil {
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.Write(c, http.StatusInternalServerError, "could not get keys")
+	}
+	return c.JSON(http.StatusOK, list)
+}
+
+// DeleteAPIKey godoc
+// @Summary Отозвать API ключ
+// @Description Удаляет ключ, после чего запросы с ним отклоняются
+// @Tags auth
+// @Produce json
+// @Param id query string true "ID ключа"
+// @Success 200 {object} views.SWGSuccessResponse "Ключ отозван"
+// @Failure 400 {object} views.SWGErrorResponse "Неверный ID"
+// @Failure 404 {object} views.SWGErrorResponse "Ключ не найден"
+// @Failure 500 {object} views.SWGErrorResponse "Ошибка на сервере"
+// @Router /api/auth/keys/delete [delete]
+func (a *Apis) DeleteAPIKey(c echo.Context) error {
+	const op = "handlers.DeleteAPIKey"
+
+	id := c.QueryParam("id")
+	if id == "" {
+		return httperr.Write(c, http.StatusBadRequest, "missing id")
+	}
+
+	if err := a.rds.DeleteAPIKey(id); err != nil {
+		if errors.Is(err, redis.ErrNotFound) {
+			return httperr.Write(c, http.StatusNotFound, "key not found")
+		}
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.Write(c, http.StatusInternalServerError, "could not delete key")
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"answer": "key revoked successfully"})
+}
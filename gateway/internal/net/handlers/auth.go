@@ -1,32 +1,438 @@
 package handlers
 
 import (
-	"github.com/labstack/echo/v4"
-	"log"
+	"context"
+	"fmt"
+	"gateway/internal/grpc/products"
+	"gateway/internal/net/httperr"
+	"gateway/internal/pkg/auth"
+	"gateway/internal/pkg/redis"
+	"gateway/internal/utils/format"
+	"gateway/internal/views"
+	"log/slog"
 	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-// CheckPw godoc
-// @Summary Проверить пароль
-// @Description проверяет пароль из cookie
+const MinPasswordLen = 8
+
+// adminsTimeout is timeout of calls to product-service about admins
+const adminsTimeout = 3 * time.Second
+
+// EnsureAdmin create first admin from config when there are no admins yet
+func (a *Apis) EnsureAdmin() error {
+	const op = "handlers.EnsureAdmin"
+
+	ctx, cancel := context.WithTimeout(context.Background(), adminsTimeout)
+	defer cancel()
+	ctx = products.AsGateway(ctx)
+
+	list, err := a.apiProduct.GetAllAdmins(ctx)
+	if err != nil {
+		return format.Error(op, err)
+	}
+	if len(list) > 0 {
+		return nil
+	}
+	if a.cfg.AdminPW == "" {
+		slog.Warn("there are no admins and admin_pw is empty, admin api is unavailable", "op", op)
+		return nil
+	}
+
+	hash, err := auth.HashPassword(a.cfg.AdminPW)
+	if err != nil {
+		return format.Error(op, err)
+	}
+	err = a.apiProduct.CreateAdmin(ctx, &views.AdminUser{
+		Login:        a.cfg.AdminLogin,
+		Role:         string(auth.RoleOwner),
+		PasswordHash: hash,
+		CreatedAt:    time.Now().Unix(),
+	})
+	// other replica of gateway could create it first
+	if err != nil && status.Code(err) != codes.AlreadyExists {
+		return format.Error(op, err)
+	}
+	slog.Info("created admin", "op", op, "login", a.cfg.AdminLogin)
+	return nil
+}
+
+// Login godoc
+// @Summary Вход администратора
+// @Description Проверяет логин и пароль и выдаёт cookie сессии. После нескольких неверных попыток вход блокируется на время
 // @Tags auth
+// @Accept json
 // @Produce json
-// @Success 200 {object} views.SWGSuccessResponse "Успешный запрос"
+// @Param credentials body views.AdminCredentials true "Логин и пароль"
+// @Success 200 {object} views.AdminUser "Успешный вход"
+// @Failure 400 {object} views.SWGErrorResponse "Неверный формат данных"
+// @Failure 401 {object} views.SWGErrorResponse "Неверный логин или пароль"
+// @Failure 429 {object} views.SWGErrorResponse "Слишком много попыток"
 // @Failure 500 {object} views.SWGErrorResponse "Ошибка на сервере"
-// @Failure 502 {object} views.SWGErrorResponse "Ошибка взаимодействия с сервисом"
+// @Router /api/auth/login [post]
+func (a *Apis) Login(c echo.Context) error {
+	const op = "handlers.Login"
+
+	var cr views.AdminCredentials
+	if err := c.Bind(&cr); err != nil {
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.Write(c, http.StatusBadRequest, "bad JSON")
+	}
+	if cr.Login == "" || cr.Password == "" {
+		return httperr.Write(c, http.StatusBadRequest, "login and password are required")
+	}
+
+	userKey := "user:" + cr.Login
+	ipKey := "ip:" + c.RealIP()
+
+	// attempt is counted as fail before bcrypt, right password take it back
+	for _, l := range []struct {
+		key string
+		max int64
+	}{{userKey, redis.MaxLoginFails}, {ipKey, redis.MaxLoginFailsIP}} {
+		n, ttl, err := a.rds.AddLoginFail(l.key)
+		if err != nil {
+			slog.ErrorContext(c.Request().Context(), op, "err", err)
+			return httperr.Write(c, http.StatusInternalServerError, "could not login")
+		}
+		if n > l.max {
+			c.Response().Header().Set("Retry-After", strconv.Itoa(int(ttl.Seconds())+1))
+			return httperr.Write(c, http.StatusTooManyRequests, "too many attempts, try later")
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), adminsTimeout)
+	defer cancel()
+
+	hash := ""
+	u, err := a.apiProduct.GetAdmin(products.AsGateway(ctx), cr.Login)
+	switch {
+	case err == nil:
+		hash = u.PasswordHash
+	case status.Code(err) != codes.NotFound:
+		slog.ErrorContext(ctx, op, "err", err)
+		return httperr.GRPC(c, err, "could not login")
+	}
+
+	if !auth.CheckPassword(hash, cr.Password) {
+		return httperr.Write(c, http.StatusUnauthorized, "wrong login or password")
+	}
+
+	if err := a.rds.ResetLoginFails(userKey); err != nil {
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+	}
+	if err := a.rds.UndoLoginFail(ipKey); err != nil {
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+	}
+
+	if err := a.startSession(c, u.Login); err != nil {
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.Write(c, http.StatusInternalServerError, "could not login")
+	}
+
+	u.PasswordHash = ""
+	return c.JSON(http.StatusOK, u)
+}
+
+// Logout godoc
+// @Summary Выход администратора
+// @Description Завершает текущую сессию и удаляет cookie
+// @Tags auth
+// @Produce json
+// @Success 200 {object} views.SWGSuccessResponse "Успешный выход"
+// @Router /api/auth/logout [post]
+func (a *Apis) Logout(c echo.Context) error {
+	const op = "handlers.Logout"
+
+	if cookie, err := c.Cookie(auth.CookieName); err == nil {
+		if sid, err := auth.ParseToken(a.cfg.SessionSecret, cookie.Value); err == nil {
+			if err := a.rds.DeleteSession(sid); err != nil {
+				slog.ErrorContext(c.Request().Context(), op, "err", err)
+			}
+		}
+	}
+
+	a.setSessionCookie(c, "", -1)
+	return c.JSON(http.StatusOK, map[string]string{"answer": "logged out"})
+}
+
+// CheckPw godoc
+// @Summary Проверить сессию
+// @Description Проверяет cookie сессии и возвращает логин и роль администратора
+// @Tags auth
+// @Produce json
+// @Success 200 {object} views.AdminUser "Сессия действительна"
+// @Failure 401 {object} views.SWGErrorResponse "Нет сессии"
 // @Router /api/auth/check [get]
 func (a *Apis) CheckPw(c echo.Context) error {
-	const op = "handlers.CheckPw"
-	log.Println(op)
+	role, _ := c.Get(auth.RoleKey).(auth.Role)
+	return c.JSON(http.StatusOK, views.AdminUser{Login: currentAdmin(c), Role: string(role)})
+}
+
+// GetAllAdmins godoc
+// @Summary Получить всех администраторов
+// @Description Возвращает список администраторов без хешей паролей
+// @Tags auth
+// @Produce json
+// @Success 200 {object} []views.AdminUser
+// @Failure 401 {object} views.SWGErrorResponse "Нет сессии"
+// @Failure 500 {object} views.SWGErrorResponse "Ошибка на сервере"
+// @Router /api/auth/users/getall [get]
+func (a *Apis) GetAllAdmins(c echo.Context) error {
+	const op = "handlers.GetAllAdmins"
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), adminsTimeout)
+	defer cancel()
+
+	list, err := a.apiProduct.GetAllAdmins(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, op, "err", err)
+		return httperr.GRPC(c, err, "could not get admins")
+	}
+	return c.JSON(http.StatusOK, list)
+}
+
+// CreateAdmin godoc
+// @Summary Создать администратора
+// @Description Добавляет администратора. Пароль не короче 8 символов. Роль: owner, content или pricing (по умолчанию content)
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param credentials body views.AdminCredentials true "Логин и пароль"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор с тем же ключом вернёт первый ответ"
+// @Success 200 {object} views.SWGSuccessResponse "Администратор создан"
+// @Failure 400 {object} views.SWGErrorResponse "Неверный формат данных"
+// @Failure 409 {object} views.SWGErrorResponse "Логин занят или запрос с этим ключом ещё выполняется"
+// @Failure 422 {object} views.SWGErrorResponse "Ключ уже использован с другим запросом"
+// @Failure 500 {object} views.SWGErrorResponse "Ошибка на сервере"
+// @Router /api/auth/users/create [post]
+func (a *Apis) CreateAdmin(c echo.Context) error {
+	const op = "handlers.CreateAdmin"
+
+	var cr views.AdminCredentials
+	if err := c.Bind(&cr); err != nil {
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.Write(c, http.StatusBadRequest, "bad JSON")
+	}
+	if cr.Login == "" {
+		return httperr.Write(c, http.StatusBadRequest, "missing login")
+	}
+	if len(cr.Password) < MinPasswordLen {
+		return httperr.Write(c, http.StatusBadRequest, fmt.Sprintf("password must be at least %d characters", MinPasswordLen))
+	}
 
-	cookie, err := c.Cookie("admin_pw")
+	if cr.Role == "" {
+		cr.Role = string(auth.RoleContent)
+	}
+	if !auth.Role(cr.Role).Valid() {
+		return httperr.Write(c, http.StatusBadRequest, "unknown role")
+	}
+
+	hash, err := auth.HashPassword(cr.Password)
+	if err != nil {
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.Write(c, http.StatusInternalServerError, "could not create admin")
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), adminsTimeout)
+	defer cancel()
+
+	err = a.apiProduct.CreateAdmin(ctx, &views.AdminUser{
+		Login:        cr.Login,
+		Role:         cr.Role,
+		PasswordHash: hash,
+		CreatedAt:    time.Now().Unix(),
+	})
+	if status.Code(err) == codes.AlreadyExists {
+		return httperr.Write(c, http.StatusConflict, "admin already exists")
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, op, "err", err)
+		return httperr.GRPC(c, err, "could not create admin")
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"answer": "admin created successfully"})
+}
+
+// DeleteAdmin godoc
+// @Summary Удалить администратора
+// @Description Удаляет администратора и все его сессии. Нельзя удалить самого себя
+// @Tags auth
+// @Produce json
+// @Param login query string true "Логин администратора"
+// @Success 200 {object} views.SWGSuccessResponse "Администратор удалён"
+// @Failure 400 {object} views.SWGErrorResponse "Неверный логин"
+// @Failure 404 {object} views.SWGErrorResponse "Администратор не найден"
+// @Failure 500 {object} views.SWGErrorResponse "Ошибка на сервере"
+// @Router /api/auth/users/delete [delete]
+func (a *Apis) DeleteAdmin(c echo.Context) error {
+	const op = "handlers.DeleteAdmin"
+
+	login := c.QueryParam("login")
+	if login == "" {
+		return httperr.Write(c, http.StatusBadRequest, "missing login")
+	}
+	if login == currentAdmin(c) {
+		return httperr.Write(c, http.StatusBadRequest, "can not delete yourself")
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), adminsTimeout)
+	defer cancel()
+
+	if err := a.apiProduct.DeleteAdmin(ctx, login); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return httperr.Write(c, http.StatusNotFound, "admin not found")
+		}
+		slog.ErrorContext(ctx, op, "err", err)
+		return httperr.GRPC(c, err, "could not delete admin")
+	}
+	if err := a.rds.DeleteAdminSessions(login); err != nil {
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"answer": "admin deleted successfully"})
+}
 
-	if err != nil || cookie.Value != a.cfg.AdminPW {
-		return c.JSON(http.StatusUnauthorized, map[string]string{
-			"error": "unauthorized",
-		})
+// SetAdminRole godoc
+// @Summary Изменить роль администратора
+// @Description Меняет роль администратора: owner, content или pricing. Нельзя менять свою роль
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param login query string true "Логин администратора"
+// @Param role body views.AdminRole true "Новая роль"
+// @Success 200 {object} views.SWGSuccessResponse "Роль изменена"
+// @Failure 400 {object} views.SWGErrorResponse "Неверный логин или роль"
+// @Failure 404 {object} views.SWGErrorResponse "Администратор не найден"
+// @Failure 500 {object} views.SWGErrorResponse "Ошибка на сервере"
+// @Router /api/auth/users/role [put]
+func (a *Apis) SetAdminRole(c echo.Context) error {
+	const op = "handlers.SetAdminRole"
+
+	login := c.QueryParam("login")
+	if login == "" {
+		return httperr.Write(c, http.StatusBadRequest, "missing login")
+	}
+	if login == currentAdmin(c) {
+		return httperr.Write(c, http.StatusBadRequest, "can not change your own role")
+	}
+
+	var r views.AdminRole
+	if err := c.Bind(&r); err != nil {
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.Write(c, http.StatusBadRequest, "bad JSON")
+	}
+	if !auth.Role(r.Role).Valid() {
+		return httperr.Write(c, http.StatusBadRequest, "unknown role")
 	}
-	return c.JSON(http.StatusOK, map[string]string{
-		"answer": "pw cool",
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), adminsTimeout)
+	defer cancel()
+
+	// without password hash only role is changed
+	if err := a.apiProduct.UpdateAdmin(ctx, &views.AdminUser{Login: login, Role: r.Role}); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return httperr.Write(c, http.StatusNotFound, "admin not found")
+		}
+		slog.ErrorContext(ctx, op, "err", err)
+		return httperr.GRPC(c, err, "could not change role")
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"answer": "role changed successfully"})
+}
+
+// ChangePassword godoc
+// @Summary Сменить пароль
+// @Description Меняет пароль текущего администратора. Все остальные сессии завершаются
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param passwords body views.AdminPasswordChange true "Старый и новый пароль"
+// @Success 200 {object} views.SWGSuccessResponse "Пароль изменён"
+// @Failure 400 {object} views.SWGErrorResponse "Неверный формат данных"
+// @Failure 401 {object} views.SWGErrorResponse "Неверный старый пароль"
+// @Failure 403 {object} views.SWGErrorResponse "Запрос с API ключом"
+// @Failure 500 {object} views.SWGErrorResponse "Ошибка на сервере"
+// @Router /api/auth/password [put]
+func (a *Apis) ChangePassword(c echo.Context) error {
+	const op = "handlers.ChangePassword"
+
+	var pc views.AdminPasswordChange
+	if err := c.Bind(&pc); err != nil {
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.Write(c, http.StatusBadRequest, "bad JSON")
+	}
+	if len(pc.New) < MinPasswordLen {
+		return httperr.Write(c, http.StatusBadRequest, fmt.Sprintf("password must be at least %d characters", MinPasswordLen))
+	}
+
+	// admin of any role change own password, so gateway do it itself
+	ctx, cancel := context.WithTimeout(c.Request().Context(), adminsTimeout)
+	defer cancel()
+	ctx = products.AsGateway(ctx)
+
+	u, err := a.apiProduct.GetAdmin(ctx, currentAdmin(c))
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return httperr.Write(c, http.StatusForbidden, "only admins have password")
+		}
+		slog.ErrorContext(ctx, op, "err", err)
+		return httperr.GRPC(c, err, "could not change password")
+	}
+	if !auth.CheckPassword(u.PasswordHash, pc.Old) {
+		return httperr.Write(c, http.StatusUnauthorized, "wrong password")
+	}
+
+	if u.PasswordHash, err = auth.HashPassword(pc.New); err != nil {
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.Write(c, http.StatusInternalServerError, "could not change password")
+	}
+	if err := a.apiProduct.UpdateAdmin(ctx, u); err != nil {
+		slog.ErrorContext(ctx, op, "err", err)
+		return httperr.GRPC(c, err, "could not change password")
+	}
+
+	if err := a.rds.DeleteAdminSessions(u.Login); err != nil {
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+	}
+	if err := a.startSession(c, u.Login); err != nil {
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"answer": "password changed successfully"})
+}
+
+func currentAdmin(c echo.Context) string {
+	login, _ := c.Get(auth.UserKey).(string)
+	return login
+}
+
+func (a *Apis) startSession(c echo.Context, login string) error {
+	token, sid, err := auth.NewToken(a.cfg.SessionSecret)
+	if err != nil {
+		return err
+	}
+	ttl := a.cfg.Live().SessionTTL
+	if err := a.rds.SetSession(sid, login, ttl); err != nil {
+		return err
+	}
+	a.setSessionCookie(c, token, int(ttl.Seconds()))
+	return nil
+}
+
+func (a *Apis) setSessionCookie(c echo.Context, value string, maxAge int) {
+	c.SetCookie(&http.Cookie{
+		Name:     auth.CookieName,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   c.Scheme() == "https",
+		SameSite: http.SameSiteLaxMode,
 	})
 }
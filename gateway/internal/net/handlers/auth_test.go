@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"context"
+	"gateway/internal/net/mw"
+	"gateway/internal/pkg/auth"
+	"gateway/internal/pkg/redis"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	productsRPC "github.com/autumnterror/volha-proto/gen/products"
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// fakeAdmins keep admins in memory like admin_users table of product-service
+type fakeAdmins struct {
+	productsRPC.UnimplementedProductsServer
+	mu     sync.Mutex
+	admins map[string]*productsRPC.AdminUser
+}
+
+func (f *fakeAdmins) GetAdmin(_ context.Context, id *productsRPC.Id) (*productsRPC.AdminUser, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	u, ok := f.admins[id.Id]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+	return proto.Clone(u).(*productsRPC.AdminUser), nil
+}
+
+func (f *fakeAdmins) GetAllAdmins(context.Context, *emptypb.Empty) (*productsRPC.AdminUserList, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	out := &productsRPC.AdminUserList{}
+	for _, u := range f.admins {
+		out.Admins = append(out.Admins, &productsRPC.AdminUser{Login: u.Login, Role: u.Role})
+	}
+	return out, nil
+}
+
+func (f *fakeAdmins) CreateAdmin(_ context.Context, u *productsRPC.AdminUser) (*emptypb.Empty, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.admins[u.Login]; ok {
+		return nil, status.Error(codes.AlreadyExists, "login already exists")
+	}
+	f.admins[u.Login] = u
+	return &emptypb.Empty{}, nil
+}
+
+func (f *fakeAdmins) UpdateAdmin(_ context.Context, u *productsRPC.AdminUser) (*emptypb.Empty, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	old, ok := f.admins[u.Login]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+	old.Role = u.Role
+	if u.PasswordHash != "" {
+		old.PasswordHash = u.PasswordHash
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (f *fakeAdmins) DeleteAdmin(_ context.Context, id *productsRPC.Id) (*emptypb.Empty, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.admins[id.Id]; !ok {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+	delete(f.admins, id.Id)
+	return &emptypb.Empty{}, nil
+}
+
+func newAuthApis(t *testing.T) *Apis {
+	t.Helper()
+	a, _ := newTestApis(t, &fakeAdmins{admins: map[string]*productsRPC.AdminUser{}})
+	a.cfg.AdminLogin = "root"
+	a.cfg.AdminPW = "root-password"
+	a.cfg.SessionSecret = "0123456789abcdef0123456789abcdef"
+	a.cfg.SessionTTL = time.Hour
+	if err := a.EnsureAdmin(); err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+// login return status and session cookie
+func login(t *testing.T, a *Apis, user, pw string) (int, *http.Cookie) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/login",
+		strings.NewReader(`{"login":"`+user+`","password":"`+pw+`"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	if err := a.Login(echo.New().NewContext(req, rec)); err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range rec.Result().Cookies() {
+		if c.Name == auth.CookieName {
+			return rec.Code, c
+		}
+	}
+	return rec.Code, nil
+}
+
+// check call /api/auth/check behind AdminAuth with cookie
+func check(t *testing.T, a *Apis, cookie *http.Cookie) int {
+	t.Helper()
+	e := echo.New()
+	e.GET("/api/auth/check", a.CheckPw, mw.AdminAuth(a.cfg, a.rds, a.apiProduct))
+	req := httptest.NewRequest(http.MethodGet, "/api/auth/check", nil)
+	req.AddCookie(cookie)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestLoginAndSession(t *testing.T) {
+	a := newAuthApis(t)
+
+	// second start do not create admin again
+	if err := a.EnsureAdmin(); err != nil {
+		t.Fatal(err)
+	}
+
+	if code, _ := login(t, a, "root", "wrong-password"); code != http.StatusUnauthorized {
+		t.Fatalf("wrong password: %d", code)
+	}
+	if code, _ := login(t, a, "nobody", "root-password"); code != http.StatusUnauthorized {
+		t.Fatalf("unknown login: %d", code)
+	}
+
+	code, cookie := login(t, a, "root", "root-password")
+	if code != http.StatusOK || cookie == nil {
+		t.Fatalf("login: %d", code)
+	}
+	if code := check(t, a, cookie); code != http.StatusOK {
+		t.Fatalf("session: %d", code)
+	}
+
+	// right password reset fails of login
+	if n, _, _ := a.rds.AddLoginFail("user:root"); n != 1 {
+		t.Fatalf("fails after successful login: %d", n-1)
+	}
+
+	// password change log out other sessions
+	_, other := login(t, a, "root", "root-password")
+	e := echo.New()
+	e.PUT("/api/auth/password", a.ChangePassword, mw.AdminAuth(a.cfg, a.rds, a.apiProduct))
+	req := httptest.NewRequest(http.MethodPut, "/api/auth/password",
+		strings.NewReader(`{"old":"root-password","new":"new-password"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.AddCookie(cookie)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("change password: %d %s", rec.Code, rec.Body)
+	}
+	if code := check(t, a, other); code != http.StatusUnauthorized {
+		t.Fatalf("old session after password change: %d", code)
+	}
+	if code, _ := login(t, a, "root", "new-password"); code != http.StatusOK {
+		t.Fatalf("login with new password: %d", code)
+	}
+
+	// deleted admin lose session at once
+	_, cookie = login(t, a, "root", "new-password")
+	if err := a.apiProduct.DeleteAdmin(context.Background(), "root"); err != nil {
+		t.Fatal(err)
+	}
+	if code := check(t, a, cookie); code != http.StatusUnauthorized {
+		t.Fatalf("session of deleted admin: %d", code)
+	}
+}
+
+func TestLoginLockout(t *testing.T) {
+	a := newAuthApis(t)
+
+	for range redis.MaxLoginFails {
+		if code, _ := login(t, a, "root", "wrong-password"); code != http.StatusUnauthorized {
+			t.Fatalf("wrong password: %d", code)
+		}
+	}
+	if code, _ := login(t, a, "root", "root-password"); code != http.StatusTooManyRequests {
+		t.Fatalf("locked login: %d", code)
+	}
+	// other login from same address is not locked yet
+	if code, _ := login(t, a, "other", "whatever-password"); code != http.StatusUnauthorized {
+		t.Fatalf("other login: %d", code)
+	}
+}
@@ -2,9 +2,10 @@ package handlers
 
 import (
 	"context"
-	"gateway/internal/utils/format"
+	"gateway/internal/net/httperr"
+	"gateway/internal/pkg/redis"
 	"gateway/internal/views"
-	"log"
+	"log/slog"
 	"net/http"
 	"time"
 
@@ -25,13 +26,13 @@ import (
 func (a *Apis) GetAllBrands(c echo.Context) error {
 	const op = "handlers.GetAllBrands"
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 3*time.Second)
 	defer cancel()
 
 	list, err := a.apiProduct.GetAllBrands(ctx)
 	if err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadGateway, map[string]string{"error": "failed to get brands"})
+		slog.ErrorContext(ctx, op, "err", err)
+		return httperr.GRPC(c, err, "failed to get brands")
 	}
 
 	if len(list) == 0 {
@@ -48,33 +49,35 @@ func (a *Apis) GetAllBrands(c echo.Context) error {
 // @Accept json
 // @Produce json
 // @Param brand body views.Brand true "Данные нового бренда"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор с тем же ключом вернёт первый ответ"
 // @Success 200 {object} views.SWGSuccessResponse
 // @Failure 400 {object} views.SWGErrorResponse
+// @Failure 409 {object} views.SWGErrorResponse "Запрос с этим ключом ещё выполняется или его результат неизвестен"
+// @Failure 422 {object} views.SWGErrorResponse "Ключ уже использован с другим запросом"
 // @Failure 500 {object} views.SWGErrorResponse
 // @Failure 502 {object} views.SWGErrorResponse
 // @Router /api/brand/create [post]
 func (a *Apis) CreateBrand(c echo.Context) error {
 	const op = "handlers.CreateBrand"
 
-	//delete cache dict
-	if err := a.rds.CleanDictionaries(); err != nil {
-		log.Println(format.Error(op, err))
-	}
-
 	var brand views.Brand
 	if err := c.Bind(&brand); err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid JSON"})
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.Write(c, http.StatusBadRequest, "invalid JSON")
 	}
 	brand.Id = xid.New().String()
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 3*time.Second)
 	defer cancel()
 
 	err := a.apiProduct.CreateBrand(ctx, &brand)
 	if err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadGateway, map[string]string{"error": "failed to create brand"})
+		slog.ErrorContext(ctx, op, "err", err)
+		return httperr.GRPC(c, err, "failed to create brand")
+	}
+
+	if err := a.rds.CleanDictionaries(); err != nil {
+		slog.ErrorContext(ctx, op, "err", err)
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{"id": brand.Id})
@@ -88,38 +91,47 @@ func (a *Apis) CreateBrand(c echo.Context) error {
 // @Produce json
 // @Param id query string true "ID бренда"
 // @Param brand body views.Brand true "Данные бренда"
+// @Param If-Match header string false "ETag объекта из GET, если версии нет в теле"
 // @Success 200 {object} views.SWGSuccessResponse
 // @Failure 400 {object} views.SWGErrorResponse
+// @Failure 409 {object} views.SWGErrorResponse "Объект уже изменён, в details.current_version текущая версия"
+// @Failure 412 {object} views.SWGErrorResponse "If-Match другого объекта"
+// @Failure 428 {object} views.SWGErrorResponse "Не передана версия"
 // @Failure 500 {object} views.SWGErrorResponse
 // @Failure 502 {object} views.SWGErrorResponse
 // @Router /api/brand/update [put]
 func (a *Apis) UpdateBrand(c echo.Context) error {
 	const op = "handlers.UpdateBrand"
 
-	//delete cache dict
-	if err := a.rds.CleanDictionaries(); err != nil {
-		log.Println(format.Error(op, err))
-	}
-
 	id := c.QueryParam("id")
 	if id == "" {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "missing id"})
+		return httperr.Write(c, http.StatusBadRequest, "missing id")
 	}
 
 	var brand views.Brand
 	if err := c.Bind(&brand); err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid JSON"})
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.Write(c, http.StatusBadRequest, "invalid JSON")
 	}
 	brand.Id = id
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	version, err := requestVersion(c, id, brand.Version)
+	if err != nil {
+		return versionError(c, err)
+	}
+	brand.Version = version
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 3*time.Second)
 	defer cancel()
 
-	err := a.apiProduct.UpdateBrand(ctx, &brand)
+	err = a.apiProduct.UpdateBrand(ctx, &brand)
 	if err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadGateway, map[string]string{"error": "failed to update brand"})
+		slog.ErrorContext(ctx, op, "err", err)
+		return httperr.GRPC(c, err, "failed to update brand")
+	}
+
+	if err := a.rds.InvalidateTags(redis.TagDictionaries, redis.TagBrand(id)); err != nil {
+		slog.ErrorContext(ctx, op, "err", err)
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{"answer": "brand updated"})
@@ -139,23 +151,22 @@ func (a *Apis) UpdateBrand(c echo.Context) error {
 func (a *Apis) DeleteBrand(c echo.Context) error {
 	const op = "handlers.DeleteBrand"
 
-	//delete cache dict
-	if err := a.rds.CleanDictionaries(); err != nil {
-		log.Println(format.Error(op, err))
-	}
-
 	id := c.QueryParam("id")
 	if id == "" {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "missing id"})
+		return httperr.Write(c, http.StatusBadRequest, "missing id")
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 3*time.Second)
 	defer cancel()
 
 	err := a.apiProduct.DeleteBrand(ctx, id)
 	if err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadGateway, map[string]string{"error": "failed to delete brand"})
+		slog.ErrorContext(ctx, op, "err", err)
+		return httperr.GRPC(c, err, "failed to delete brand")
+	}
+
+	if err := a.rds.InvalidateTags(redis.TagDictionaries, redis.TagBrand(id)); err != nil {
+		slog.ErrorContext(ctx, op, "err", err)
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{"answer": "brand deleted"})
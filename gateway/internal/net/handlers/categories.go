@@ -2,9 +2,10 @@ package handlers
 
 import (
 	"context"
-	"gateway/internal/utils/format"
+	"gateway/internal/net/httperr"
+	"gateway/internal/pkg/redis"
 	"gateway/internal/views"
-	"log"
+	"log/slog"
 	"net/http"
 	"time"
 
@@ -19,32 +20,34 @@ import (
 // @Accept json
 // @Produce json
 // @Param category body views.Category true "Новая категория"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор с тем же ключом вернёт первый ответ"
 // @Success 200 {object} views.SWGSuccessResponse "Категория успешно создана"
 // @Failure 400 {object} views.SWGErrorResponse "Неверный формат данных"
+// @Failure 409 {object} views.SWGErrorResponse "Запрос с этим ключом ещё выполняется или его результат неизвестен"
+// @Failure 422 {object} views.SWGErrorResponse "Ключ уже использован с другим запросом"
 // @Failure 500 {object} views.SWGErrorResponse "Ошибка на сервере"
 // @Failure 502 {object} views.SWGErrorResponse "Ошибка взаимодействия с сервисом"
 // @Router /api/category/create [post]
 func (a *Apis) CreateCategory(c echo.Context) error {
 	const op = "handlers.CreateCategory"
 
-	//delete cache dict
-	if err := a.rds.CleanDictionaries(); err != nil {
-		log.Println(format.Error(op, err))
-	}
-
 	var cat views.Category
 	if err := c.Bind(&cat); err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "bad JSON"})
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.Write(c, http.StatusBadRequest, "bad JSON")
 	}
 	cat.Id = xid.New().String()
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 3*time.Second)
 	defer cancel()
 
 	if err := a.apiProduct.CreateCategory(ctx, &cat); err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadGateway, map[string]string{"error": "could not create category"})
+		slog.ErrorContext(ctx, op, "err", err)
+		return httperr.GRPC(c, err, "could not create category")
+	}
+
+	if err := a.rds.CleanDictionaries(); err != nil {
+		slog.ErrorContext(ctx, op, "err", err)
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{"id": cat.Id})
@@ -58,37 +61,46 @@ func (a *Apis) CreateCategory(c echo.Context) error {
 // @Produce json
 // @Param id query string true "ID категории"
 // @Param category body views.Category true "Обновлённая категория"
+// @Param If-Match header string false "ETag объекта из GET, если версии нет в теле"
 // @Success 200 {object} views.SWGSuccessResponse "Категория успешно обновлена"
 // @Failure 400 {object} views.SWGErrorResponse "Неверный ID или формат"
+// @Failure 409 {object} views.SWGErrorResponse "Объект уже изменён, в details.current_version текущая версия"
+// @Failure 412 {object} views.SWGErrorResponse "If-Match другого объекта"
+// @Failure 428 {object} views.SWGErrorResponse "Не передана версия"
 // @Failure 500 {object} views.SWGErrorResponse "Ошибка на сервере"
 // @Failure 502 {object} views.SWGErrorResponse "Ошибка взаимодействия с сервисом"
 // @Router /api/category/update [put]
 func (a *Apis) UpdateCategory(c echo.Context) error {
 	const op = "handlers.UpdateCategory"
 
-	//delete cache dict
-	if err := a.rds.CleanDictionaries(); err != nil {
-		log.Println(format.Error(op, err))
-	}
-
 	id := c.QueryParam("id")
 	if id == "" {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "missing id"})
+		return httperr.Write(c, http.StatusBadRequest, "missing id")
 	}
 
 	var cat views.Category
 	if err := c.Bind(&cat); err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "bad JSON"})
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.Write(c, http.StatusBadRequest, "bad JSON")
 	}
 	cat.Id = id
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	version, err := requestVersion(c, id, cat.Version)
+	if err != nil {
+		return versionError(c, err)
+	}
+	cat.Version = version
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 3*time.Second)
 	defer cancel()
 
 	if err := a.apiProduct.UpdateCategory(ctx, &cat); err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadGateway, map[string]string{"error": "could not update category"})
+		slog.ErrorContext(ctx, op, "err", err)
+		return httperr.GRPC(c, err, "could not update category")
+	}
+
+	if err := a.rds.InvalidateTags(redis.TagDictionaries, redis.TagCategory(id)); err != nil {
+		slog.ErrorContext(ctx, op, "err", err)
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{"answer": "category updated successfully"})
@@ -108,22 +120,21 @@ func (a *Apis) UpdateCategory(c echo.Context) error {
 func (a *Apis) DeleteCategory(c echo.Context) error {
 	const op = "handlers.DeleteCategory"
 
-	//delete cache dict
-	if err := a.rds.CleanDictionaries(); err != nil {
-		log.Println(format.Error(op, err))
-	}
-
 	id := c.QueryParam("id")
 	if id == "" {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "missing id"})
+		return httperr.Write(c, http.StatusBadRequest, "missing id")
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 3*time.Second)
 	defer cancel()
 
 	if err := a.apiProduct.DeleteCategory(ctx, id); err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadGateway, map[string]string{"error": "could not delete category"})
+		slog.ErrorContext(ctx, op, "err", err)
+		return httperr.GRPC(c, err, "could not delete category")
+	}
+
+	if err := a.rds.InvalidateTags(redis.TagDictionaries, redis.TagCategory(id)); err != nil {
+		slog.ErrorContext(ctx, op, "err", err)
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{"answer": "category deleted successfully"})
@@ -141,13 +152,13 @@ func (a *Apis) DeleteCategory(c echo.Context) error {
 func (a *Apis) GetAllCategories(c echo.Context) error {
 	const op = "handlers.GetAllCategories"
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 3*time.Second)
 	defer cancel()
 
 	list, err := a.apiProduct.GetAllCategories(ctx)
 	if err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadGateway, map[string]string{"error": "failed to get categories"})
+		slog.ErrorContext(ctx, op, "err", err)
+		return httperr.GRPC(c, err, "failed to get categories")
 	}
 	if len(list) == 0 {
 		list = []views.Category{}
@@ -2,10 +2,10 @@ package handlers
 
 import (
 	"context"
-	"gateway/internal/utils/format"
+	"gateway/internal/net/httperr"
 	"gateway/internal/views"
 	"github.com/labstack/echo/v4"
-	"log"
+	"log/slog"
 	"net/http"
 	"time"
 )
@@ -17,8 +17,11 @@ import (
 // @Accept json
 // @Produce json
 // @Param photos body views.ProductColorPhotos true "Новый набор фотографий"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор с тем же ключом вернёт первый ответ"
 // @Success 200 {object} views.SWGSuccessResponse "Фотографии успешно добавлены"
 // @Failure 400 {object} views.SWGErrorResponse "Неверный формат данных"
+// @Failure 409 {object} views.SWGErrorResponse "Запрос с этим ключом ещё выполняется или его результат неизвестен"
+// @Failure 422 {object} views.SWGErrorResponse "Ключ уже использован с другим запросом"
 // @Failure 500 {object} views.SWGErrorResponse "Ошибка на сервере"
 // @Failure 502 {object} views.SWGErrorResponse "Ошибка взаимодействия с сервисом"
 // @Router /api/productcolorphotos/create [post]
@@ -27,16 +30,16 @@ func (a *Apis) CreateProductColorPhotos(c echo.Context) error {
 
 	var pcp views.ProductColorPhotos
 	if err := c.Bind(&pcp); err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "bad JSON"})
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.Write(c, http.StatusBadRequest, "bad JSON")
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 3*time.Second)
 	defer cancel()
 
 	if err := a.apiProduct.CreateProductColorPhotos(ctx, &pcp); err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadGateway, map[string]string{"error": "could not create product color photos"})
+		slog.ErrorContext(ctx, op, "err", err)
+		return httperr.GRPC(c, err, "could not create product color photos")
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{"answer": "product color photos created successfully"})
@@ -59,16 +62,16 @@ func (a *Apis) UpdateProductColorPhotos(c echo.Context) error {
 
 	var pcp views.ProductColorPhotos
 	if err := c.Bind(&pcp); err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "bad JSON"})
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.Write(c, http.StatusBadRequest, "bad JSON")
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 3*time.Second)
 	defer cancel()
 
 	if err := a.apiProduct.UpdateProductColorPhotos(ctx, &pcp); err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadGateway, map[string]string{"error": "could not update product color photos"})
+		slog.ErrorContext(ctx, op, "err", err)
+		return httperr.GRPC(c, err, "could not update product color photos")
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{"answer": "product color photos updated successfully"})
@@ -91,16 +94,16 @@ func (a *Apis) DeleteProductColorPhotos(c echo.Context) error {
 
 	var pcpId views.ProductColorPhotosId
 	if err := c.Bind(&pcpId); err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "bad JSON"})
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.Write(c, http.StatusBadRequest, "bad JSON")
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 3*time.Second)
 	defer cancel()
 
 	if err := a.apiProduct.DeleteProductColorPhotos(ctx, pcpId.ProductId, pcpId.ColorId); err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadGateway, map[string]string{"error": "could not delete product color photos"})
+		slog.ErrorContext(ctx, op, "err", err)
+		return httperr.GRPC(c, err, "could not delete product color photos")
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{"answer": "product color photos deleted successfully"})
@@ -118,13 +121,13 @@ func (a *Apis) DeleteProductColorPhotos(c echo.Context) error {
 func (a *Apis) GetAllProductColorPhotos(c echo.Context) error {
 	const op = "handlers.GetAllProductColorPhotos"
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 3*time.Second)
 	defer cancel()
 
 	list, err := a.apiProduct.GetAllProductColorPhotos(ctx)
 	if err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadGateway, map[string]string{"error": "failed to get product color photos"})
+		slog.ErrorContext(ctx, op, "err", err)
+		return httperr.GRPC(c, err, "failed to get product color photos")
 	}
 
 	if len(list) == 0 {
@@ -151,17 +154,17 @@ func (a *Apis) GetPhotosByProductAndColor(c echo.Context) error {
 
 	var pcpId views.ProductColorPhotosId
 	if err := c.Bind(&pcpId); err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "bad JSON"})
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.Write(c, http.StatusBadRequest, "bad JSON")
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 3*time.Second)
 	defer cancel()
 
 	photos, err := a.apiProduct.GetPhotosByProductAndColor(ctx, pcpId.ProductId, pcpId.ColorId)
 	if err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadGateway, map[string]string{"error": "failed to get photos"})
+		slog.ErrorContext(ctx, op, "err", err)
+		return httperr.GRPC(c, err, "failed to get photos")
 	}
 
 	return c.JSON(http.StatusOK, photos)
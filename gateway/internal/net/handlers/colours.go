@@ -2,9 +2,10 @@ package handlers
 
 import (
 	"context"
-	"gateway/internal/utils/format"
+	"gateway/internal/net/httperr"
+	"gateway/internal/pkg/redis"
 	"gateway/internal/views"
-	"log"
+	"log/slog"
 	"net/http"
 	"time"
 
@@ -19,32 +20,34 @@ import (
 // @Accept json
 // @Produce json
 // @Param color body views.Color true "Новый цвет"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор с тем же ключом вернёт первый ответ"
 // @Success 200 {object} views.SWGSuccessResponse "Цвет успешно создан"
 // @Failure 400 {object} views.SWGErrorResponse "Неверный формат данных"
+// @Failure 409 {object} views.SWGErrorResponse "Запрос с этим ключом ещё выполняется или его результат неизвестен"
+// @Failure 422 {object} views.SWGErrorResponse "Ключ уже использован с другим запросом"
 // @Failure 500 {object} views.SWGErrorResponse "Ошибка на сервере"
 // @Failure 502 {object} views.SWGErrorResponse "Ошибка взаимодействия с сервисом"
 // @Router /api/color/create [post]
 func (a *Apis) CreateColor(c echo.Context) error {
 	const op = "handlers.CreateColor"
 
-	//delete cache dict
-	if err := a.rds.CleanDictionaries(); err != nil {
-		log.Println(format.Error(op, err))
-	}
-
 	var clr views.Color
 	if err := c.Bind(&clr); err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "bad JSON"})
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.Write(c, http.StatusBadRequest, "bad JSON")
 	}
 	clr.Id = xid.New().String()
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 3*time.Second)
 	defer cancel()
 
 	if err := a.apiProduct.CreateColor(ctx, &clr); err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadGateway, map[string]string{"error": "could not create color"})
+		slog.ErrorContext(ctx, op, "err", err)
+		return httperr.GRPC(c, err, "could not create color")
+	}
+
+	if err := a.rds.CleanDictionaries(); err != nil {
+		slog.ErrorContext(ctx, op, "err", err)
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{"id": clr.Id})
@@ -58,37 +61,46 @@ func (a *Apis) CreateColor(c echo.Context) error {
 // @Produce json
 // @Param id query string true "ID цвета"
 // @Param color body views.Color true "Обновлённый цвет"
+// @Param If-Match header string false "ETag объекта из GET, если версии нет в теле"
 // @Success 200 {object} views.SWGSuccessResponse "Цвет успешно обновлён"
 // @Failure 400 {object} views.SWGErrorResponse "Неверный ID или данные"
+// @Failure 409 {object} views.SWGErrorResponse "Объект уже изменён, в details.current_version текущая версия"
+// @Failure 412 {object} views.SWGErrorResponse "If-Match другого объекта"
+// @Failure 428 {object} views.SWGErrorResponse "Не передана версия"
 // @Failure 500 {object} views.SWGErrorResponse "Ошибка на сервере"
 // @Failure 502 {object} views.SWGErrorResponse "Ошибка взаимодействия с сервисом"
 // @Router /api/color/update [put]
 func (a *Apis) UpdateColor(c echo.Context) error {
 	const op = "handlers.UpdateColor"
 
-	//delete cache dict
-	if err := a.rds.CleanDictionaries(); err != nil {
-		log.Println(format.Error(op, err))
-	}
-
 	id := c.QueryParam("id")
 	if id == "" {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "missing id"})
+		return httperr.Write(c, http.StatusBadRequest, "missing id")
 	}
 
 	var clr views.Color
 	if err := c.Bind(&clr); err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "bad JSON"})
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.Write(c, http.StatusBadRequest, "bad JSON")
 	}
 	clr.Id = id
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	version, err := requestVersion(c, id, clr.Version)
+	if err != nil {
+		return versionError(c, err)
+	}
+	clr.Version = version
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 3*time.Second)
 	defer cancel()
 
 	if err := a.apiProduct.UpdateColor(ctx, &clr); err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadGateway, map[string]string{"error": "could not update color"})
+		slog.ErrorContext(ctx, op, "err", err)
+		return httperr.GRPC(c, err, "could not update color")
+	}
+
+	if err := a.rds.InvalidateTags(redis.TagDictionaries, redis.TagColor(id)); err != nil {
+		slog.ErrorContext(ctx, op, "err", err)
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{"answer": "color updated successfully"})
@@ -108,22 +120,21 @@ func (a *Apis) UpdateColor(c echo.Context) error {
 func (a *Apis) DeleteColor(c echo.Context) error {
 	const op = "handlers.DeleteColor"
 
-	//delete cache dict
-	if err := a.rds.CleanDictionaries(); err != nil {
-		log.Println(format.Error(op, err))
-	}
-
 	id := c.QueryParam("id")
 	if id == "" {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "missing id"})
+		return httperr.Write(c, http.StatusBadRequest, "missing id")
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 3*time.Second)
 	defer cancel()
 
 	if err := a.apiProduct.DeleteColor(ctx, id); err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadGateway, map[string]string{"error": "could not delete color"})
+		slog.ErrorContext(ctx, op, "err", err)
+		return httperr.GRPC(c, err, "could not delete color")
+	}
+
+	if err := a.rds.InvalidateTags(redis.TagDictionaries, redis.TagColor(id)); err != nil {
+		slog.ErrorContext(ctx, op, "err", err)
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{"answer": "color deleted successfully"})
@@ -141,13 +152,13 @@ func (a *Apis) DeleteColor(c echo.Context) error {
 func (a *Apis) GetAllColors(c echo.Context) error {
 	const op = "handlers.GetAllColors"
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 3*time.Second)
 	defer cancel()
 
 	list, err := a.apiProduct.GetAllColors(ctx)
 	if err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadGateway, map[string]string{"error": "failed to get colors"})
+		slog.ErrorContext(ctx, op, "err", err)
+		return httperr.GRPC(c, err, "failed to get colors")
 	}
 
 	if len(list) == 0 {
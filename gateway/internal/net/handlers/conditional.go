@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gateway/internal/net/httperr"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	errNoVersion   = errors.New("no version")
+	errTagMismatch = errors.New("entity tag is not of this object")
+)
+
+// entityTag of single object. Version is what If-Match is checked against, updatedAt is in tag
+// because object also change when something returned with it (brand, category...) is renamed
+func entityTag(id string, version, updatedAt int64) string {
+	return fmt.Sprintf(`W/"%s-%d-%d"`, id, version, updatedAt)
+}
+
+// conditional write v as JSON with ETag (etag, or hash of body when it is empty), Last-Modified
+// (if modified, unix seconds, is known) and Cache-Control of route. When client already has same
+// version it answer 304 without body, or 412 when method is not GET or HEAD
+func (a *Apis) conditional(c echo.Context, v any, etag string, modified int64) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if etag == "" {
+		sum := sha256.Sum256(body)
+		etag = `"` + hex.EncodeToString(sum[:12]) + `"`
+	}
+
+	h := c.Response().Header()
+	h.Set("ETag", etag)
+	if h.Get("Cache-Control") == "" {
+		if cc, ok := a.cfg.Live().CacheControl[c.Path()]; ok {
+			h.Set("Cache-Control", cc)
+		}
+	}
+	var lastModified time.Time
+	if modified > 0 {
+		lastModified = time.Unix(modified, 0).UTC()
+		h.Set("Last-Modified", lastModified.Format(http.TimeFormat))
+	}
+
+	if notModified(c.Request(), etag, lastModified) {
+		// 304 is only for GET and HEAD, other methods (POST of filter) get 412 as RFC 9110 say
+		if m := c.Request().Method; m != http.MethodGet && m != http.MethodHead {
+			return httperr.Write(c, http.StatusPreconditionFailed, "If-None-Match matches current result")
+		}
+		return c.NoContent(http.StatusNotModified)
+	}
+	return c.Blob(http.StatusOK, echo.MIMEApplicationJSON, body)
+}
+
+// notModified check If-None-Match (weak comparison) and, only without it, If-Modified-Since as RFC 9110 say
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		for _, t := range strings.Split(inm, ",") {
+			t = strings.TrimSpace(t)
+			if t == "*" || strings.TrimPrefix(t, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		}
+		return false
+	}
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+	if lastModified.IsZero() {
+		return false
+	}
+	ims, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	return !lastModified.After(ims)
+}
+
+// requestVersion return version update of object id is based on: from body, or if it is not set from
+// If-Match header holding ETag got from GET (W/"<id>-<version>-<updated_at>", updated_at may be omitted).
+// errNoVersion when client sent neither, errTagMismatch when tag is of other object or broken
+func requestVersion(c echo.Context, id string, body int64) (int64, error) {
+	if body > 0 {
+		return body, nil
+	}
+	tag := strings.TrimSpace(c.Request().Header.Get("If-Match"))
+	if tag == "" {
+		return 0, errNoVersion
+	}
+	tag = strings.Trim(strings.TrimPrefix(tag, "W/"), `"`)
+
+	rest, ok := strings.CutPrefix(tag, id+"-")
+	if !ok {
+		return 0, errTagMismatch
+	}
+	rest, _, _ = strings.Cut(rest, "-")
+	v, err := strconv.ParseInt(rest, 10, 64)
+	if err != nil || v <= 0 {
+		return 0, errTagMismatch
+	}
+	return v, nil
+}
+
+// versionError answer on error of requestVersion
+func versionError(c echo.Context, err error) error {
+	if errors.Is(err, errTagMismatch) {
+		return httperr.Write(c, http.StatusPreconditionFailed, "If-Match does not match this object")
+	}
+	return httperr.Write(c, http.StatusPreconditionRequired, "missing version, send it in body or ETag in If-Match header")
+}
@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"gateway/config"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestConditional(t *testing.T) {
+	a := &Apis{cfg: &config.Config{}}
+	const etag = `W/"1-2-3"`
+
+	for _, tt := range []struct {
+		method string
+		inm    string
+		code   int
+	}{
+		{http.MethodGet, "", http.StatusOK},
+		{http.MethodGet, etag, http.StatusNotModified},
+		{http.MethodHead, `"1-2-3"`, http.StatusNotModified},
+		{http.MethodGet, `W/"1-2-4"`, http.StatusOK},
+		{http.MethodPost, etag, http.StatusPreconditionFailed},
+		{http.MethodPost, `W/"other"`, http.StatusOK},
+	} {
+		req := httptest.NewRequest(tt.method, "/api/product/filter", nil)
+		if tt.inm != "" {
+			req.Header.Set("If-None-Match", tt.inm)
+		}
+		rec := httptest.NewRecorder()
+		if err := a.conditional(echo.New().NewContext(req, rec), map[string]int{"a": 1}, etag, 0); err != nil {
+			t.Fatal(err)
+		}
+		if rec.Code != tt.code {
+			t.Errorf("%s If-None-Match %s: %d", tt.method, tt.inm, rec.Code)
+		}
+	}
+}
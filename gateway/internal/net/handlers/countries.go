@@ -2,9 +2,10 @@ package handlers
 
 import (
 	"context"
-	"gateway/internal/utils/format"
+	"gateway/internal/net/httperr"
+	"gateway/internal/pkg/redis"
 	"gateway/internal/views"
-	"log"
+	"log/slog"
 	"net/http"
 	"time"
 
@@ -19,32 +20,34 @@ import (
 // @Accept json
 // @Produce json
 // @Param country body views.Country true "Новая страна"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор с тем же ключом вернёт первый ответ"
 // @Success 200 {object} views.SWGSuccessResponse "Страна успешно создана"
 // @Failure 400 {object} views.SWGErrorResponse "Неверный формат данных"
+// @Failure 409 {object} views.SWGErrorResponse "Запрос с этим ключом ещё выполняется или его результат неизвестен"
+// @Failure 422 {object} views.SWGErrorResponse "Ключ уже использован с другим запросом"
 // @Failure 500 {object} views.SWGErrorResponse "Ошибка на сервере"
 // @Failure 502 {object} views.SWGErrorResponse "Ошибка взаимодействия с сервисом"
 // @Router /api/country/create [post]
 func (a *Apis) CreateCountry(c echo.Context) error {
 	const op = "handlers.CreateCountry"
 
-	//delete cache dict
-	if err := a.rds.CleanDictionaries(); err != nil {
-		log.Println(format.Error(op, err))
-	}
-
 	var ctr views.Country
 	if err := c.Bind(&ctr); err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "bad JSON"})
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.Write(c, http.StatusBadRequest, "bad JSON")
 	}
 	ctr.Id = xid.New().String()
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 3*time.Second)
 	defer cancel()
 
 	if err := a.apiProduct.CreateCountry(ctx, &ctr); err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadGateway, map[string]string{"error": "could not create country"})
+		slog.ErrorContext(ctx, op, "err", err)
+		return httperr.GRPC(c, err, "could not create country")
+	}
+
+	if err := a.rds.CleanDictionaries(); err != nil {
+		slog.ErrorContext(ctx, op, "err", err)
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{"id": ctr.Id})
@@ -58,37 +61,46 @@ func (a *Apis) CreateCountry(c echo.Context) error {
 // @Produce json
 // @Param id query string true "ID страны"
 // @Param country body views.Country true "Обновлённые данные страны"
+// @Param If-Match header string false "ETag объекта из GET, если версии нет в теле"
 // @Success 200 {object} views.SWGSuccessResponse "Страна успешно обновлена"
 // @Failure 400 {object} views.SWGErrorResponse "Неверный ID или данные"
+// @Failure 409 {object} views.SWGErrorResponse "Объект уже изменён, в details.current_version текущая версия"
+// @Failure 412 {object} views.SWGErrorResponse "If-Match другого объекта"
+// @Failure 428 {object} views.SWGErrorResponse "Не передана версия"
 // @Failure 500 {object} views.SWGErrorResponse "Ошибка на сервере"
 // @Failure 502 {object} views.SWGErrorResponse "Ошибка взаимодействия с сервисом"
 // @Router /api/country/update [put]
 func (a *Apis) UpdateCountry(c echo.Context) error {
 	const op = "handlers.UpdateCountry"
 
-	//delete cache dict
-	if err := a.rds.CleanDictionaries(); err != nil {
-		log.Println(format.Error(op, err))
-	}
-
 	id := c.QueryParam("id")
 	if id == "" {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "missing id"})
+		return httperr.Write(c, http.StatusBadRequest, "missing id")
 	}
 
 	var ctr views.Country
 	if err := c.Bind(&ctr); err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "bad JSON"})
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.Write(c, http.StatusBadRequest, "bad JSON")
 	}
 	ctr.Id = id
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	version, err := requestVersion(c, id, ctr.Version)
+	if err != nil {
+		return versionError(c, err)
+	}
+	ctr.Version = version
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 3*time.Second)
 	defer cancel()
 
 	if err := a.apiProduct.UpdateCountry(ctx, &ctr); err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadGateway, map[string]string{"error": "could not update country"})
+		slog.ErrorContext(ctx, op, "err", err)
+		return httperr.GRPC(c, err, "could not update country")
+	}
+
+	if err := a.rds.InvalidateTags(redis.TagDictionaries, redis.TagCountry(id)); err != nil {
+		slog.ErrorContext(ctx, op, "err", err)
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{"answer": "country updated successfully"})
@@ -108,22 +120,21 @@ func (a *Apis) UpdateCountry(c echo.Context) error {
 func (a *Apis) DeleteCountry(c echo.Context) error {
 	const op = "handlers.DeleteCountry"
 
-	//delete cache dict
-	if err := a.rds.CleanDictionaries(); err != nil {
-		log.Println(format.Error(op, err))
-	}
-
 	id := c.QueryParam("id")
 	if id == "" {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "missing id"})
+		return httperr.Write(c, http.StatusBadRequest, "missing id")
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 3*time.Second)
 	defer cancel()
 
 	if err := a.apiProduct.DeleteCountry(ctx, id); err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadGateway, map[string]string{"error": "could not delete country"})
+		slog.ErrorContext(ctx, op, "err", err)
+		return httperr.GRPC(c, err, "could not delete country")
+	}
+
+	if err := a.rds.InvalidateTags(redis.TagDictionaries, redis.TagCountry(id)); err != nil {
+		slog.ErrorContext(ctx, op, "err", err)
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{"answer": "country deleted successfully"})
@@ -141,13 +152,13 @@ func (a *Apis) DeleteCountry(c echo.Context) error {
 func (a *Apis) GetAllCountries(c echo.Context) error {
 	const op = "handlers.GetAllCountries"
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 3*time.Second)
 	defer cancel()
 
 	list, err := a.apiProduct.GetAllCountries(ctx)
 	if err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadGateway, map[string]string{"error": "failed to get countries"})
+		slog.ErrorContext(ctx, op, "err", err)
+		return httperr.GRPC(c, err, "failed to get countries")
 	}
 	if len(list) == 0 {
 		list = []views.Country{}
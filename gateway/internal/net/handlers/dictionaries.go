@@ -2,15 +2,14 @@ package handlers
 
 import (
 	"context"
-	"gateway/internal/utils/format"
+	"gateway/config"
+	"gateway/internal/net/httperr"
+	"gateway/internal/pkg/redis"
 	"gateway/internal/views"
-	"log"
+	"log/slog"
 	"net/http"
-	"time"
 
 	"github.com/labstack/echo/v4"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 // GetAllDictionariesByCategory godoc
@@ -19,7 +18,9 @@ import (
 // @Tags dictionaries
 // @Produce json
 // @Param id query string true "ID категории"
+// @Param If-None-Match header string false "ETag из прошлого ответа"
 // @Success 200 {object} views.Dictionaries "Успешный запрос"
+// @Success 304 "Данные не изменились"
 // @Failure 500 {object} views.SWGErrorResponse "Ошибка на сервере"
 // @Failure 502 {object} views.SWGErrorResponse "Ошибка взаимодействия с сервисом"
 // @Router /api/dictionaries/getall/category [get]
@@ -28,53 +29,36 @@ func (a *Apis) GetAllDictionariesByCategory(c echo.Context) error {
 
 	id := c.QueryParam("id")
 	if id == "" {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "missing id"})
+		return httperr.Write(c, http.StatusBadRequest, "missing id")
 	}
 
-	//check cache
-	if ds, err := a.rds.GetDictionariesByCategory(id); err == nil {
-		log.Println("read dict from cache", ds)
-		return c.JSON(http.StatusOK, ds)
-	}
-	log.Println("no dict on cache")
-
-	//if not in cache get
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-	defer cancel()
-
-	data, err := a.apiProduct.GetAllDictionariesByCategory(ctx, id)
-	if err != nil {
-		if st, ok := status.FromError(err); ok {
-			switch st.Code() {
-			case codes.Internal:
-				log.Println(format.Error(op, err))
-				return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal service error"})
-			default:
-				log.Println(format.Error(op, err))
-				return c.JSON(http.StatusBadGateway, map[string]string{"error": "failed to get dictionaries"})
-			}
+	var data views.Dictionaries
+	stale, err := a.rds.Fetch(c.Request().Context(), redis.DictionariesByCategoryKey(id), &data, a.cfg.Live().CacheTTL[config.CacheDictionaries], func(ctx context.Context) (any, []string, error) {
+		data, err := a.apiProduct.GetAllDictionariesByCategory(ctx, id)
+		if err != nil {
+			return nil, nil, err
 		}
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadGateway, map[string]string{"error": "gateway error"})
-	}
-
-	if len(data.Materials) == 0 {
-		data.Materials = []views.Material{}
-	}
-	if len(data.Colors) == 0 {
-		data.Colors = []views.Color{}
-	}
-	if len(data.Brands) == 0 {
-		data.Brands = []views.Brand{}
-	}
-	if len(data.Countries) == 0 {
-		data.Countries = []views.Country{}
+		if len(data.Materials) == 0 {
+			data.Materials = []views.Material{}
+		}
+		if len(data.Colors) == 0 {
+			data.Colors = []views.Color{}
+		}
+		if len(data.Brands) == 0 {
+			data.Brands = []views.Brand{}
+		}
+		if len(data.Countries) == 0 {
+			data.Countries = []views.Country{}
+		}
+		return data, []string{redis.TagDictionaries, redis.TagCategory(id), redis.TagCategoryMembers(id)}, nil
+	})
+	if err != nil {
+		return dictionariesError(c, op, err)
 	}
-	//set in cache
-	if err := a.rds.SetDictionariesByCategory(id, data); err != nil {
-		log.Println(format.Error(op, err))
+	if stale {
+		markStale(c)
 	}
-	return c.JSON(http.StatusOK, data)
+	return a.conditional(c, data, "", data.UpdatedAt)
 }
 
 // GetAllDictionaries godoc
@@ -82,57 +66,48 @@ func (a *Apis) GetAllDictionariesByCategory(c echo.Context) error {
 // @Description Возвращает список всех доступных справочных данных: бренды, категории, материалы, страны и цвета
 // @Tags dictionaries
 // @Produce json
+// @Param If-None-Match header string false "ETag из прошлого ответа"
 // @Success 200 {object} views.Dictionaries "Успешный запрос"
+// @Success 304 "Данные не изменились"
 // @Failure 500 {object} views.SWGErrorResponse "Ошибка на сервере"
 // @Failure 502 {object} views.SWGErrorResponse "Ошибка взаимодействия с сервисом"
 // @Router /api/dictionaries/getall [get]
 func (a *Apis) GetAllDictionaries(c echo.Context) error {
 	const op = "handlers.GetAllDictionaries"
-	//check cache
-	if ds, err := a.rds.GetDictionaries(); err == nil {
-		log.Println("read dict from cache", ds)
-		return c.JSON(http.StatusOK, ds)
-	}
-	log.Println("no dict on cache")
-
-	//if not in cache get
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-	defer cancel()
 
-	data, err := a.apiProduct.GetAllDictionaries(ctx)
-	if err != nil {
-		if st, ok := status.FromError(err); ok {
-			switch st.Code() {
-			case codes.Internal:
-				log.Println(format.Error(op, err))
-				return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal service error"})
-			default:
-				log.Println(format.Error(op, err))
-				return c.JSON(http.StatusBadGateway, map[string]string{"error": "failed to get dictionaries"})
-			}
+	var data views.Dictionaries
+	stale, err := a.rds.Fetch(c.Request().Context(), redis.DictionariesKey, &data, a.cfg.Live().CacheTTL[config.CacheDictionaries], func(ctx context.Context) (any, []string, error) {
+		data, err := a.apiProduct.GetAllDictionaries(ctx)
+		if err != nil {
+			return nil, nil, err
 		}
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadGateway, map[string]string{"error": "gateway error"})
-	}
-
-	if len(data.Categories) == 0 {
-		data.Categories = []views.Category{}
-	}
-	if len(data.Materials) == 0 {
-		data.Materials = []views.Material{}
-	}
-	if len(data.Colors) == 0 {
-		data.Colors = []views.Color{}
-	}
-	if len(data.Brands) == 0 {
-		data.Brands = []views.Brand{}
-	}
-	if len(data.Countries) == 0 {
-		data.Countries = []views.Country{}
+		if len(data.Categories) == 0 {
+			data.Categories = []views.Category{}
+		}
+		if len(data.Materials) == 0 {
+			data.Materials = []views.Material{}
+		}
+		if len(data.Colors) == 0 {
+			data.Colors = []views.Color{}
+		}
+		if len(data.Brands) == 0 {
+			data.Brands = []views.Brand{}
+		}
+		if len(data.Countries) == 0 {
+			data.Countries = []views.Country{}
+		}
+		return data, []string{redis.TagDictionaries}, nil
+	})
+	if err != nil {
+		return dictionariesError(c, op, err)
 	}
-	//set in cache
-	if err := a.rds.SetDictionaries(data); err != nil {
-		log.Println(format.Error(op, err))
+	if stale {
+		markStale(c)
 	}
-	return c.JSON(http.StatusOK, data)
+	return a.conditional(c, data, "", data.UpdatedAt)
+}
+
+func dictionariesError(c echo.Context, op string, err error) error {
+	slog.ErrorContext(c.Request().Context(), op, "err", err)
+	return httperr.GRPC(c, err, "failed to get dictionaries")
 }
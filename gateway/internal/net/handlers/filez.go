@@ -1,17 +1,25 @@
 package handlers
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
-	"log"
+	"fmt"
+	"gateway/internal/net/httperr"
+	"gateway/internal/views"
+	"log/slog"
 	"os"
+	"sync"
+	"time"
 
-	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"io"
 	"mime/multipart"
 	"net/http"
 	"path/filepath"
 	"strings"
+	"syscall"
 )
 
 var allowedMIMEs = map[string]string{
@@ -21,18 +29,32 @@ var allowedMIMEs = map[string]string{
 	"image/gif":  ".gif",
 }
 
-func newName() string {
-	return uuid.NewString()
+// newName return name of file by its content. Same bytes always get same name
+func newName(sum []byte, ext string) string {
+	return hex.EncodeToString(sum) + ext
 }
 
+// imagesMu guard images dir and reference counters from concurrent upload and delete of same file
+var imagesMu sync.Mutex
+
+// imageGrace is time after upload when unused image is not removed by release of other product.
+// Same bytes may be uploaded again right now to be put into new product
+const imageGrace = time.Hour
+
 const (
-	ImagesDir      = "./images"
+	ImagesDir = "./images"
+	// UploadsDir keep temp files and parts of resumable uploads. It is out of ImagesDir,
+	// because everything there is served as is
+	UploadsDir     = "./uploads"
 	MaxUploadBytes = 10 << 20 // 10 MB
+	MaxBatchFiles  = 50
 )
 
 // UploadFile godoc
 // @Summary Загрузить изображение
-// @Description Сохраняет изображение в папке сервера images/. В поле "img" передается файл с расширениями jpg, png, webp, gif
+// @Description Сохраняет изображение в папке сервера images/. В поле "img" передается файл с расширениями jpg, png, webp, gif.
+// @Description Имя файла вычисляется по содержимому: повторная загрузка тех же байт вернет уже существующее имя.
+// @Description Файл считается занятым, когда он указан в фото продукта
 // @Tags files
 // @Accept json
 // @Produce json
@@ -43,105 +65,384 @@ const (
 func (a *Apis) UploadFile(c echo.Context) error {
 	fileHeader, err := c.FormFile("img")
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "file field 'img' is required"})
+		return httperr.Write(c, http.StatusBadRequest, "file field 'img' is required")
 	}
 
-	src, err := fileHeader.Open()
+	filename, detected, err := a.storeUploadedFile(fileHeader)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "cannot open uploaded file"})
+		slog.ErrorContext(c.Request().Context(), "store uploaded file", "file", fileHeader.Filename, "err", err)
+		return httperr.Write(c, uploadErrorStatus(err), uploadErrorMessage(err))
 	}
-	defer src.Close()
 
+	return c.JSON(http.StatusOK, map[string]string{
+		"name": filename,
+		"mime": detected,
+	})
+}
+
+// UploadFiles godoc
+// @Summary Загрузить несколько изображений
+// @Description Сохраняет все файлы из поля "img" (можно передать несколько раз) в папке сервера images/.
+// @Description Ошибка одного файла не прерывает загрузку остальных: результат возвращается по каждому файлу в порядке передачи
+// @Tags files
+// @Accept mpfd
+// @Produce json
+// @Success 200 {object} []views.FileUploadResult "Результаты загрузки по каждому файлу"
+// @Failure 400 {object} views.SWGErrorResponse "Неверный формат данных"
+// @Router /api/files/upload/batch [post]
+func (a *Apis) UploadFiles(c echo.Context) error {
+	form, err := c.MultipartForm()
+	if err != nil {
+		return httperr.Write(c, http.StatusBadRequest, "multipart form is required")
+	}
+
+	files := form.File["img"]
+	if len(files) == 0 {
+		return httperr.Write(c, http.StatusBadRequest, "file field 'img' is required")
+	}
+	if len(files) > MaxBatchFiles {
+		return httperr.Write(c, http.StatusBadRequest, fmt.Sprintf("too many files, max %d", MaxBatchFiles))
+	}
+
+	results := make([]views.FileUploadResult, 0, len(files))
+	for _, fh := range files {
+		res := views.FileUploadResult{File: fh.Filename}
+
+		name, detected, err := a.storeUploadedFile(fh)
+		if err != nil {
+			slog.ErrorContext(c.Request().Context(), "store uploaded file", "file", fh.Filename, "err", err)
+			res.Error = uploadErrorMessage(err)
+		} else {
+			res.Name = name
+			res.Mime = detected
+		}
+
+		results = append(results, res)
+	}
+
+	return c.JSON(http.StatusOK, results)
+}
+
+var (
+	errTooLarge    = errors.New("too large")
+	errCantOpen    = errors.New("cannot open uploaded file")
+	errUnsupported = errors.New("unsupported file type")
+	errNoImage     = errors.New("image is not uploaded")
+	errImageUsed   = errors.New("image is used by products")
+)
+
+func uploadErrorMessage(err error) string {
+	switch {
+	case errors.Is(err, errTooLarge):
+		return "file is too large"
+	case errors.Is(err, errCantOpen):
+		return "cannot open uploaded file"
+	case errors.Is(err, errUnsupported):
+		return "unsupported file type"
+	default:
+		return "failed to save file"
+	}
+}
+
+func uploadErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, errTooLarge), errors.Is(err, errCantOpen), errors.Is(err, errUnsupported):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// detectImage return mime type and extension of image by first bytes of src and rewind it
+func detectImage(src io.ReadSeeker) (string, string, error) {
 	buff := make([]byte, 512)
 	n, _ := io.ReadFull(src, buff)
-	if _, err := src.(io.Seeker).Seek(0, io.SeekStart); err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "cannot seek file"})
+	if _, err := src.Seek(0, io.SeekStart); err != nil {
+		return "", "", err
 	}
 
 	detected := http.DetectContentType(buff[:n])
 	ext, ok := allowedMIMEs[detected]
 	if !ok {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "unsupported file type"})
+		return "", "", errUnsupported
 	}
+	return detected, ext, nil
+}
 
-	filename := newName() + ext
+// storeUploadedFile check and save one multipart image. Reference is taken later by product using it
+func (a *Apis) storeUploadedFile(fh *multipart.FileHeader) (string, string, error) {
+	if fh.Size > MaxUploadBytes {
+		return "", "", errTooLarge
+	}
+
+	src, err := fh.Open()
+	if err != nil {
+		return "", "", errCantOpen
+	}
+	defer src.Close()
+
+	detected, ext, err := detectImage(src)
+	if err != nil {
+		return "", "", err
+	}
+
+	// copy and hash are done before lock, so slow upload does not hold other ones
+	tmpPath, sum, err := stageImage(src)
+	if err != nil {
+		return "", "", err
+	}
+	defer os.Remove(tmpPath)
+
+	imagesMu.Lock()
+	defer imagesMu.Unlock()
+
+	filename, err := placeImage(tmpPath, sum, ext)
+	if err != nil {
+		return "", "", err
+	}
+
+	return filename, detected, nil
+}
+
+// stageImage write src into temp file of uploads dir and return its path and hash sum.
+// Caller remove the file, placeImage leave it when same content is already stored
+func stageImage(src io.Reader) (string, []byte, error) {
+	if err := os.MkdirAll(UploadsDir, 0755); err != nil {
+		return "", nil, err
+	}
+	tmp, err := os.CreateTemp(UploadsDir, "upload-*")
+	if err != nil {
+		return "", nil, err
+	}
+
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmp, h), src); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return "", nil, err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return "", nil, err
+	}
+
+	return tmp.Name(), h.Sum(nil), nil
+}
+
+// placeImage move finished file into images dir under name by its hash sum.
+// File at srcPath is left untouched when same content is already stored, then stored one
+// is touched, so it get new imageGrace
+func placeImage(srcPath string, sum []byte, ext string) (string, error) {
+	filename := newName(sum, ext)
 	dstPath := filepath.Join(ImagesDir, filename)
 
 	if !strings.HasPrefix(filepath.Clean(dstPath), filepath.Clean(ImagesDir)) {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid path"})
+		return "", errors.New("invalid path")
 	}
 
-	if err := saveMultipartFile(src, dstPath, fileHeader); err != nil {
-		var msg string
-		if errors.Is(err, errTooLarge) {
-			msg = "file is too large"
-		} else {
-			msg = "failed to save file"
-		}
-		log.Println(err)
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": msg})
+	if _, err := os.Stat(dstPath); err == nil {
+		now := time.Now()
+		return filename, os.Chtimes(dstPath, now, now)
 	}
 
-	return c.JSON(http.StatusOK, map[string]string{
-		"name": filename,
-		"mime": detected,
-	})
-}
+	if err := os.Chmod(srcPath, 0644); err != nil {
+		return "", err
+	}
+	if err := moveFile(srcPath, dstPath); err != nil {
+		return "", err
+	}
 
-var errTooLarge = errors.New("too large")
+	return filename, nil
+}
 
-func saveMultipartFile(src multipart.File, dstPath string, fh *multipart.FileHeader) error {
-	if fh.Size > MaxUploadBytes {
-		return errTooLarge
+// moveFile rename src to dst. When uploads and images are different volumes rename is not
+// possible, then file is copied into hidden temp near dst (dot files are not served) and renamed
+func moveFile(src, dst string) error {
+	err := os.Rename(src, dst)
+	if !errors.Is(err, syscall.EXDEV) {
+		return err
 	}
 
-	dst, err := os.Create(dstPath)
+	in, err := os.Open(src)
 	if err != nil {
 		return err
 	}
-	defer dst.Close()
-	if _, err := io.Copy(dst, src); err != nil {
-		_ = os.Remove(dstPath)
+	defer in.Close()
+
+	tmp, err := os.CreateTemp(filepath.Dir(dst), ".move-*")
+	if err != nil {
 		return err
 	}
+	defer os.Remove(tmp.Name())
 
-	if err := os.Chmod(dstPath, 0644); err != nil {
+	if _, err := io.Copy(tmp, in); err != nil {
+		_ = tmp.Close()
 		return err
 	}
-
-	return nil
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), dst); err != nil {
+		return err
+	}
+	return os.Remove(src)
 }
 
 // DeleteFile godoc
 // @Summary Удалить файл
 // @Description Удаляет файл. Требуется передовать только имя. Пример: example.gif
+// @Description Одинаковые загрузки хранятся одним файлом. Файл, который указан в фото продуктов, не удаляется
 // @Tags files
 // @Produce json
 // @Param title query string true "название файла"
 // @Success 200 {object} views.SWGSuccessResponse "файл успешно удалён"
 // @Failure 400 {object} views.SWGErrorResponse "Неверное название"
+// @Failure 404 {object} views.SWGErrorResponse "Файл не найден"
+// @Failure 409 {object} views.SWGErrorResponse "Файл используется продуктами"
 // @Failure 500 {object} views.SWGErrorResponse "Ошибка на сервере"
 // @Router /api/files/delete [delete]
 func (a *Apis) DeleteFile(c echo.Context) error {
 	filename := c.QueryParam("title")
 
 	if strings.Contains(filename, "/") || strings.Contains(filename, "\\") {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid filename"})
+		return httperr.Write(c, http.StatusBadRequest, "invalid filename")
 	}
 
 	path := filepath.Join("images", filename)
 
-	if err := deleteImage(path); err != nil {
-		if errors.Is(err, os.ErrNotExist) {
-			return c.JSON(http.StatusNotFound, map[string]string{"error": "file not found"})
+	if err := a.deleteUnusedImage(filename); err != nil {
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			return httperr.Write(c, http.StatusNotFound, "file not found")
+		case errors.Is(err, errImageUsed):
+			return httperr.Write(c, http.StatusConflict, "file is used by products")
 		}
-		log.Println(err)
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		slog.ErrorContext(c.Request().Context(), "delete image", "file", filename, "err", err)
+		return httperr.Write(c, http.StatusInternalServerError, err.Error())
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{"deleted": path})
 }
 
+// deleteUnusedImage remove image from disk when no product use it
+func (a *Apis) deleteUnusedImage(filename string) error {
+	imagesMu.Lock()
+	defer imagesMu.Unlock()
+
+	n, err := a.rds.ImageRefs(filename)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return errImageUsed
+	}
+	return deleteImage(filepath.Join("images", filename))
+}
+
+// acquireImages take reference for every use of file. Files must be already uploaded, so
+// product never point to bytes removed in between
+func (a *Apis) acquireImages(files []string) error {
+	if len(files) == 0 {
+		return nil
+	}
+
+	imagesMu.Lock()
+	defer imagesMu.Unlock()
+
+	for _, f := range files {
+		if strings.ContainsAny(f, "/\\") || strings.HasPrefix(f, ".") {
+			return fmt.Errorf("%w: %s", errNoImage, f)
+		}
+		if _, err := os.Stat(filepath.Join(ImagesDir, f)); err != nil {
+			return fmt.Errorf("%w: %s", errNoImage, f)
+		}
+	}
+	return a.rds.AcquireImages(files...)
+}
+
+// unrefImages give back references taken by acquireImages when product was not changed.
+// Bytes are left on disk, they are just uploaded and may be used by next try
+func (a *Apis) unrefImages(ctx context.Context, files []string) {
+	imagesMu.Lock()
+	defer imagesMu.Unlock()
+
+	for _, f := range files {
+		if _, err := a.rds.ReleaseImage(f); err != nil {
+			slog.ErrorContext(ctx, "handlers.unrefImages", "file", f, "err", err)
+		}
+	}
+}
+
+// releaseImages drop reference of every file whose use is ended, errors are only logged
+func (a *Apis) releaseImages(ctx context.Context, files []string) {
+	for _, f := range files {
+		if err := a.releaseImage(f); err != nil {
+			slog.ErrorContext(ctx, "handlers.releaseImages", "file", f, "err", err)
+		}
+	}
+}
+
+// productFiles return files product hold references on, one for every photo of gallery.
+// Legacy photos are only its copy
+func productFiles(pr *views.Product) []string {
+	if len(pr.Gallery) == 0 {
+		return pr.Photos
+	}
+	files := make([]string, 0, len(pr.Gallery))
+	for _, p := range pr.Gallery {
+		files = append(files, p.File)
+	}
+	return files
+}
+
+// removedFiles return files of old which are not in new, counting repeats
+func removedFiles(old, new []string) []string {
+	left := make(map[string]int, len(new))
+	for _, f := range new {
+		left[f]++
+	}
+	var out []string
+	for _, f := range old {
+		if left[f] > 0 {
+			left[f]--
+			continue
+		}
+		out = append(out, f)
+	}
+	return out
+}
+
+// releaseImage drop one reference to image and remove bytes from disk when it was the last one.
+// Image uploaded less than imageGrace ago is kept, it may be waiting to be put into product
+func (a *Apis) releaseImage(filename string) error {
+	imagesMu.Lock()
+	defer imagesMu.Unlock()
+
+	left, err := a.rds.ReleaseImage(filename)
+	if err != nil {
+		return err
+	}
+	if left > 0 {
+		return nil
+	}
+
+	st, err := os.Stat(filepath.Join(ImagesDir, filename))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	if time.Since(st.ModTime()) < imageGrace {
+		return nil
+	}
+
+	return deleteImage(filepath.Join("images", filename))
+}
+
 func deleteImage(relPath string) error {
 	cleanPath := strings.TrimPrefix(relPath, "./")
 	if !strings.HasPrefix(cleanPath, "images/") && !strings.HasPrefix(cleanPath, "images\\") {
@@ -0,0 +1,305 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"gateway/config"
+	"gateway/internal/grpc/products"
+	"gateway/internal/pkg/redis"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	productsRPC "github.com/autumnterror/volha-proto/gen/products"
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// fakeProducts keep products in memory, gallery is made of photos like product-service do
+type fakeProducts struct {
+	productsRPC.UnimplementedProductsServer
+	mu       sync.Mutex
+	products map[string]*productsRPC.ProductId
+	// fail is returned by next create or update
+	fail error
+}
+
+func (f *fakeProducts) CreateProduct(_ context.Context, p *productsRPC.ProductId) (*emptypb.Empty, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail; err != nil {
+		f.fail = nil
+		return nil, err
+	}
+	f.products[p.Id] = p
+	return &emptypb.Empty{}, nil
+}
+
+func (f *fakeProducts) UpdateProduct(_ context.Context, p *productsRPC.ProductId) (*emptypb.Empty, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail; err != nil {
+		f.fail = nil
+		return nil, err
+	}
+	old, ok := f.products[p.Id]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+	if !p.ReplacePhotos {
+		p.Photos = old.Photos
+	}
+	f.products[p.Id] = p
+	return &emptypb.Empty{}, nil
+}
+
+func (f *fakeProducts) GetProduct(_ context.Context, id *productsRPC.Id) (*productsRPC.Product, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p, ok := f.products[id.Id]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+	pr := &productsRPC.Product{
+		Id:       p.Id,
+		Brand:    &productsRPC.Brand{Id: p.Brand},
+		Category: &productsRPC.Category{Id: p.Category},
+		Country:  &productsRPC.Country{Id: p.Country},
+		Photos:   p.Photos,
+		Version:  p.Version,
+	}
+	for i, file := range p.Photos {
+		pr.Gallery = append(pr.Gallery, &productsRPC.ProductPhoto{ProductId: p.Id, File: file, Position: int32(i)})
+	}
+	return pr, nil
+}
+
+func (f *fakeProducts) DeleteProduct(_ context.Context, id *productsRPC.Id) (*emptypb.Empty, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.products, id.Id)
+	return &emptypb.Empty{}, nil
+}
+
+// newTestApis start fake product-service over bufconn and miniredis. Work dir is changed to
+// temp one, so images of test are in its ./images
+func newTestApis(t *testing.T, srv productsRPC.ProductsServer) (*Apis, *miniredis.Miniredis) {
+	t.Helper()
+	t.Chdir(t.TempDir())
+	if err := os.MkdirAll(ImagesDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	productsRPC.RegisterProductsServer(s, srv)
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+
+	cc, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = cc.Close() })
+
+	mr := miniredis.RunT(t)
+	cfg := &config.Config{RedisAddr: mr.Addr()}
+	rds := redis.New(cfg)
+	t.Cleanup(func() { _ = rds.Close() })
+
+	return New(products.NewFromConn(cc), rds, cfg), mr
+}
+
+// call run handler with json body and return status and decoded answer
+func call(t *testing.T, h echo.HandlerFunc, method, target, body string) (int, map[string]any) {
+	t.Helper()
+	e := echo.New()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	if err := h(e.NewContext(req, rec)); err != nil {
+		t.Fatal(err)
+	}
+	var out map[string]any
+	_ = json.Unmarshal(rec.Body.Bytes(), &out)
+	return rec.Code, out
+}
+
+// writeImage put file into images dir as if it was uploaded long ago
+func writeImage(t *testing.T, name string) {
+	t.Helper()
+	path := filepath.Join(ImagesDir, name)
+	if err := os.WriteFile(path, []byte(name), 0644); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-2 * imageGrace)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func imageExists(name string) bool {
+	_, err := os.Stat(filepath.Join(ImagesDir, name))
+	return err == nil
+}
+
+func TestSharedImageRefs(t *testing.T) {
+	fake := &fakeProducts{products: map[string]*productsRPC.ProductId{}}
+	a, _ := newTestApis(t, fake)
+	writeImage(t, "shared.jpg")
+	writeImage(t, "own.jpg")
+
+	create := func(photos string) string {
+		code, out := call(t, a.CreateProduct, http.MethodPost, "/api/product/create",
+			`{"article":"12345678","photos":`+photos+`}`)
+		if code != http.StatusOK {
+			t.Fatalf("create: %d %v", code, out)
+		}
+		return out["id"].(string)
+	}
+	refs := func(name string) int64 {
+		n, err := a.rds.ImageRefs(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+
+	first := create(`["shared.jpg","own.jpg"]`)
+	second := create(`["shared.jpg"]`)
+	if refs("shared.jpg") != 2 || refs("own.jpg") != 1 {
+		t.Fatalf("refs after create: %d %d", refs("shared.jpg"), refs("own.jpg"))
+	}
+
+	if code, _ := call(t, a.DeleteFile, http.MethodDelete, "/api/files/delete?title=shared.jpg", ""); code != http.StatusConflict {
+		t.Fatalf("delete used file: %d", code)
+	}
+
+	// first product drop shared file, second still use it
+	if code, out := call(t, a.UpdateProduct, http.MethodPut, "/api/product/update?id="+first,
+		`{"article":"12345678","photos":["own.jpg"],"version":1}`); code != http.StatusOK {
+		t.Fatalf("update: %d %v", code, out)
+	}
+	if refs("shared.jpg") != 1 || !imageExists("shared.jpg") {
+		t.Fatalf("shared file after update: refs %d exists %v", refs("shared.jpg"), imageExists("shared.jpg"))
+	}
+
+	if code, _ := call(t, a.DeleteProduct, http.MethodDelete, "/api/product/delete?id="+second, ""); code != http.StatusOK {
+		t.Fatalf("delete second: %d", code)
+	}
+	if refs("shared.jpg") != 0 || imageExists("shared.jpg") {
+		t.Fatalf("shared file after last use: refs %d exists %v", refs("shared.jpg"), imageExists("shared.jpg"))
+	}
+	if !imageExists("own.jpg") {
+		t.Fatal("file of first product is removed")
+	}
+
+	// update without photos keep gallery and references
+	if code, out := call(t, a.UpdateProduct, http.MethodPut, "/api/product/update?id="+first,
+		`{"article":"12345678","title":"new","version":2}`); code != http.StatusOK {
+		t.Fatalf("update without photos: %d %v", code, out)
+	}
+	if refs("own.jpg") != 1 || len(fake.products[first].Photos) != 1 {
+		t.Fatalf("update without photos: refs %d photos %v", refs("own.jpg"), fake.products[first].Photos)
+	}
+
+	// empty photos clear gallery
+	if code, out := call(t, a.UpdateProduct, http.MethodPut, "/api/product/update?id="+first,
+		`{"article":"12345678","photos":[],"version":3}`); code != http.StatusOK {
+		t.Fatalf("update with empty photos: %d %v", code, out)
+	}
+	if refs("own.jpg") != 0 || imageExists("own.jpg") || len(fake.products[first].Photos) != 0 {
+		t.Fatal("empty photos do not clear gallery")
+	}
+}
+
+func TestImageRefsRollback(t *testing.T) {
+	fake := &fakeProducts{products: map[string]*productsRPC.ProductId{}}
+	a, _ := newTestApis(t, fake)
+	writeImage(t, "a.jpg")
+
+	if code, _ := call(t, a.CreateProduct, http.MethodPost, "/api/product/create",
+		`{"article":"12345678","photos":["missing.jpg"]}`); code != http.StatusBadRequest {
+		t.Fatalf("not uploaded file: %d", code)
+	}
+
+	// rejected create give reference back
+	fake.fail = status.Error(codes.InvalidArgument, "bad")
+	if code, _ := call(t, a.CreateProduct, http.MethodPost, "/api/product/create",
+		`{"article":"12345678","photos":["a.jpg"]}`); code == http.StatusOK {
+		t.Fatal("create must fail")
+	}
+	if n, _ := a.rds.ImageRefs("a.jpg"); n != 0 {
+		t.Fatalf("refs after rejected create: %d", n)
+	}
+
+	// result of timeout is unknown, reference is kept
+	fake.fail = status.Error(codes.DeadlineExceeded, "timeout")
+	if code, _ := call(t, a.CreateProduct, http.MethodPost, "/api/product/create",
+		`{"article":"12345678","photos":["a.jpg"]}`); code == http.StatusOK {
+		t.Fatal("create must fail")
+	}
+	if n, _ := a.rds.ImageRefs("a.jpg"); n != 1 {
+		t.Fatalf("refs after timeout: %d", n)
+	}
+}
+
+func TestPlaceImageDedup(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := os.MkdirAll(ImagesDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	stage := func(data string) (string, []byte) {
+		path, sum, err := stageImage(strings.NewReader(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return path, sum
+	}
+
+	p1, s1 := stage("same bytes")
+	p2, s2 := stage("same bytes")
+	n1, err := placeImage(p1, s1, ".png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-2 * imageGrace)
+	_ = os.Chtimes(filepath.Join(ImagesDir, n1), old, old)
+
+	n2, err := placeImage(p2, s2, ".png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n1 != n2 {
+		t.Fatalf("same bytes got names %s and %s", n1, n2)
+	}
+	// second upload give stored file new grace
+	st, _ := os.Stat(filepath.Join(ImagesDir, n2))
+	if time.Since(st.ModTime()) > imageGrace {
+		t.Fatal("stored file is not touched")
+	}
+	if _, err := os.Stat(p2); err != nil {
+		t.Fatal("temp file of duplicate is removed by placeImage")
+	}
+
+	p3, s3 := stage("other bytes")
+	if n3, _ := placeImage(p3, s3, ".png"); n3 == n1 {
+		t.Fatal("other bytes got same name")
+	}
+}
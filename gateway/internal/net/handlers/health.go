@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"context"
+	"gateway/internal/views"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// checkTimeout limit every check of readiness, probes of docker and k8s wait few seconds only
+const checkTimeout = 2 * time.Second
+
+// Healthz godoc
+// @Summary Проверка жизни
+// @Description Отвечает 200, пока процесс жив. Зависимости не проверяются, для этого есть /readyz
+// @Tags health
+// @Produce json
+// @Success 200 {object} views.Health "Процесс жив"
+// @Router /healthz [get]
+func (a *Apis) Healthz(c echo.Context) error {
+	return c.JSON(http.StatusOK, views.Health{Status: "ok"})
+}
+
+// Drain make /readyz answer 503, so balancer stop sending requests before server is stopped
+func (a *Apis) Drain() {
+	a.draining.Store(true)
+}
+
+// Readyz godoc
+// @Summary Проверка готовности
+// @Description Проверяет Redis и соединение с product-service. Если что-то недоступно, отвечает 503 и
+// @Description в checks пишет ошибку этой зависимости. Во время остановки сервиса всегда отвечает 503
+// @Tags health
+// @Produce json
+// @Success 200 {object} views.Health "Готов принимать запросы"
+// @Failure 503 {object} views.Health "Зависимость недоступна"
+// @Router /readyz [get]
+func (a *Apis) Readyz(c echo.Context) error {
+	const op = "handlers.Readyz"
+
+	if a.draining.Load() {
+		return c.JSON(http.StatusServiceUnavailable, views.Health{Status: "shutting down"})
+	}
+
+	checks := map[string]func(context.Context) error{
+		"redis":           a.rds.Ping,
+		"product-service": a.apiProduct.Check,
+	}
+
+	resp := views.Health{Status: "ok", Checks: make(map[string]string, len(checks))}
+	httpStatus := http.StatusOK
+	for name, check := range checks {
+		ctx, cancel := context.WithTimeout(c.Request().Context(), checkTimeout)
+		err := check(ctx)
+		cancel()
+		if err != nil {
+			slog.WarnContext(c.Request().Context(), op, "check", name, "err", err)
+			resp.Status = "unavailable"
+			resp.Checks[name] = err.Error()
+			httpStatus = http.StatusServiceUnavailable
+			continue
+		}
+		resp.Checks[name] = "ok"
+	}
+	return c.JSON(httpStatus, resp)
+}
@@ -2,9 +2,10 @@ package handlers
 
 import (
 	"context"
-	"gateway/internal/utils/format"
+	"gateway/internal/net/httperr"
+	"gateway/internal/pkg/redis"
 	"gateway/internal/views"
-	"log"
+	"log/slog"
 	"net/http"
 	"time"
 
@@ -19,32 +20,34 @@ import (
 // @Accept json
 // @Produce json
 // @Param material body views.Material true "Новый материал"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор с тем же ключом вернёт первый ответ"
 // @Success 200 {object} views.SWGSuccessResponse "Материал успешно создан"
 // @Failure 400 {object} views.SWGErrorResponse "Неверный формат данных"
+// @Failure 409 {object} views.SWGErrorResponse "Запрос с этим ключом ещё выполняется или его результат неизвестен"
+// @Failure 422 {object} views.SWGErrorResponse "Ключ уже использован с другим запросом"
 // @Failure 500 {object} views.SWGErrorResponse "Ошибка на сервере"
 // @Failure 502 {object} views.SWGErrorResponse "Ошибка взаимодействия с сервисом"
 // @Router /api/material/create [post]
 func (a *Apis) CreateMaterial(c echo.Context) error {
 	const op = "handlers.CreateMaterial"
 
-	//delete cache dict
-	if err := a.rds.CleanDictionaries(); err != nil {
-		log.Println(format.Error(op, err))
-	}
-
 	var m views.Material
 	if err := c.Bind(&m); err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "bad JSON"})
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.Write(c, http.StatusBadRequest, "bad JSON")
 	}
 	m.Id = xid.New().String()
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 3*time.Second)
 	defer cancel()
 
 	if err := a.apiProduct.CreateMaterial(ctx, &m); err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadGateway, map[string]string{"error": "could not create material"})
+		slog.ErrorContext(ctx, op, "err", err)
+		return httperr.GRPC(c, err, "could not create material")
+	}
+
+	if err := a.rds.CleanDictionaries(); err != nil {
+		slog.ErrorContext(ctx, op, "err", err)
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{"id": m.Id})
@@ -58,37 +61,46 @@ func (a *Apis) CreateMaterial(c echo.Context) error {
 // @Produce json
 // @Param id query string true "ID материала"
 // @Param material body views.Material true "Обновлённые данные материала"
+// @Param If-Match header string false "ETag объекта из GET, если версии нет в теле"
 // @Success 200 {object} views.SWGSuccessResponse "Материал успешно обновлён"
 // @Failure 400 {object} views.SWGErrorResponse "Неверный ID или данные"
+// @Failure 409 {object} views.SWGErrorResponse "Объект уже изменён, в details.current_version текущая версия"
+// @Failure 412 {object} views.SWGErrorResponse "If-Match другого объекта"
+// @Failure 428 {object} views.SWGErrorResponse "Не передана версия"
 // @Failure 500 {object} views.SWGErrorResponse "Ошибка на сервере"
 // @Failure 502 {object} views.SWGErrorResponse "Ошибка взаимодействия с сервисом"
 // @Router /api/material/update [put]
 func (a *Apis) UpdateMaterial(c echo.Context) error {
 	const op = "handlers.UpdateMaterial"
 
-	//delete cache dict
-	if err := a.rds.CleanDictionaries(); err != nil {
-		log.Println(format.Error(op, err))
-	}
-
 	id := c.QueryParam("id")
 	if id == "" {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "missing id"})
+		return httperr.Write(c, http.StatusBadRequest, "missing id")
 	}
 
 	var m views.Material
 	if err := c.Bind(&m); err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "bad JSON"})
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.Write(c, http.StatusBadRequest, "bad JSON")
 	}
 	m.Id = id
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	version, err := requestVersion(c, id, m.Version)
+	if err != nil {
+		return versionError(c, err)
+	}
+	m.Version = version
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 3*time.Second)
 	defer cancel()
 
 	if err := a.apiProduct.UpdateMaterial(ctx, &m); err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadGateway, map[string]string{"error": "could not update material"})
+		slog.ErrorContext(ctx, op, "err", err)
+		return httperr.GRPC(c, err, "could not update material")
+	}
+
+	if err := a.rds.InvalidateTags(redis.TagDictionaries, redis.TagMaterial(id)); err != nil {
+		slog.ErrorContext(ctx, op, "err", err)
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{"answer": "material updated successfully"})
@@ -108,22 +120,21 @@ func (a *Apis) UpdateMaterial(c echo.Context) error {
 func (a *Apis) DeleteMaterial(c echo.Context) error {
 	const op = "handlers.DeleteMaterial"
 
-	//delete cache dict
-	if err := a.rds.CleanDictionaries(); err != nil {
-		log.Println(format.Error(op, err))
-	}
-
 	id := c.QueryParam("id")
 	if id == "" {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "missing id"})
+		return httperr.Write(c, http.StatusBadRequest, "missing id")
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 3*time.Second)
 	defer cancel()
 
 	if err := a.apiProduct.DeleteMaterial(ctx, id); err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadGateway, map[string]string{"error": "could not delete material"})
+		slog.ErrorContext(ctx, op, "err", err)
+		return httperr.GRPC(c, err, "could not delete material")
+	}
+
+	if err := a.rds.InvalidateTags(redis.TagDictionaries, redis.TagMaterial(id)); err != nil {
+		slog.ErrorContext(ctx, op, "err", err)
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{"answer": "material deleted successfully"})
@@ -141,13 +152,13 @@ func (a *Apis) DeleteMaterial(c echo.Context) error {
 func (a *Apis) GetAllMaterials(c echo.Context) error {
 	const op = "handlers.GetAllMaterials"
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 3*time.Second)
 	defer cancel()
 
 	list, err := a.apiProduct.GetAllMaterials(ctx)
 	if err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadGateway, map[string]string{"error": "failed to get materials"})
+		slog.ErrorContext(ctx, op, "err", err)
+		return httperr.GRPC(c, err, "failed to get materials")
 	}
 
 	if len(list) == 0 {
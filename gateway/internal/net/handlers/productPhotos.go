@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"context"
+	"gateway/internal/net/httperr"
+	"gateway/internal/pkg/redis"
+	"gateway/internal/views"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/xid"
+)
+
+// GetProductPhotos godoc
+// @Summary Получить галерею продукта
+// @Description Возвращает фотографии продукта в порядке отображения с подписями и цветом
+// @Tags productphotos
+// @Produce json
+// @Param id query string true "ID продукта"
+// @Success 200 {object} []views.ProductPhoto
+// @Failure 400 {object} views.SWGErrorResponse "Неверный ID"
+// @Failure 502 {object} views.SWGErrorResponse "Ошибка взаимодействия с сервисом"
+// @Router /api/productphotos/get [get]
+func (a *Apis) GetProductPhotos(c echo.Context) error {
+	const op = "handlers.GetProductPhotos"
+
+	id := c.QueryParam("id")
+	if id == "" {
+		return httperr.Write(c, http.StatusBadRequest, "missing id")
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 3*time.Second)
+	defer cancel()
+
+	list, err := a.apiProduct.GetProductPhotos(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, op, "err", err)
+		return httperr.GRPC(c, err, "could not get photos")
+	}
+
+	if len(list) == 0 {
+		list = []views.ProductPhoto{}
+	}
+
+	return c.JSON(http.StatusOK, list)
+}
+
+// AddProductPhoto godoc
+// @Summary Добавить фото в галерею
+// @Description Добавляет фото в конец галереи продукта. Первое фото продукта становится главным.
+// @Description Файл должен быть уже загружен через /api/files
+// @Tags productphotos
+// @Accept json
+// @Produce json
+// @Param photo body views.ProductPhoto true "Фото (id и position игнорируются)"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор с тем же ключом вернёт первый ответ"
+// @Success 200 {object} views.SWGIdResponse "Фото добавлено"
+// @Failure 400 {object} views.SWGErrorResponse "Неверный формат данных"
+// @Failure 409 {object} views.SWGErrorResponse "Запрос с этим ключом ещё выполняется или его результат неизвестен"
+// @Failure 422 {object} views.SWGErrorResponse "Ключ уже использован с другим запросом"
+// @Failure 502 {object} views.SWGErrorResponse "Ошибка взаимодействия с сервисом"
+// @Router /api/productphotos/add [post]
+func (a *Apis) AddProductPhoto(c echo.Context) error {
+	const op = "handlers.AddProductPhoto"
+
+	var p views.ProductPhoto
+	if err := c.Bind(&p); err != nil {
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.Write(c, http.StatusBadRequest, "bad JSON")
+	}
+	if p.ProductId == "" || p.File == "" {
+		return httperr.Write(c, http.StatusBadRequest, "product_id and file are required")
+	}
+
+	p.Id = xid.New().String()
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 3*time.Second)
+	defer cancel()
+
+	files := []string{p.File}
+	if err := a.acquireImages(files); err != nil {
+		return imagesError(c, op, err)
+	}
+
+	if err := a.apiProduct.AddProductPhoto(ctx, &p); err != nil {
+		slog.ErrorContext(ctx, op, "err", err)
+		if notApplied(err) {
+			a.unrefImages(ctx, files)
+		}
+		return httperr.GRPC(c, err, "could not add photo")
+	}
+
+	if err := a.rds.InvalidateTags(redis.TagProduct(p.ProductId)); err != nil {
+		slog.ErrorContext(ctx, op, "err", err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"id": p.Id})
+}
+
+// UpdateProductPhoto godoc
+// @Summary Обновить фото галереи
+// @Description Меняет подпись, цвет и признак главного фото. Файл и позиция не меняются
+// @Tags productphotos
+// @Accept json
+// @Produce json
+// @Param id query string true "ID фото"
+// @Param photo body views.ProductPhoto true "Новые данные фото"
+// @Success 200 {object} views.SWGSuccessResponse "Фото обновлено"
+// @Failure 400 {object} views.SWGErrorResponse "Неверный ID или данные"
+// @Failure 502 {object} views.SWGErrorResponse "Ошибка взаимодействия с сервисом"
+// @Router /api/productphotos/update [put]
+func (a *Apis) UpdateProductPhoto(c echo.Context) error {
+	const op = "handlers.UpdateProductPhoto"
+
+	id := c.QueryParam("id")
+	if id == "" {
+		return httperr.Write(c, http.StatusBadRequest, "missing id")
+	}
+
+	var p views.ProductPhoto
+	if err := c.Bind(&p); err != nil {
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.Write(c, http.StatusBadRequest, "bad JSON")
+	}
+	p.Id = id
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 3*time.Second)
+	defer cancel()
+
+	if err := a.apiProduct.UpdateProductPhoto(ctx, &p); err != nil {
+		slog.ErrorContext(ctx, op, "err", err)
+		return httperr.GRPC(c, err, "could not update photo")
+	}
+
+	if err := a.rds.InvalidateTags(redis.TagPhoto(id)); err != nil {
+		slog.ErrorContext(ctx, op, "err", err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"answer": "photo updated successfully"})
+}
+
+// ReorderProductPhotos godoc
+// @Summary Изменить порядок фото
+// @Description Задаёт порядок галереи. В ids должны быть все фото продукта
+// @Tags productphotos
+// @Accept json
+// @Produce json
+// @Param order body views.ProductPhotosOrder true "ID продукта и новый порядок фото"
+// @Success 200 {object} views.SWGSuccessResponse "Порядок обновлён"
+// @Failure 400 {object} views.SWGErrorResponse "Неверный формат данных"
+// @Failure 502 {object} views.SWGErrorResponse "Ошибка взаимодействия с сервисом"
+// @Router /api/productphotos/reorder [put]
+func (a *Apis) ReorderProductPhotos(c echo.Context) error {
+	const op = "handlers.ReorderProductPhotos"
+
+	var o views.ProductPhotosOrder
+	if err := c.Bind(&o); err != nil {
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.Write(c, http.StatusBadRequest, "bad JSON")
+	}
+	if o.ProductId == "" {
+		return httperr.Write(c, http.StatusBadRequest, "missing product_id")
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 3*time.Second)
+	defer cancel()
+
+	if err := a.apiProduct.ReorderProductPhotos(ctx, &o); err != nil {
+		slog.ErrorContext(ctx, op, "err", err)
+		return httperr.GRPC(c, err, "could not reorder photos")
+	}
+
+	if err := a.rds.InvalidateTags(redis.TagProduct(o.ProductId)); err != nil {
+		slog.ErrorContext(ctx, op, "err", err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"answer": "photos reordered successfully"})
+}
+
+// RemoveProductPhoto godoc
+// @Summary Удалить фото из галереи
+// @Description Удаляет фото из галереи продукта и освобождает файл изображения
+// @Tags productphotos
+// @Produce json
+// @Param id query string true "ID фото"
+// @Success 200 {object} views.SWGSuccessResponse "Фото удалено"
+// @Failure 400 {object} views.SWGErrorResponse "Неверный ID"
+// @Failure 502 {object} views.SWGErrorResponse "Ошибка взаимодействия с сервисом"
+// @Router /api/productphotos/delete [delete]
+func (a *Apis) RemoveProductPhoto(c echo.Context) error {
+	const op = "handlers.RemoveProductPhoto"
+
+	id := c.QueryParam("id")
+	if id == "" {
+		return httperr.Write(c, http.StatusBadRequest, "missing id")
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 3*time.Second)
+	defer cancel()
+
+	p, err := a.apiProduct.RemoveProductPhoto(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, op, "err", err)
+		return httperr.GRPC(c, err, "could not delete photo")
+	}
+
+	a.releaseImages(ctx, []string{p.File})
+
+	if err := a.rds.InvalidateTags(redis.TagProduct(p.ProductId)); err != nil {
+		slog.ErrorContext(ctx, op, "err", err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"answer": "photo deleted successfully"})
+}
@@ -2,17 +2,22 @@ package handlers
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"gateway/config"
 	"gateway/internal/grpc/products"
+	"gateway/internal/net/httperr"
 	"gateway/internal/pkg/redis"
-	"gateway/internal/utils/format"
 	"gateway/internal/views"
-	"log"
+	"log/slog"
 	"net/http"
 	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/rs/xid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/labstack/echo/v4"
 )
@@ -21,6 +26,8 @@ type Apis struct {
 	apiProduct *products.Client
 	rds        *redis.Client
 	cfg        *config.Config
+	// draining is set on shutdown, /readyz answer 503 from this moment
+	draining atomic.Bool
 }
 
 func New(
@@ -47,6 +54,14 @@ func classifyQuery(q string) *views.ProductSearch {
 	return &views.ProductSearch{Title: q}
 }
 
+// markStale tell client that response is last known good copy served while product-service is down.
+// Such response must be revalidated, so it is not kept after backend is back
+func markStale(c echo.Context) {
+	c.Response().Header().Set("Warning", `110 - "Response is Stale"`)
+	c.Response().Header().Set("X-Cache", "STALE")
+	c.Response().Header().Set("Cache-Control", "no-cache")
+}
+
 func isDigitsOnly(s string) bool {
 	for _, r := range s {
 		if r < '0' || r > '9' {
@@ -62,7 +77,9 @@ func isDigitsOnly(s string) bool {
 // @Tags product
 // @Produce json
 // @Param query query string true "Поисковый запрос"
+// @Param If-None-Match header string false "ETag из прошлого ответа"
 // @Success 200 {object} []views.Product
+// @Success 304 "Данные не изменились"
 // @Failure 400 {object} views.SWGErrorResponse
 // @Failure 502 {object} views.SWGErrorResponse
 // @Router /api/product/search [get]
@@ -71,64 +88,80 @@ func (a *Apis) SearchProducts(c echo.Context) error {
 
 	query := c.QueryParam("query")
 	if query == "" {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "empty query"})
+		return httperr.Write(c, http.StatusBadRequest, "empty query")
 	}
 
 	filter := classifyQuery(query)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-	defer cancel()
-
-	list, err := a.apiProduct.SearchProducts(ctx, filter)
+	var list []views.Product
+	stale, err := a.rds.Fetch(c.Request().Context(), redis.HashKey("search:", filter), &list, a.cfg.Live().CacheTTL[config.CacheList], func(ctx context.Context) (any, []string, error) {
+		list, err := a.apiProduct.SearchProducts(ctx, filter)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(list) == 0 {
+			list = []views.Product{}
+		}
+		return list, append(redis.ListTags(list), redis.TagSearch), nil
+	})
 	if err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadGateway, map[string]string{"error": "could not search products"})
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.GRPC(c, err, "could not search products")
 	}
-
-	if len(list) == 0 {
-		list = []views.Product{}
+	if stale {
+		markStale(c)
 	}
 
-	return c.JSON(http.StatusOK, list)
+	return a.conditional(c, list, "", 0)
 }
 
 // CreateProduct godoc
 // @Summary Создать продукт
-// @Description Добавляет новый продукт
+// @Description Добавляет новый продукт. Файлы из photos должны быть уже загружены через /api/files
 // @Tags product
 // @Accept json
 // @Produce json
 // @Param product body views.ProductId true "Новый продукт"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор с тем же ключом вернёт первый ответ"
 // @Success 200 {object} views.SWGIdResponse "Продукт успешно создан"
 // @Failure 400 {object} views.SWGErrorResponse "Неверный формат данных"
+// @Failure 409 {object} views.SWGErrorResponse "Запрос с этим ключом ещё выполняется или его результат неизвестен"
+// @Failure 422 {object} views.SWGErrorResponse "Ключ уже использован с другим запросом"
 // @Failure 500 {object} views.SWGErrorResponse "Ошибка на сервере"
 // @Failure 502 {object} views.SWGErrorResponse "Ошибка взаимодействия с сервисом"
 // @Router /api/product/create [post]
 func (a *Apis) CreateProduct(c echo.Context) error {
 	const op = "handlers.CreateProduct"
 
-	//delete cache dict
-	if err := a.rds.CleanDictionaries(); err != nil {
-		log.Println(format.Error(op, err))
-	}
-
 	var p views.ProductId
 	if err := c.Bind(&p); err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "bad JSON"})
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.Write(c, http.StatusBadRequest, "bad JSON")
 	}
 	if len(p.Article) != 8 {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "article must be 8 digits"})
+		return httperr.Write(c, http.StatusBadRequest, "article must be 8 digits")
 	}
 
 	p.Id = xid.New().String()
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 3*time.Second)
 	defer cancel()
 
+	// every photo of new product is one more use of its file
+	if err := a.acquireImages(p.Photos); err != nil {
+		return imagesError(c, op, err)
+	}
+
 	if err := a.apiProduct.CreateProduct(ctx, &p); err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadGateway, map[string]string{"error": "could not create product"})
+		slog.ErrorContext(ctx, op, "err", err)
+		if notApplied(err) {
+			a.unrefImages(ctx, p.Photos)
+		}
+		return httperr.GRPC(c, err, "could not create product")
+	}
+
+	if err := a.rds.InvalidateTags(redis.ProductChangeTags(p.Id, []string{p.Category}, []string{p.Brand})...); err != nil {
+		slog.ErrorContext(ctx, op, "err", err)
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{"id": p.Id})
@@ -136,48 +169,159 @@ func (a *Apis) CreateProduct(c echo.Context) error {
 
 // UpdateProduct godoc
 // @Summary Обновить продукт
-// @Description Обновляет данные существующего продукта
+// @Description Обновляет данные существующего продукта. Без поля photos галерея не меняется, пустой photos очищает её
 // @Tags product
 // @Accept json
 // @Produce json
 // @Param id query string true "ID продукта"
 // @Param product body views.ProductId true "Обновлённые данные продукта"
+// @Param If-Match header string false "ETag объекта из GET, если версии нет в теле"
 // @Success 200 {object} views.SWGSuccessResponse "Продукт успешно обновлён"
 // @Failure 400 {object} views.SWGErrorResponse "Неверный ID или данные"
+// @Failure 403 {object} views.SWGErrorResponse "Роль не может менять эти поля: content - цену, pricing - всё кроме цены"
+// @Failure 409 {object} views.SWGErrorResponse "Объект уже изменён, в details.current_version текущая версия"
+// @Failure 412 {object} views.SWGErrorResponse "If-Match другого объекта"
+// @Failure 428 {object} views.SWGErrorResponse "Не передана версия"
 // @Failure 500 {object} views.SWGErrorResponse "Ошибка на сервере"
 // @Failure 502 {object} views.SWGErrorResponse "Ошибка взаимодействия с сервисом"
 // @Router /api/product/update [put]
 func (a *Apis) UpdateProduct(c echo.Context) error {
 	const op = "handlers.UpdateProduct"
 
-	//delete cache dict
-	if err := a.rds.CleanDictionaries(); err != nil {
-		log.Println(format.Error(op, err))
-	}
-
 	id := c.QueryParam("id")
 	if id == "" {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "missing id"})
+		return httperr.Write(c, http.StatusBadRequest, "missing id")
 	}
 
 	var p views.ProductId
 	if err := c.Bind(&p); err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "bad JSON"})
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.Write(c, http.StatusBadRequest, "bad JSON")
 	}
 	p.Id = id
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	version, err := requestVersion(c, id, p.Version)
+	if err != nil {
+		return versionError(c, err)
+	}
+	p.Version = version
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 3*time.Second)
 	defer cancel()
 
+	// old category and brand lose this product. Without old photos references can not be
+	// counted, so update is not done
+	old, err := a.apiProduct.GetProduct(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, op, "err", err)
+		return httperr.GRPC(c, err, "could not update product")
+	}
+	categories := []string{p.Category, old.Category.Id}
+	brands := []string{p.Brand, old.Brand.Id}
+
+	// reference of added photo is taken before update, of removed one is dropped after it.
+	// Version in request make update fail if product is changed after old was read.
+	// Without photos gallery is not changed
+	var added, removed []string
+	if p.Photos != nil {
+		added = removedFiles(p.Photos, productFiles(old))
+		removed = removedFiles(productFiles(old), p.Photos)
+	}
+	if err := a.acquireImages(added); err != nil {
+		return imagesError(c, op, err)
+	}
+
 	if err := a.apiProduct.UpdateProduct(ctx, &p); err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadGateway, map[string]string{"error": "could not update product"})
+		slog.ErrorContext(ctx, op, "err", err)
+		if notApplied(err) {
+			a.unrefImages(ctx, added)
+		}
+		return httperr.GRPC(c, err, "could not update product")
+	}
+
+	a.releaseImages(ctx, removed)
+
+	if err := a.rds.InvalidateTags(redis.ProductChangeTags(id, categories, brands)...); err != nil {
+		slog.ErrorContext(ctx, op, "err", err)
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{"answer": "product updated successfully"})
 }
 
+// notApplied tell that failed call surely did not change product, so references taken for it
+// are given back. After timeout change may be already done, then extra reference is kept,
+// it is better than file removed under product
+func notApplied(err error) bool {
+	switch status.Code(err) {
+	case codes.DeadlineExceeded, codes.Canceled, codes.Unknown:
+		return false
+	}
+	return true
+}
+
+// imagesError answer on failed acquireImages
+func imagesError(c echo.Context, op string, err error) error {
+	if errors.Is(err, errNoImage) {
+		return httperr.Write(c, http.StatusBadRequest, err.Error())
+	}
+	slog.ErrorContext(c.Request().Context(), op, "err", err)
+	return httperr.Write(c, http.StatusInternalServerError, "could not acquire images")
+}
+
+// UpdateProductPrice godoc
+// @Summary Обновить цену продукта
+// @Description Меняет только цену продукта, остальные поля не трогаются
+// @Tags product
+// @Accept json
+// @Produce json
+// @Param id query string true "ID продукта"
+// @Param price body views.ProductPrice true "Новая цена"
+// @Param If-Match header string false "ETag объекта из GET, если версии нет в теле"
+// @Success 200 {object} views.SWGSuccessResponse "Цена обновлена"
+// @Failure 400 {object} views.SWGErrorResponse "Неверный ID или данные"
+// @Failure 403 {object} views.SWGErrorResponse "Нет прав"
+// @Failure 409 {object} views.SWGErrorResponse "Объект уже изменён, в details.current_version текущая версия"
+// @Failure 502 {object} views.SWGErrorResponse "Ошибка взаимодействия с сервисом"
+// @Router /api/product/price [put]
+func (a *Apis) UpdateProductPrice(c echo.Context) error {
+	const op = "handlers.UpdateProductPrice"
+
+	id := c.QueryParam("id")
+	if id == "" {
+		return httperr.Write(c, http.StatusBadRequest, "missing id")
+	}
+
+	var p views.ProductPrice
+	if err := c.Bind(&p); err != nil {
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.Write(c, http.StatusBadRequest, "bad JSON")
+	}
+	if p.Price < 0 {
+		return httperr.Write(c, http.StatusBadRequest, "price must not be negative")
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 3*time.Second)
+	defer cancel()
+
+	// version is optional here, without it price is applied to current product
+	version, err := requestVersion(c, id, p.Version)
+	if err != nil && !errors.Is(err, errNoVersion) {
+		return versionError(c, err)
+	}
+
+	pr, err := a.apiProduct.UpdateProductPrice(ctx, id, p.Price, version)
+	if err != nil {
+		slog.ErrorContext(ctx, op, "err", err)
+		return httperr.GRPC(c, err, "could not update price")
+	}
+
+	if err := a.rds.InvalidateTags(redis.ProductChangeTags(id, []string{pr.Category.Id}, []string{pr.Brand.Id})...); err != nil {
+		slog.ErrorContext(ctx, op, "err", err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"answer": "price updated successfully"})
+}
+
 // DeleteProduct godoc
 // @Summary Удалить продукт
 // @Description Удаляет продукт по ID
@@ -192,22 +336,34 @@ func (a *Apis) UpdateProduct(c echo.Context) error {
 func (a *Apis) DeleteProduct(c echo.Context) error {
 	const op = "handlers.DeleteProduct"
 
-	//delete cache dict
-	if err := a.rds.CleanDictionaries(); err != nil {
-		log.Println(format.Error(op, err))
-	}
-
 	id := c.QueryParam("id")
 	if id == "" {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "missing id"})
+		return httperr.Write(c, http.StatusBadRequest, "missing id")
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(c.Request().Context(), 3*time.Second)
 	defer cancel()
 
+	pr, err := a.apiProduct.GetProduct(ctx, id)
+	if err != nil {
+		slog.ErrorContext(ctx, op, "err", err)
+	}
+
 	if err := a.apiProduct.DeleteProduct(ctx, id); err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadGateway, map[string]string{"error": "could not delete product"})
+		slog.ErrorContext(ctx, op, "err", err)
+		return httperr.GRPC(c, err, "could not delete product")
+	}
+
+	tags := []string{redis.TagProduct(id)}
+	if pr != nil {
+		tags = redis.ProductChangeTags(id, []string{pr.Category.Id}, []string{pr.Brand.Id})
+	}
+	if err := a.rds.InvalidateTags(tags...); err != nil {
+		slog.ErrorContext(ctx, op, "err", err)
+	}
+
+	if pr != nil {
+		a.releaseImages(ctx, productFiles(pr))
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{"answer": "product deleted successfully"})
@@ -220,7 +376,9 @@ func (a *Apis) DeleteProduct(c echo.Context) error {
 // @Produce json
 // @Param start query int true  "start > 0"
 // @Param end query int true  "end"
+// @Param If-None-Match header string false "ETag из прошлого ответа"
 // @Success 200 {object} views.SWGProductListResponse "Успешный запрос"
+// @Success 304 "Данные не изменились"
 // @Failure 500 {object} views.SWGErrorResponse "Ошибка на сервере"
 // @Failure 502 {object} views.SWGErrorResponse "Ошибка взаимодействия с сервисом"
 // @Router /api/product/getall [get]
@@ -229,20 +387,20 @@ func (a *Apis) GetAllProducts(c echo.Context) error {
 
 	s := c.QueryParam("start")
 	if s == "" {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "bad start"})
+		return httperr.Write(c, http.StatusBadRequest, "bad start")
 	}
 	en := c.QueryParam("end")
 	if en == "" {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "bad end"})
+		return httperr.Write(c, http.StatusBadRequest, "bad end")
 	}
 
 	start, err := strconv.Atoi(s)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "start must be int"})
+		return httperr.Write(c, http.StatusBadRequest, "start must be int")
 	}
 	end, err := strconv.Atoi(en)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "end must be int"})
+		return httperr.Write(c, http.StatusBadRequest, "end must be int")
 	}
 
 	if start > end {
@@ -250,23 +408,29 @@ func (a *Apis) GetAllProducts(c echo.Context) error {
 	}
 
 	if start < 0 {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "start < 0!"})
+		return httperr.Write(c, http.StatusBadRequest, "start < 0!")
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-	defer cancel()
-
-	list, err := a.apiProduct.GetAllProducts(ctx, start, end)
+	var list []views.Product
+	stale, err := a.rds.Fetch(c.Request().Context(), fmt.Sprintf("products:%d:%d", start, end), &list, a.cfg.Live().CacheTTL[config.CacheList], func(ctx context.Context) (any, []string, error) {
+		list, err := a.apiProduct.GetAllProducts(ctx, start, end)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(list) == 0 {
+			list = []views.Product{}
+		}
+		return list, append(redis.ListTags(list), redis.TagProductsAll), nil
+	})
 	if err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadGateway, map[string]string{"error": "could not fetch products"})
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.GRPC(c, err, "could not fetch products")
 	}
-
-	if len(list) == 0 {
-		return c.JSON(http.StatusOK, []views.Product{})
+	if stale {
+		markStale(c)
 	}
 
-	return c.JSON(http.StatusOK, list)
+	return a.conditional(c, list, "", 0)
 }
 
 // GetProduct godoc
@@ -275,7 +439,9 @@ func (a *Apis) GetAllProducts(c echo.Context) error {
 // @Tags product
 // @Produce json
 // @Param id query string true "ID продукта"
+// @Param If-None-Match header string false "ETag из прошлого ответа"
 // @Success 200 {object} views.Product "Успешный запрос"
+// @Success 304 "Данные не изменились"
 // @Failure 500 {object} views.SWGErrorResponse "Ошибка на сервере"
 // @Failure 502 {object} views.SWGErrorResponse "Ошибка взаимодействия с сервисом"
 // @Router /api/product/get [get]
@@ -284,19 +450,26 @@ func (a *Apis) GetProduct(c echo.Context) error {
 
 	id := c.QueryParam("id")
 	if id == "" {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "missing id"})
+		return httperr.Write(c, http.StatusBadRequest, "missing id")
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-	defer cancel()
-
-	pr, err := a.apiProduct.GetProduct(ctx, id)
+	var pr views.Product
+	stale, err := a.rds.Fetch(c.Request().Context(), "product:"+id, &pr, a.cfg.Live().CacheTTL[config.CacheProduct], func(ctx context.Context) (any, []string, error) {
+		pr, err := a.apiProduct.GetProduct(ctx, id)
+		if err != nil {
+			return nil, nil, err
+		}
+		return pr, redis.ProductTags(pr), nil
+	})
 	if err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadGateway, map[string]string{"error": "could not fetch products"})
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.GRPC(c, err, "could not fetch products")
+	}
+	if stale {
+		markStale(c)
 	}
 
-	return c.JSON(http.StatusOK, pr)
+	return a.conditional(c, pr, entityTag(pr.Id, pr.Version, pr.UpdatedAt), pr.UpdatedAt)
 }
 
 // FilterProducts godoc
@@ -306,8 +479,10 @@ func (a *Apis) GetProduct(c echo.Context) error {
 // @Accept json
 // @Produce json
 // @Param filter body views.ProductFilter true "Параметры фильтрации"
+// @Param If-None-Match header string false "ETag из прошлого ответа"
 // @Success 200 {object} views.SWGProductListResponse "Успешный запрос"
 // @Failure 400 {object} views.SWGErrorResponse "Неверный формат запроса"
+// @Failure 412 {object} views.SWGErrorResponse "Данные не изменились (If-None-Match совпал)"
 // @Failure 500 {object} views.SWGErrorResponse "Ошибка на сервере"
 // @Failure 502 {object} views.SWGErrorResponse "Ошибка взаимодействия с сервисом"
 // @Router /api/product/filter [post]
@@ -316,22 +491,28 @@ func (a *Apis) FilterProducts(c echo.Context) error {
 
 	var f views.ProductFilter
 	if err := c.Bind(&f); err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "bad JSON"})
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.Write(c, http.StatusBadRequest, "bad JSON")
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-	defer cancel()
-
-	list, err := a.apiProduct.FilterProducts(ctx, &f)
+	var list []views.Product
+	stale, err := a.rds.Fetch(c.Request().Context(), redis.HashKey("filter:", f), &list, a.cfg.Live().CacheTTL[config.CacheList], func(ctx context.Context) (any, []string, error) {
+		list, err := a.apiProduct.FilterProducts(ctx, &f)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(list) == 0 {
+			list = []views.Product{}
+		}
+		return list, redis.FilterTags(&f, list), nil
+	})
 	if err != nil {
-		log.Println(format.Error(op, err))
-		return c.JSON(http.StatusBadGateway, map[string]string{"error": "could not filter products"})
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.GRPC(c, err, "could not filter products")
 	}
-
-	if len(list) == 0 {
-		return c.JSON(http.StatusOK, []views.Product{})
+	if stale {
+		markStale(c)
 	}
 
-	return c.JSON(http.StatusOK, list)
+	return a.conditional(c, list, "", 0)
 }
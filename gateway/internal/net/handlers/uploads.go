@@ -0,0 +1,373 @@
+package handlers
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"gateway/internal/net/httperr"
+	"gateway/internal/pkg/redis"
+	"gateway/internal/views"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/xid"
+)
+
+const (
+	ChunksDir              = UploadsDir + "/chunks"
+	MaxResumableBytes      = 100 << 20 // 100 MB
+	uploadsCleanupInterval = time.Hour
+
+	tusVersion      = "1.0.0"
+	chunkMediaType  = "application/offset+octet-stream"
+	hdrTusResumable = "Tus-Resumable"
+	hdrUploadLength = "Upload-Length"
+	hdrUploadOffset = "Upload-Offset"
+)
+
+// uploadLocks serialize chunks of one upload, different uploads are written in parallel
+var uploadLocks sync.Map
+
+func lockUpload(id string) func() {
+	mu, _ := uploadLocks.LoadOrStore(id, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	return mu.(*sync.Mutex).Unlock
+}
+
+func chunkPath(id string) string {
+	return filepath.Join(ChunksDir, id)
+}
+
+// CreateUpload godoc
+// @Summary Начать возобновляемую загрузку
+// @Description Создает загрузку большого изображения по частям (протокол по мотивам tus). В заголовке Upload-Length передается полный размер файла в байтах.
+// @Description Адрес для отправки частей возвращается в заголовке Location
+// @Tags files
+// @Produce json
+// @Param Upload-Length header int true "Размер файла в байтах"
+// @Param Idempotency-Key header string false "Ключ идемпотентности: повтор с тем же ключом вернёт первый ответ"
+// @Success 201 {object} views.SWGIdResponse "Загрузка создана"
+// @Failure 400 {object} views.SWGErrorResponse "Неверный размер"
+// @Failure 413 {object} views.SWGErrorResponse "Файл слишком большой"
+// @Failure 409 {object} views.SWGErrorResponse "Запрос с этим ключом ещё выполняется или его результат неизвестен"
+// @Failure 422 {object} views.SWGErrorResponse "Ключ уже использован с другим запросом"
+// @Failure 500 {object} views.SWGErrorResponse "Ошибка на сервере"
+// @Router /api/files/uploads [post]
+func (a *Apis) CreateUpload(c echo.Context) error {
+	const op = "handlers.CreateUpload"
+
+	length, err := strconv.ParseInt(c.Request().Header.Get(hdrUploadLength), 10, 64)
+	if err != nil || length <= 0 {
+		return httperr.Write(c, http.StatusBadRequest, "bad Upload-Length")
+	}
+	if length > MaxResumableBytes {
+		return httperr.Write(c, http.StatusRequestEntityTooLarge, "file is too large")
+	}
+
+	if err := os.MkdirAll(ChunksDir, 0755); err != nil {
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.Write(c, http.StatusInternalServerError, "failed to create upload")
+	}
+
+	u := &views.FileUpload{
+		Id:     xid.New().String(),
+		Length: length,
+	}
+
+	f, err := os.Create(chunkPath(u.Id))
+	if err != nil {
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.Write(c, http.StatusInternalServerError, "failed to create upload")
+	}
+	_ = f.Close()
+
+	if err := a.rds.SetUpload(u); err != nil {
+		_ = os.Remove(chunkPath(u.Id))
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.Write(c, http.StatusInternalServerError, "failed to create upload")
+	}
+
+	c.Response().Header().Set(hdrTusResumable, tusVersion)
+	c.Response().Header().Set(echo.HeaderLocation, c.Request().URL.Path+"/"+u.Id)
+	return c.JSON(http.StatusCreated, map[string]string{"id": u.Id})
+}
+
+// UploadStatus godoc
+// @Summary Состояние возобновляемой загрузки
+// @Description Возвращает в заголовке Upload-Offset сколько байт уже принято сервером. С этого места клиент продолжает отправку после обрыва.
+// @Description В теле возвращается состояние загрузки, после завершения в нем есть имя сохраненного файла
+// @Tags files
+// @Produce json
+// @Param id path string true "ID загрузки"
+// @Success 200 {object} views.FileUpload "Состояние загрузки"
+// @Failure 404 {object} views.SWGErrorResponse "Загрузка не найдена"
+// @Failure 500 {object} views.SWGErrorResponse "Ошибка на сервере"
+// @Router /api/files/uploads/{id} [get]
+// @Router /api/files/uploads/{id} [head]
+func (a *Apis) UploadStatus(c echo.Context) error {
+	const op = "handlers.UploadStatus"
+
+	u, err := a.rds.GetUpload(c.Param("id"))
+	if err != nil {
+		if errors.Is(err, redis.ErrNotFound) {
+			return httperr.Write(c, http.StatusNotFound, "upload not found")
+		}
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.Write(c, http.StatusInternalServerError, "failed to get upload")
+	}
+
+	setUploadHeaders(c, u)
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+	return c.JSON(http.StatusOK, u)
+}
+
+// UploadChunk godoc
+// @Summary Отправить часть файла
+// @Description Дописывает тело запроса (Content-Type: application/offset+octet-stream) в загрузку начиная с Upload-Offset, который должен совпадать с текущим смещением на сервере.
+// @Description Если соединение оборвалось, принятые байты сохраняются. После последней части файл собирается, проверяется и сохраняется как при обычной загрузке
+// @Tags files
+// @Accept octet-stream
+// @Produce json
+// @Param id path string true "ID загрузки"
+// @Param Upload-Offset header int true "Смещение части в байтах"
+// @Success 200 {object} views.FileUpload "Файл загружен полностью"
+// @Success 204 "Часть принята, новое смещение в заголовке Upload-Offset"
+// @Failure 400 {object} views.SWGErrorResponse "Неверные данные"
+// @Failure 404 {object} views.SWGErrorResponse "Загрузка не найдена"
+// @Failure 409 {object} views.SWGErrorResponse "Смещение не совпадает с сервером"
+// @Failure 415 {object} views.SWGErrorResponse "Неверный Content-Type"
+// @Failure 500 {object} views.SWGErrorResponse "Ошибка на сервере"
+// @Router /api/files/uploads/{id} [patch]
+func (a *Apis) UploadChunk(c echo.Context) error {
+	const op = "handlers.UploadChunk"
+
+	if c.Request().Header.Get(echo.HeaderContentType) != chunkMediaType {
+		return httperr.Write(c, http.StatusUnsupportedMediaType, "content type must be "+chunkMediaType)
+	}
+
+	offset, err := strconv.ParseInt(c.Request().Header.Get(hdrUploadOffset), 10, 64)
+	if err != nil || offset < 0 {
+		return httperr.Write(c, http.StatusBadRequest, "bad Upload-Offset")
+	}
+
+	id := c.Param("id")
+	unlock := lockUpload(id)
+	defer unlock()
+
+	u, err := a.rds.GetUpload(id)
+	if err != nil {
+		if errors.Is(err, redis.ErrNotFound) {
+			return httperr.Write(c, http.StatusNotFound, "upload not found")
+		}
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.Write(c, http.StatusInternalServerError, "failed to get upload")
+	}
+
+	if u.Name != "" {
+		setUploadHeaders(c, u)
+		return c.JSON(http.StatusOK, u)
+	}
+	if offset != u.Offset {
+		setUploadHeaders(c, u)
+		return httperr.Write(c, http.StatusConflict, fmt.Sprintf("offset mismatch, expected %d", u.Offset))
+	}
+
+	written, err := appendChunk(id, offset, c.Request().Body, u.Length-offset)
+	u.Offset += written
+	if serr := a.rds.SetUpload(u); serr != nil {
+		slog.ErrorContext(c.Request().Context(), op, "err", serr)
+		return httperr.Write(c, http.StatusInternalServerError, "failed to save upload")
+	}
+	if err != nil {
+		setUploadHeaders(c, u)
+		if errors.Is(err, errTooLarge) {
+			return httperr.Write(c, http.StatusBadRequest, "chunk exceeds Upload-Length")
+		}
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.Write(c, http.StatusInternalServerError, "failed to save chunk")
+	}
+
+	if u.Offset < u.Length {
+		setUploadHeaders(c, u)
+		return c.NoContent(http.StatusNoContent)
+	}
+
+	if err := a.finishUpload(u); err != nil {
+		_ = os.Remove(chunkPath(id))
+		if derr := a.rds.DeleteUpload(id); derr != nil {
+			slog.ErrorContext(c.Request().Context(), op, "err", derr)
+		}
+		uploadLocks.Delete(id)
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.Write(c, uploadErrorStatus(err), uploadErrorMessage(err))
+	}
+
+	if err := a.rds.SetUpload(u); err != nil {
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+	}
+
+	setUploadHeaders(c, u)
+	return c.JSON(http.StatusOK, u)
+}
+
+// CancelUpload godoc
+// @Summary Отменить возобновляемую загрузку
+// @Description Удаляет незавершенную загрузку и принятые части
+// @Tags files
+// @Produce json
+// @Param id path string true "ID загрузки"
+// @Success 200 {object} views.SWGSuccessResponse "Загрузка отменена"
+// @Failure 404 {object} views.SWGErrorResponse "Загрузка не найдена"
+// @Failure 500 {object} views.SWGErrorResponse "Ошибка на сервере"
+// @Router /api/files/uploads/{id} [delete]
+func (a *Apis) CancelUpload(c echo.Context) error {
+	const op = "handlers.CancelUpload"
+
+	id := c.Param("id")
+	unlock := lockUpload(id)
+	defer unlock()
+
+	if _, err := a.rds.GetUpload(id); err != nil {
+		if errors.Is(err, redis.ErrNotFound) {
+			return httperr.Write(c, http.StatusNotFound, "upload not found")
+		}
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.Write(c, http.StatusInternalServerError, "failed to get upload")
+	}
+
+	if err := a.rds.DeleteUpload(id); err != nil {
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+		return httperr.Write(c, http.StatusInternalServerError, "failed to cancel upload")
+	}
+	if err := os.Remove(chunkPath(id)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+	}
+	uploadLocks.Delete(id)
+
+	return c.JSON(http.StatusOK, map[string]string{"answer": "upload canceled"})
+}
+
+func setUploadHeaders(c echo.Context, u *views.FileUpload) {
+	h := c.Response().Header()
+	h.Set(hdrTusResumable, tusVersion)
+	h.Set(hdrUploadOffset, strconv.FormatInt(u.Offset, 10))
+	h.Set(hdrUploadLength, strconv.FormatInt(u.Length, 10))
+}
+
+// appendChunk write body into upload file at offset. Bytes received before an error are kept
+// so client can resume from returned position
+func appendChunk(id string, offset int64, body io.Reader, left int64) (int64, error) {
+	f, err := os.OpenFile(chunkPath(id), os.O_WRONLY, 0644)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	written, err := io.Copy(f, io.LimitReader(body, left))
+	if err != nil {
+		return written, err
+	}
+
+	// client sent more than declared in Upload-Length
+	if n, _ := body.Read(make([]byte, 1)); n > 0 {
+		return written, errTooLarge
+	}
+	return written, nil
+}
+
+// finishUpload check assembled file and move it into images dir like usual upload
+func (a *Apis) finishUpload(u *views.FileUpload) error {
+	f, err := os.Open(chunkPath(u.Id))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	detected, ext, err := detectImage(f)
+	if err != nil {
+		return err
+	}
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+
+	imagesMu.Lock()
+	defer imagesMu.Unlock()
+
+	filename, err := placeImage(chunkPath(u.Id), h.Sum(nil), ext)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(chunkPath(u.Id)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		slog.Error("remove upload chunk", "upload", u.Id, "err", err)
+	}
+
+	u.Name = filename
+	u.Mime = detected
+	uploadLocks.Delete(u.Id)
+	return nil
+}
+
+// CleanUploads periodically remove parts of uploads abandoned for longer than redis.UploadsTTL.
+// Blocks until ctx is done
+func (a *Apis) CleanUploads(ctx context.Context) {
+	const op = "handlers.CleanUploads"
+
+	t := time.NewTicker(uploadsCleanupInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			entries, err := os.ReadDir(ChunksDir)
+			if err != nil {
+				if !errors.Is(err, os.ErrNotExist) {
+					slog.ErrorContext(ctx, op, "err", err)
+				}
+				continue
+			}
+
+			for _, e := range entries {
+				info, err := e.Info()
+				if err != nil || e.IsDir() || time.Since(info.ModTime()) < redis.UploadsTTL {
+					continue
+				}
+				a.removeUpload(ctx, e.Name())
+			}
+		}
+	}
+}
+
+// removeUpload drop abandoned upload: its part, state in redis and lock
+func (a *Apis) removeUpload(ctx context.Context, id string) {
+	const op = "handlers.CleanUploads"
+
+	unlock := lockUpload(id)
+	defer unlock()
+	defer uploadLocks.Delete(id)
+
+	if err := os.Remove(chunkPath(id)); err != nil {
+		slog.ErrorContext(ctx, op, "err", err)
+		return
+	}
+	if err := a.rds.DeleteUpload(id); err != nil {
+		slog.ErrorContext(ctx, op, "err", err)
+	}
+	slog.Info("removed abandoned upload", "op", op, "upload", id)
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	productsRPC "github.com/autumnterror/volha-proto/gen/products"
+	"github.com/labstack/echo/v4"
+)
+
+func testPNG(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 8, 8))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestResumableUpload(t *testing.T) {
+	a, _ := newTestApis(t, &fakeProducts{products: map[string]*productsRPC.ProductId{}})
+
+	e := echo.New()
+	e.POST("/api/files/uploads", a.CreateUpload)
+	e.HEAD("/api/files/uploads/:id", a.UploadStatus)
+	e.PATCH("/api/files/uploads/:id", a.UploadChunk)
+	e.DELETE("/api/files/uploads/:id", a.CancelUpload)
+
+	do := func(method, target string, header map[string]string, body []byte) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, bytes.NewReader(body))
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	create := func(length int) string {
+		rec := do(http.MethodPost, "/api/files/uploads", map[string]string{hdrUploadLength: strconv.Itoa(length)}, nil)
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("create: %d %s", rec.Code, rec.Body)
+		}
+		var out map[string]string
+		_ = json.Unmarshal(rec.Body.Bytes(), &out)
+		return "/api/files/uploads/" + out["id"]
+	}
+	chunk := func(path string, offset int, data []byte) *httptest.ResponseRecorder {
+		return do(http.MethodPatch, path, map[string]string{
+			echo.HeaderContentType: chunkMediaType,
+			hdrUploadOffset:        strconv.Itoa(offset),
+		}, data)
+	}
+
+	file := testPNG(t)
+	half := len(file) / 2
+	path := create(len(file))
+
+	if rec := chunk(path, 0, file[:half]); rec.Code != http.StatusNoContent || rec.Header().Get(hdrUploadOffset) != strconv.Itoa(half) {
+		t.Fatalf("first chunk: %d offset %s", rec.Code, rec.Header().Get(hdrUploadOffset))
+	}
+	// chunk sent again after lost answer
+	if rec := chunk(path, 0, file[:half]); rec.Code != http.StatusConflict || rec.Header().Get(hdrUploadOffset) != strconv.Itoa(half) {
+		t.Fatalf("repeated chunk: %d", rec.Code)
+	}
+	if rec := do(http.MethodHead, path, nil, nil); rec.Header().Get(hdrUploadOffset) != strconv.Itoa(half) {
+		t.Fatalf("status offset: %s", rec.Header().Get(hdrUploadOffset))
+	}
+
+	rec := chunk(path, half, file[half:])
+	if rec.Code != http.StatusOK {
+		t.Fatalf("last chunk: %d %s", rec.Code, rec.Body)
+	}
+	var done struct {
+		Name string `json:"name"`
+		Mime string `json:"mime"`
+	}
+	_ = json.Unmarshal(rec.Body.Bytes(), &done)
+	if done.Mime != "image/png" || !imageExists(done.Name) {
+		t.Fatalf("finished upload: %+v", done)
+	}
+	// finished upload answer same result
+	if rec := chunk(path, half, file[half:]); rec.Code != http.StatusOK || !bytes.Contains(rec.Body.Bytes(), []byte(done.Name)) {
+		t.Fatalf("chunk after finish: %d %s", rec.Code, rec.Body)
+	}
+
+	// more bytes than Upload-Length, accepted part is kept
+	path = create(4)
+	if rec := chunk(path, 0, []byte("123456")); rec.Code != http.StatusBadRequest || rec.Header().Get(hdrUploadOffset) != "4" {
+		t.Fatalf("too long chunk: %d offset %s", rec.Code, rec.Header().Get(hdrUploadOffset))
+	}
+
+	path = create(len(file))
+	if rec := do(http.MethodDelete, path, nil, nil); rec.Code != http.StatusOK {
+		t.Fatalf("cancel: %d", rec.Code)
+	}
+	if rec := chunk(path, 0, file); rec.Code != http.StatusNotFound {
+		t.Fatalf("chunk of canceled upload: %d", rec.Code)
+	}
+}
@@ -0,0 +1,169 @@
+package httperr
+
+import (
+	"errors"
+	"fmt"
+	"gateway/internal/views"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"github.com/labstack/echo/v4"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// StatusClientClosed is answered when client went away before product-service answered (as in nginx)
+const StatusClientClosed = 499
+
+// Write answer with error envelope. Code is made from http status, like NOT_FOUND
+func Write(c echo.Context, httpStatus int, msg string) error {
+	return write(c, httpStatus, &views.ErrorResponse{Error: msg, Code: statusCode(httpStatus)})
+}
+
+// WriteDetails is Write with machine readable details, like current version on conflict
+func WriteDetails(c echo.Context, httpStatus int, msg string, details map[string]string) error {
+	return write(c, httpStatus, &views.ErrorResponse{Error: msg, Code: statusCode(httpStatus), Details: details})
+}
+
+// CodeKey is echo context key with gRPC code of error answered by GRPC. Middlewares use it
+// to know whether call reached product-service
+const CodeKey = "grpc_code"
+
+// GRPC answer with error returned by product-service. Status code is mapped to http one and
+// details (reason, field violations, metadata) are put into envelope. Message of status is shown
+// only for client errors, for others msg is used, so internals do not leak
+func GRPC(c echo.Context, err error, msg string) error {
+	st, ok := grpcStatus(err)
+	if !ok {
+		return Write(c, http.StatusBadGateway, msg)
+	}
+
+	c.Set(CodeKey, st.Code())
+	httpStatus := FromCode(st.Code())
+	resp := &views.ErrorResponse{Error: msg, Code: codeName(st.Code())}
+	if httpStatus < http.StatusInternalServerError || httpStatus == StatusClientClosed {
+		resp.Error = st.Message()
+	}
+
+	for _, d := range st.Details() {
+		switch d := d.(type) {
+		case *errdetails.ErrorInfo:
+			resp.Code = d.GetReason()
+			for k, v := range d.GetMetadata() {
+				if v == "" {
+					continue
+				}
+				if resp.Details == nil {
+					resp.Details = make(map[string]string)
+				}
+				resp.Details[k] = v
+			}
+		case *errdetails.BadRequest:
+			for _, f := range d.GetFieldViolations() {
+				resp.Fields = append(resp.Fields, views.FieldError{Field: f.GetField(), Message: f.GetDescription()})
+			}
+		}
+	}
+	return write(c, httpStatus, resp)
+}
+
+// Handler is echo.HTTPErrorHandler, so errors of echo itself (unknown route, body limit, ...)
+// have same envelope
+func Handler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+	var he *echo.HTTPError
+	if !errors.As(err, &he) {
+		he = echo.NewHTTPError(http.StatusInternalServerError)
+		c.Logger().Error(err)
+	}
+	msg := fmt.Sprint(he.Message)
+	if m, ok := he.Message.(string); ok {
+		msg = m
+	}
+	if c.Request().Method == http.MethodHead {
+		_ = c.NoContent(he.Code)
+		return
+	}
+	_ = Write(c, he.Code, msg)
+}
+
+// FromCode map gRPC code to http status
+func FromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted, codes.FailedPrecondition:
+		return http.StatusConflict
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Canceled:
+		return StatusClientClosed
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusBadGateway
+	}
+}
+
+func write(c echo.Context, httpStatus int, resp *views.ErrorResponse) error {
+	resp.RequestId = RequestId(c)
+	return c.JSON(httpStatus, resp)
+}
+
+// RequestId return id of request set by RequestID middleware, or sent by client
+func RequestId(c echo.Context) string {
+	if id := c.Response().Header().Get(echo.HeaderXRequestID); id != "" {
+		return id
+	}
+	return c.Request().Header.Get(echo.HeaderXRequestID)
+}
+
+// grpcStatus find status in err. Unlike status.FromError it keep message of status itself
+// instead of message of whole wrapped error
+func grpcStatus(err error) (*status.Status, bool) {
+	var se interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &se) {
+		return nil, false
+	}
+	return se.GRPCStatus(), true
+}
+
+// statusCode make code from http status: 404 -> NOT_FOUND
+func statusCode(httpStatus int) string {
+	if httpStatus == StatusClientClosed {
+		return "CLIENT_CLOSED_REQUEST"
+	}
+	text := http.StatusText(httpStatus)
+	if text == "" {
+		return "ERROR"
+	}
+	return strings.ToUpper(strings.NewReplacer(" ", "_", "-", "_", "'", "").Replace(text))
+}
+
+// codeName make code from gRPC code: NotFound -> NOT_FOUND
+func codeName(code codes.Code) string {
+	var b strings.Builder
+	for i, r := range code.String() {
+		if i > 0 && unicode.IsUpper(r) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,54 @@
+package mw
+
+import (
+	"gateway/config"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// HTTPSRedirect send plain http requests to https on port with 308, so method and body of POST are
+// kept. Probes stay on http, docker call them so. X-Forwarded-Proto is
+// here only when request came from trusted proxy, see UntrustedForwarded
+func HTTPSRedirect(port int) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if c.IsTLS() || c.Request().Header.Get(echo.HeaderXForwardedProto) == "https" {
+				return next(c)
+			}
+			switch c.Path() {
+			case "/healthz", "/readyz":
+				return next(c)
+			}
+
+			host := c.Request().Host
+			if h, _, err := net.SplitHostPort(host); err == nil {
+				host = h
+			}
+			host = strings.Trim(host, "[]")
+			if port != 443 {
+				host = net.JoinHostPort(host, strconv.Itoa(port))
+			} else if strings.Contains(host, ":") {
+				host = "[" + host + "]"
+			}
+			return c.Redirect(http.StatusPermanentRedirect, "https://"+host+c.Request().RequestURI)
+		}
+	}
+}
+
+// SecurityHeaders set CSP, nosniff, frame options and referrer policy on every response. HSTS is
+// sent only over https or behind trusted proxy with X-Forwarded-Proto: https
+func SecurityHeaders(cfg *config.Config) echo.MiddlewareFunc {
+	return middleware.SecureWithConfig(middleware.SecureConfig{
+		XSSProtection:         "0",
+		ContentTypeNosniff:    "nosniff",
+		XFrameOptions:         "DENY",
+		HSTSMaxAge:            cfg.HTTPS.HSTSMaxAge,
+		ContentSecurityPolicy: cfg.ContentSecurityPolicy,
+		ReferrerPolicy:        "strict-origin-when-cross-origin",
+	})
+}
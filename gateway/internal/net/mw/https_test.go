@@ -0,0 +1,59 @@
+package mw
+
+import (
+	"gateway/config"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestHTTPSRedirect(t *testing.T) {
+	e := echo.New()
+	e.Pre(UntrustedForwarded(&config.Config{TrustedProxies: []string{"10.1.1.1"}}))
+	e.Use(SecurityHeaders(&config.Config{HTTPS: config.HTTPS{HSTSMaxAge: 60}, ContentSecurityPolicy: "default-src 'self'"}))
+	e.Use(HTTPSRedirect(8443))
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+	e.POST("/api/product/filter", ok)
+	e.GET("/healthz", ok)
+
+	for _, tt := range []struct {
+		host, path, proto string
+		code              int
+		location          string
+		remote            string
+	}{
+		{"shop.example:8080", "/api/product/filter?x=1", "", http.StatusPermanentRedirect, "https://shop.example:8443/api/product/filter?x=1", ""},
+		{"[::1]:8080", "/api/product/filter", "", http.StatusPermanentRedirect, "https://[::1]:8443/api/product/filter", ""},
+		{"shop.example", "/api/product/filter", "https", http.StatusOK, "", "10.1.1.1:4000"},
+		// header of client which is not proxy is ignored
+		{"shop.example", "/api/product/filter", "https", http.StatusPermanentRedirect, "https://shop.example:8443/api/product/filter", ""},
+		{"shop.example:8080", "/healthz", "", http.StatusOK, "", ""},
+	} {
+		method := http.MethodPost
+		if tt.path == "/healthz" {
+			method = http.MethodGet
+		}
+		req := httptest.NewRequest(method, tt.path, nil)
+		req.Host = tt.host
+		if tt.remote != "" {
+			req.RemoteAddr = tt.remote
+		}
+		if tt.proto != "" {
+			req.Header.Set(echo.HeaderXForwardedProto, tt.proto)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		if rec.Code != tt.code || rec.Header().Get(echo.HeaderLocation) != tt.location {
+			t.Errorf("%s%s: %d %q", tt.host, tt.path, rec.Code, rec.Header().Get(echo.HeaderLocation))
+		}
+		if rec.Header().Get("X-Content-Type-Options") != "nosniff" || rec.Header().Get("Content-Security-Policy") == "" {
+			t.Errorf("%s%s: no security headers", tt.host, tt.path)
+		}
+		if hsts := rec.Header().Get("Strict-Transport-Security"); (tt.code == http.StatusOK && tt.proto == "https") != (hsts != "") {
+			t.Errorf("%s%s: hsts %q", tt.host, tt.path, hsts)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package mw
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"gateway/config"
+	"gateway/internal/net/httperr"
+	"gateway/internal/pkg/auth"
+	"gateway/internal/pkg/redis"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	IdempotencyHeader = "Idempotency-Key"
+	// ReplayedHeader is set on response returned from saved result of earlier request
+	ReplayedHeader    = "Idempotent-Replayed"
+	maxIdempotencyKey = 255
+)
+
+// replayHeaders are headers of response saved with it and returned on replay
+var replayHeaders = []string{
+	echo.HeaderContentType, echo.HeaderLocation, "ETag", "Last-Modified",
+	"Tus-Resumable", "Upload-Offset", "Upload-Length",
+}
+
+// Idempotency make retries of request with same Idempotency-Key return result of first one instead
+// of doing it again. Key is scoped to client and route, and reusing it with other body is rejected.
+// Only successful responses are saved, after client error (4xx) retry is executed again. So it is
+// when product-service was not reached at all (Unavailable, circuit breaker is open). After other
+// server error or timeout change may be already made, so key is kept and retry get 409.
+// Requests without key pass as is, so do requests when redis is down. Must be used after AdminAuth
+func Idempotency(cfg *config.Config, rds *redis.Client) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			const op = "mw.Idempotency"
+
+			key := c.Request().Header.Get(IdempotencyHeader)
+			if key == "" {
+				return next(c)
+			}
+			if len(key) > maxIdempotencyKey {
+				return httperr.Write(c, http.StatusBadRequest, "Idempotency-Key is too long")
+			}
+
+			body, err := io.ReadAll(c.Request().Body)
+			if err != nil {
+				return httperr.Write(c, http.StatusBadRequest, "could not read body")
+			}
+			c.Request().Body = io.NopCloser(bytes.NewReader(body))
+
+			client := "ip:" + c.RealIP()
+			if login, ok := c.Get(auth.UserKey).(string); ok && login != "" {
+				client = "user:" + login
+			}
+			key = client + ":" + c.Path() + ":" + key
+
+			h := sha256.New()
+			h.Write([]byte(c.Request().URL.RawQuery))
+			h.Write([]byte{0})
+			h.Write(body)
+			hash := hex.EncodeToString(h.Sum(nil))
+
+			saved, ok, err := rds.StartIdempotent(key, hash)
+			if err != nil {
+				slog.ErrorContext(c.Request().Context(), op, "err", err)
+				return next(c)
+			}
+			if !ok {
+				switch {
+				case saved.Hash != hash:
+					return httperr.Write(c, http.StatusUnprocessableEntity, "Idempotency-Key was already used with other request")
+				case saved.Unknown:
+					return httperr.Write(c, http.StatusConflict, "result of request with this Idempotency-Key is unknown, check it and retry with new key")
+				case !saved.Done:
+					return httperr.Write(c, http.StatusConflict, "request with this Idempotency-Key is in progress")
+				}
+				h := c.Response().Header()
+				for name, values := range saved.Header {
+					h[name] = values
+				}
+				h.Set(ReplayedHeader, "true")
+				return c.Blob(saved.Status, h.Get(echo.HeaderContentType), saved.Body)
+			}
+
+			rec := &recorder{ResponseWriter: c.Response().Writer}
+			c.Response().Writer = rec
+			err = next(c)
+			c.Response().Writer = rec.ResponseWriter
+
+			status := responseStatus(c, err)
+			switch {
+			case status >= http.StatusInternalServerError && !notSent(c):
+				if err := rds.UnknownIdempotent(key, hash, cfg.Live().IdempotencyTTL); err != nil {
+					slog.ErrorContext(c.Request().Context(), op, "err", err)
+				}
+				return err
+			case status >= http.StatusBadRequest:
+				if err := rds.DropIdempotent(key); err != nil {
+					slog.ErrorContext(c.Request().Context(), op, "err", err)
+				}
+				return err
+			}
+
+			header := make(map[string][]string)
+			for _, name := range replayHeaders {
+				if v := c.Response().Header().Values(name); len(v) > 0 {
+					header[http.CanonicalHeaderKey(name)] = v
+				}
+			}
+			if err := rds.FinishIdempotent(key, &redis.IdempotentResponse{
+				Hash:   hash,
+				Status: status,
+				Header: header,
+				Body:   rec.body.Bytes(),
+			}, cfg.Live().IdempotencyTTL); err != nil {
+				slog.ErrorContext(c.Request().Context(), op, "err", err)
+			}
+			return err
+		}
+	}
+}
+
+// notSent tell that request failed because product-service is unavailable, so it did nothing.
+// gRPC answer Unavailable when connection can not be made, breaker.ErrOpen is Unavailable too
+func notSent(c echo.Context) bool {
+	code, ok := c.Get(httperr.CodeKey).(codes.Code)
+	return ok && code == codes.Unavailable
+}
+
+// responseStatus return status response got or will get from error handler
+func responseStatus(c echo.Context, err error) int {
+	if err == nil || c.Response().Committed {
+		return c.Response().Status
+	}
+	var he *echo.HTTPError
+	if errors.As(err, &he) {
+		return he.Code
+	}
+	return http.StatusInternalServerError
+}
+
+// recorder copy response body while it is written to client
+type recorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
@@ -0,0 +1,84 @@
+package mw
+
+import (
+	"gateway/config"
+	"gateway/internal/net/httperr"
+	"gateway/internal/pkg/redis"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestIdempotency(t *testing.T) {
+	mr := miniredis.RunT(t)
+	cfg := &config.Config{RedisAddr: mr.Addr(), IdempotencyTTL: time.Hour}
+	rds := redis.New(cfg)
+	t.Cleanup(func() { _ = rds.Close() })
+
+	calls := 0
+	// answer is err of product-service when it is set
+	var answer error
+	e := echo.New()
+	e.POST("/api/brand/create", func(c echo.Context) error {
+		calls++
+		if answer != nil {
+			return httperr.GRPC(c, answer, "could not create brand")
+		}
+		return c.JSON(http.StatusOK, map[string]int{"n": calls})
+	}, Idempotency(cfg, rds))
+
+	do := func(key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/brand/create", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(IdempotencyHeader, key)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	first := do("k1", `{"name":"a"}`)
+	again := do("k1", `{"name":"a"}`)
+	if calls != 1 || again.Code != http.StatusOK || again.Body.String() != first.Body.String() {
+		t.Fatalf("retry is executed again: calls %d, %d %s", calls, again.Code, again.Body)
+	}
+	if again.Header().Get(ReplayedHeader) != "true" {
+		t.Fatal("replay is not marked")
+	}
+
+	if rec := do("k1", `{"name":"b"}`); rec.Code != http.StatusUnprocessableEntity || calls != 1 {
+		t.Fatalf("key with other body: %d", rec.Code)
+	}
+
+	// product-service was not reached, retry with same key is executed
+	answer = status.Error(codes.Unavailable, "circuit breaker is open")
+	if rec := do("k2", `{}`); rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("unavailable: %d", rec.Code)
+	}
+	answer = nil
+	if rec := do("k2", `{}`); rec.Code != http.StatusOK || calls != 3 {
+		t.Fatalf("retry after unavailable: %d, calls %d", rec.Code, calls)
+	}
+
+	// timeout may be applied, retry with same key is refused
+	answer = status.Error(codes.DeadlineExceeded, "timeout")
+	do("k3", `{}`)
+	answer = nil
+	if rec := do("k3", `{}`); rec.Code != http.StatusConflict || calls != 4 {
+		t.Fatalf("retry after timeout: %d, calls %d", rec.Code, calls)
+	}
+
+	// client error is not saved
+	answer = status.Error(codes.InvalidArgument, "bad")
+	do("k4", `{}`)
+	answer = nil
+	if rec := do("k4", `{}`); rec.Code != http.StatusOK || calls != 6 {
+		t.Fatalf("retry after client error: %d, calls %d", rec.Code, calls)
+	}
+}
@@ -0,0 +1,43 @@
+package mw
+
+import (
+	"gateway/internal/grpc/products"
+	"gateway/internal/pkg/logger"
+	"log/slog"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// RequestFields is RequestIDHandler of echo. Id and route of request are added to every log record
+// of request and sent to product-service, so its logs can be matched with ours
+func RequestFields(c echo.Context, id string) {
+	req := c.Request()
+	ctx := logger.With(req.Context(), "request_id", id, "route", c.Path())
+	c.SetRequest(req.WithContext(products.WithRequest(ctx, id, c.Path())))
+}
+
+// AccessLog log every request with fields of its context
+func AccessLog() echo.MiddlewareFunc {
+	return middleware.RequestLoggerWithConfig(middleware.RequestLoggerConfig{
+		HandleError: true,
+		LogLatency:  true,
+		LogMethod:   true,
+		LogURI:      true,
+		LogStatus:   true,
+		LogRemoteIP: true,
+		LogError:    true,
+		LogValuesFunc: func(c echo.Context, v middleware.RequestLoggerValues) error {
+			level := slog.LevelInfo
+			if v.Status >= 500 {
+				level = slog.LevelError
+			}
+			args := []any{"method", v.Method, "uri", v.URI, "status", v.Status, "latency", v.Latency, "ip", v.RemoteIP}
+			if v.Error != nil {
+				args = append(args, "err", v.Error)
+			}
+			slog.Log(c.Request().Context(), level, "request", args...)
+			return nil
+		},
+	})
+}
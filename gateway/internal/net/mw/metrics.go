@@ -0,0 +1,38 @@
+package mw
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var httpDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "http_request_duration_seconds",
+	Help:    "Latency of HTTP requests, by method, route and status.",
+	Buckets: prometheus.DefBuckets,
+}, []string{"method", "route", "status"})
+
+// Metrics observe latency of every request. Route is path pattern of echo, not real path,
+// so ids do not blow up number of series
+func Metrics() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			err := next(c)
+			if err != nil && !c.Response().Committed {
+				// let error handler write response, so its status is observed
+				c.Error(err)
+			}
+			route := c.Path()
+			if route == "" {
+				route = "unknown"
+			}
+			status := strconv.Itoa(c.Response().Status)
+			httpDuration.WithLabelValues(c.Request().Method, route, status).Observe(time.Since(start).Seconds())
+			return err
+		}
+	}
+}
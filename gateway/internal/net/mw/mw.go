@@ -1,21 +1,192 @@
 package mw
 
 import (
+	"context"
+	"errors"
 	"gateway/config"
+	"gateway/internal/grpc/products"
+	"gateway/internal/net/httperr"
+	"gateway/internal/pkg/auth"
+	"gateway/internal/pkg/logger"
+	"gateway/internal/pkg/redis"
+	"log/slog"
+	"math"
 	"net/http"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/rs/xid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-func AdminAuth(cfg *config.Config) echo.MiddlewareFunc {
+// AdminAuth allow request only with valid admin session cookie or api key in auth.APIKeyHeader.
+// Login, role and permissions are put to echo context and acting admin is added to request
+// context for product-service
+func AdminAuth(cfg *config.Config, rds *redis.Client, api *products.Client) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			cookie, err := c.Cookie("admin_pw")
-			if err != nil || cookie.Value != cfg.AdminPW {
-				return c.JSON(http.StatusUnauthorized, map[string]string{
-					"error": "unauthorized",
-				})
+			const op = "mw.AdminAuth"
+
+			if key := c.Request().Header.Get(auth.APIKeyHeader); key != "" {
+				if !apiKeyAuth(c, rds, key) {
+					return httperr.Write(c, http.StatusUnauthorized, "unauthorized")
+				}
+				return next(c)
+			}
+
+			cookie, err := c.Cookie(auth.CookieName)
+			if err != nil {
+				return httperr.Write(c, http.StatusUnauthorized, "unauthorized")
+			}
+			sid, err := auth.ParseToken(cfg.SessionSecret, cookie.Value)
+			if err != nil {
+				return httperr.Write(c, http.StatusUnauthorized, "unauthorized")
+			}
+			login, err := rds.GetSession(sid)
+			if err != nil {
+				if !errors.Is(err, redis.ErrNotFound) {
+					slog.ErrorContext(c.Request().Context(), op, "err", err)
+				}
+				return httperr.Write(c, http.StatusUnauthorized, "unauthorized")
+			}
+
+			// role is read on every request, so change of it or delete of admin work at once
+			ctx, cancel := context.WithTimeout(c.Request().Context(), 3*time.Second)
+			u, err := api.GetAdmin(products.AsGateway(ctx), login)
+			cancel()
+			if err != nil {
+				if status.Code(err) != codes.NotFound {
+					slog.ErrorContext(c.Request().Context(), op, "err", err)
+					return httperr.GRPC(c, err, "could not check session")
+				}
+				return httperr.Write(c, http.StatusUnauthorized, "unauthorized")
+			}
+
+			role := auth.Role(u.Role)
+			c.Set(auth.UserKey, u.Login)
+			c.Set(auth.RoleKey, role)
+			c.Set(auth.PermsKey, role.Perms())
+			req := c.Request()
+			ctx = logger.With(req.Context(), "admin", u.Login)
+			c.SetRequest(req.WithContext(products.WithActor(ctx, u.Login, u.Role)))
+			return next(c)
+		}
+	}
+}
+
+// APIKey check api key if client sent it. Requests without key pass as is, so it can be used on public api
+// to count usage of partners
+func APIKey(rds *redis.Client) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key := c.Request().Header.Get(auth.APIKeyHeader)
+			if key == "" {
+				return next(c)
+			}
+			if !apiKeyAuth(c, rds, key) {
+				return httperr.Write(c, http.StatusUnauthorized, "unauthorized")
+			}
+			return next(c)
+		}
+	}
+}
+
+func apiKeyAuth(c echo.Context, rds *redis.Client, key string) bool {
+	const op = "mw.apiKeyAuth"
+
+	id, secret, err := auth.ParseAPIKey(key)
+	if err != nil {
+		return false
+	}
+	k, err := rds.GetAPIKey(id)
+	if err != nil {
+		if !errors.Is(err, redis.ErrNotFound) {
+			slog.ErrorContext(c.Request().Context(), op, "err", err)
+		}
+		return false
+	}
+	if !auth.CheckAPIKeySecret(k.Hash, secret) {
+		return false
+	}
+
+	if err := rds.TouchAPIKey(k.Id); err != nil {
+		slog.ErrorContext(c.Request().Context(), op, "err", err)
+	}
+
+	perms := auth.ScopePerms(k.Scopes)
+	login := "apikey:" + k.Name
+	c.Set(auth.UserKey, login)
+	c.Set(auth.PermsKey, perms)
+
+	names := make([]string, 0, len(perms))
+	for _, p := range perms {
+		names = append(names, string(p))
+	}
+	req := c.Request()
+	ctx := logger.With(req.Context(), "admin", login)
+	c.SetRequest(req.WithContext(products.WithActor(ctx, login, "", names...)))
+	return true
+}
+
+// KeyRequire allow request without api key, but request with key only if key has permission.
+// Catalog is public, so scope of key is checked only when key is sent
+func KeyRequire(p auth.Perm) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if c.Request().Header.Get(auth.APIKeyHeader) == "" {
+				return next(c)
+			}
+			perms, _ := c.Get(auth.PermsKey).([]auth.Perm)
+			if !slices.Contains(perms, p) {
+				return httperr.Write(c, http.StatusForbidden, "forbidden")
+			}
+			return next(c)
+		}
+	}
+}
+
+// Require allow request only if admin or api key has permission. Must be used after AdminAuth
+func Require(p auth.Perm) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			perms, _ := c.Get(auth.PermsKey).([]auth.Perm)
+			if !slices.Contains(perms, p) {
+				return httperr.Write(c, http.StatusForbidden, "forbidden")
+			}
+			return next(c)
+		}
+	}
+}
+
+// RateLimit limit requests of one client to route group by token bucket from cfg.RateLimits[group].
+// Client is api key or admin when they are known, otherwise ip. If redis is down requests pass.
+// Limits are read on every request, so reload of config change them
+func RateLimit(cfg *config.Config, rds *redis.Client, group string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			const op = "mw.RateLimit"
+
+			l := cfg.Live().RateLimits[group]
+
+			client := "ip:" + c.RealIP()
+			if login, ok := c.Get(auth.UserKey).(string); ok && login != "" {
+				client = "user:" + login
+			}
+
+			ok, wait, err := rds.Allow(group+":"+client, l.Rate, l.Burst)
+			if err != nil {
+				slog.ErrorContext(c.Request().Context(), op, "err", err)
+				return next(c)
+			}
+			if !ok {
+				c.Response().Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+				return httperr.Write(c, http.StatusTooManyRequests, "too many requests")
 			}
 			return next(c)
 		}
@@ -30,9 +201,25 @@ func CheckId() echo.MiddlewareFunc {
 				return next(c)
 			}
 			if _, err := xid.FromString(id); err != nil {
-				return c.JSON(http.StatusBadRequest, map[string]string{
-					"error": "bad id",
-				})
+				return httperr.Write(c, http.StatusBadRequest, "bad id")
+			}
+			return next(c)
+		}
+	}
+}
+
+// HideDotFiles answer 404 for hidden files of static dir, images being copied in are such
+func HideDotFiles() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			p, err := url.PathUnescape(c.Param("*"))
+			if err != nil {
+				return echo.ErrNotFound
+			}
+			for _, part := range strings.Split(filepath.ToSlash(p), "/") {
+				if strings.HasPrefix(part, ".") {
+					return echo.ErrNotFound
+				}
 			}
 			return next(c)
 		}
@@ -0,0 +1,71 @@
+package mw
+
+import (
+	"gateway/config"
+	"log/slog"
+	"net"
+
+	"github.com/labstack/echo/v4"
+)
+
+// IPExtractor return ip of client for rate limits and lockouts. Without trusted_proxies it is
+// address of connection and X-Forwarded-For is ignored, any client can send it. With them chain of
+// X-Forwarded-For is read from the end while addresses are trusted proxies
+func IPExtractor(cfg *config.Config) echo.IPExtractor {
+	nets := proxies(cfg)
+	if len(nets) == 0 {
+		return echo.ExtractIPDirect()
+	}
+	// private and loopback networks are trusted by echo by default, only config is trusted here
+	opts := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, n := range nets {
+		opts = append(opts, echo.TrustIPRange(n))
+	}
+	return echo.ExtractIPFromXFFHeader(opts...)
+}
+
+func proxies(cfg *config.Config) []*net.IPNet {
+	nets, err := config.ParseProxies(cfg.TrustedProxies)
+	if err != nil {
+		// config is validated on start, so it is not expected
+		slog.Error("trusted_proxies", "err", err)
+		return nil
+	}
+	return nets
+}
+
+// schemeHeaders is headers by which echo and Secure middleware learn that request came over https
+var schemeHeaders = []string{echo.HeaderXForwardedProto, echo.HeaderXForwardedProtocol, echo.HeaderXForwardedSsl, echo.HeaderXUrlScheme}
+
+// UntrustedForwarded remove scheme headers of requests which do not come from trusted proxy, so
+// plain http client can not skip redirect to https and get HSTS over cleartext
+func UntrustedForwarded(cfg *config.Config) echo.MiddlewareFunc {
+	nets := proxies(cfg)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !fromProxy(nets, c.Request().RemoteAddr) {
+				for _, h := range schemeHeaders {
+					c.Request().Header.Del(h)
+				}
+			}
+			return next(c)
+		}
+	}
+}
+
+func fromProxy(nets []*net.IPNet, remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, n := range nets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+package mw
+
+import (
+	"gateway/config"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestIPExtractor(t *testing.T) {
+	for _, tt := range []struct {
+		proxies []string
+		remote  string
+		want    string
+	}{
+		{nil, "203.0.113.5:1234", "203.0.113.5"},
+		{[]string{"10.0.0.1"}, "10.0.0.1:1234", "198.51.100.7"},
+		{[]string{"10.0.0.1"}, "203.0.113.5:1234", "203.0.113.5"},
+		{[]string{"10.0.0.0/8"}, "10.0.0.1:1234", "198.51.100.7"},
+	} {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.RemoteAddr = tt.remote
+		req.Header.Set(echo.HeaderXForwardedFor, "198.51.100.7")
+		if got := IPExtractor(&config.Config{TrustedProxies: tt.proxies})(req); got != tt.want {
+			t.Errorf("proxies %v, remote %s: ip = %s, want %s", tt.proxies, tt.remote, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package mw
+
+import (
+	"gateway/config"
+	"gateway/internal/pkg/auth"
+	"gateway/internal/pkg/redis"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/labstack/echo/v4"
+)
+
+func TestRateLimit(t *testing.T) {
+	mr := miniredis.RunT(t)
+	cfg := &config.Config{
+		RedisAddr:  mr.Addr(),
+		RateLimits: map[string]config.RateLimit{config.LimitSearch: {Rate: 0.01, Burst: 2}},
+	}
+	rds := redis.New(cfg)
+	t.Cleanup(func() { _ = rds.Close() })
+
+	// user act as AdminAuth for requests with login
+	user := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if login := c.Request().Header.Get("X-Test-User"); login != "" {
+				c.Set(auth.UserKey, login)
+			}
+			return next(c)
+		}
+	}
+	e := echo.New()
+	e.GET("/search", func(c echo.Context) error { return c.NoContent(http.StatusOK) },
+		user, RateLimit(cfg, rds, config.LimitSearch))
+
+	do := func(ip, login string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/search", nil)
+		req.RemoteAddr = ip + ":1234"
+		if login != "" {
+			req.Header.Set("X-Test-User", login)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for range 2 {
+		if rec := do("10.0.0.1", ""); rec.Code != http.StatusOK {
+			t.Fatalf("request of burst: %d", rec.Code)
+		}
+	}
+	rec := do("10.0.0.1", "")
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") != "100" {
+		t.Fatalf("request over limit: %d, Retry-After %q", rec.Code, rec.Header().Get("Retry-After"))
+	}
+
+	// other ip and admin behind same ip are counted apart
+	if rec := do("10.0.0.2", ""); rec.Code != http.StatusOK {
+		t.Fatalf("other ip: %d", rec.Code)
+	}
+	if rec := do("10.0.0.1", "root"); rec.Code != http.StatusOK {
+		t.Fatalf("admin: %d", rec.Code)
+	}
+
+	// redis is down, requests pass
+	mr.Close()
+	if rec := do("10.0.0.1", ""); rec.Code != http.StatusOK {
+		t.Fatalf("redis down: %d", rec.Code)
+	}
+}
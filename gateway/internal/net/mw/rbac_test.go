@@ -0,0 +1,77 @@
+package mw
+
+import (
+	"gateway/internal/pkg/auth"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+// withPerms act as AdminAuth or APIKey: set permissions of request
+func withPerms(perms []auth.Perm) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if perms != nil {
+				c.Set(auth.PermsKey, perms)
+			}
+			return next(c)
+		}
+	}
+}
+
+func TestKeyRequire(t *testing.T) {
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+
+	for _, tt := range []struct {
+		name   string
+		key    bool
+		scopes []string
+		code   int
+	}{
+		{"anonymous", false, nil, http.StatusOK},
+		{"read key", true, []string{"catalog:read"}, http.StatusOK},
+		{"products key read too", true, []string{"products:write"}, http.StatusOK},
+		{"upload key", true, []string{"files:upload"}, http.StatusForbidden},
+	} {
+		e := echo.New()
+		e.GET("/api/product/get", ok, withPerms(auth.ScopePerms(tt.scopes)), KeyRequire(auth.PermCatalogRead))
+		req := httptest.NewRequest(http.MethodGet, "/api/product/get", nil)
+		if tt.key {
+			req.Header.Set(auth.APIKeyHeader, "key")
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		if rec.Code != tt.code {
+			t.Errorf("%s: %d", tt.name, rec.Code)
+		}
+	}
+}
+
+func TestRequire(t *testing.T) {
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+
+	for _, tt := range []struct {
+		name  string
+		perms []auth.Perm
+		perm  auth.Perm
+		code  int
+	}{
+		{"owner delete", auth.RoleOwner.Perms(), auth.PermDelete, http.StatusOK},
+		{"content delete", auth.RoleContent.Perms(), auth.PermDelete, http.StatusForbidden},
+		{"pricing price", auth.RolePricing.Perms(), auth.PermPriceWrite, http.StatusOK},
+		{"pricing products", auth.RolePricing.Perms(), auth.PermProductsWrite, http.StatusForbidden},
+		{"content users", auth.RoleContent.Perms(), auth.PermUsers, http.StatusForbidden},
+		{"upload key", auth.ScopePerms([]string{"files:upload"}), auth.PermFilesUpload, http.StatusOK},
+		{"no perms", nil, auth.PermCatalogWrite, http.StatusForbidden},
+	} {
+		e := echo.New()
+		e.POST("/api/x", ok, withPerms(tt.perms), Require(tt.perm))
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/x", nil))
+		if rec.Code != tt.code {
+			t.Errorf("%s: %d", tt.name, rec.Code)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/rs/xid"
+)
+
+const (
+	// APIKeyHeader is header with api key of machine clients
+	APIKeyHeader = "X-API-Key"
+	apiKeyPrefix = "vk_"
+)
+
+var ErrBadAPIKey = errors.New("bad api key")
+
+// NewAPIKey return key for client, its id and hash of secret part which is stored instead of key
+func NewAPIKey() (key, id, hash string, err error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", "", err
+	}
+	secret := base64.RawURLEncoding.EncodeToString(raw)
+	id = xid.New().String()
+	return apiKeyPrefix + id + "_" + secret, id, hashSecret(secret), nil
+}
+
+// ParseAPIKey split key to id and secret
+func ParseAPIKey(key string) (id, secret string, err error) {
+	rest, ok := strings.CutPrefix(key, apiKeyPrefix)
+	if !ok {
+		return "", "", ErrBadAPIKey
+	}
+	id, secret, ok = strings.Cut(rest, "_")
+	if !ok || id == "" || secret == "" {
+		return "", "", ErrBadAPIKey
+	}
+	return id, secret, nil
+}
+
+// CheckAPIKeySecret compare secret with stored hash in constant time
+func CheckAPIKeySecret(hash, secret string) bool {
+	return subtle.ConstantTimeCompare([]byte(hash), []byte(hashSecret(secret))) == 1
+}
+
+// secret is 256 random bits, so plain sha256 is enough here
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// CookieName is name of cookie with admin session token
+	CookieName = "admin_session"
+	// UserKey is echo context key with login of authenticated admin
+	UserKey = "admin_user"
+)
+
+var ErrBadToken = errors.New("bad session token")
+
+// dummyHash is compared when user does not exist, so response time does not tell whether login is valid
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("volha-dummy-password"), bcrypt.DefaultCost)
+
+func HashPassword(pw string) (string, error) {
+	h, err := bcrypt.GenerateFromPassword([]byte(pw), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(h), nil
+}
+
+// CheckPassword compare password with bcrypt hash. Empty hash is compared with dummy hash and always fails
+func CheckPassword(hash, pw string) bool {
+	if hash == "" {
+		_ = bcrypt.CompareHashAndPassword(dummyHash, []byte(pw))
+		return false
+	}
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(pw)) == nil
+}
+
+// NewToken return signed token for cookie and session id under which session is stored
+func NewToken(secret string) (token, sid string, err error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", err
+	}
+	body := base64.RawURLEncoding.EncodeToString(raw)
+	return body + "." + sign(secret, body), SessionId(body), nil
+}
+
+// ParseToken check signature of token and return session id
+func ParseToken(secret, token string) (string, error) {
+	body, sig, ok := strings.Cut(token, ".")
+	if !ok || body == "" {
+		return "", ErrBadToken
+	}
+	if !hmac.Equal([]byte(sig), []byte(sign(secret, body))) {
+		return "", ErrBadToken
+	}
+	return SessionId(body), nil
+}
+
+// SessionId is hash of token body, so redis dump does not contain usable tokens
+func SessionId(body string) string {
+	sum := sha256.Sum256([]byte(body))
+	return hex.EncodeToString(sum[:])
+}
+
+func sign(secret, body string) string {
+	m := hmac.New(sha256.New, []byte(secret))
+	m.Write([]byte(body))
+	return base64.RawURLEncoding.EncodeToString(m.Sum(nil))
+}
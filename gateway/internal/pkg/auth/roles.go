@@ -0,0 +1,81 @@
+package auth
+
+import "slices"
+
+type Role string
+
+const (
+	// RoleOwner can do everything including deletes and admin management
+	RoleOwner Role = "owner"
+	// RoleContent edit products, photos and dictionaries but not prices
+	RoleContent Role = "content"
+	// RolePricing change only prices of products
+	RolePricing Role = "pricing"
+)
+
+type Perm string
+
+// Same permissions are checked by product-service in internal/grpc/rbac.go
+const (
+	// PermCatalogRead is needed by api key to read catalog. Requests without key read it freely
+	PermCatalogRead   Perm = "catalog:read"
+	PermCatalogWrite  Perm = "catalog:write"
+	PermProductsWrite Perm = "products:write"
+	PermPriceWrite    Perm = "price:write"
+	PermFilesUpload   Perm = "files:upload"
+	PermDelete        Perm = "catalog:delete"
+	PermUsers         Perm = "users:manage"
+)
+
+const (
+	RoleKey = "admin_role"
+	// PermsKey is echo context key with []Perm of authenticated admin or api key
+	PermsKey = "admin_perms"
+)
+
+var rolePerms = map[Role][]Perm{
+	RoleOwner: {
+		PermCatalogRead, PermCatalogWrite, PermProductsWrite, PermPriceWrite,
+		PermFilesUpload, PermDelete, PermUsers,
+	},
+	RoleContent: {PermCatalogRead, PermCatalogWrite, PermProductsWrite, PermFilesUpload},
+	RolePricing: {PermCatalogRead, PermPriceWrite},
+}
+
+// Scopes of api keys. Key which write products read them too
+var scopePerms = map[string][]Perm{
+	"catalog:read":   {PermCatalogRead},
+	"products:write": {PermCatalogRead, PermProductsWrite, PermPriceWrite},
+	"files:upload":   {PermFilesUpload},
+}
+
+func (r Role) Valid() bool {
+	_, ok := rolePerms[r]
+	return ok
+}
+
+func (r Role) Can(p Perm) bool {
+	return slices.Contains(rolePerms[r], p)
+}
+
+func (r Role) Perms() []Perm {
+	return rolePerms[r]
+}
+
+func ValidScope(s string) bool {
+	_, ok := scopePerms[s]
+	return ok
+}
+
+// ScopePerms return permissions granted by api key scopes
+func ScopePerms(scopes []string) []Perm {
+	var out []Perm
+	for _, s := range scopes {
+		for _, p := range scopePerms[s] {
+			if !slices.Contains(out, p) {
+				out = append(out, p)
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,142 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type state int
+
+const (
+	closed state = iota
+	open
+	halfOpen
+)
+
+func (s state) String() string {
+	switch s {
+	case open:
+		return "open"
+	case halfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// ErrOpen is returned without calling backend while breaker is open
+var ErrOpen = status.Error(codes.Unavailable, "circuit breaker is open")
+
+// Breaker stop calls to backend after failures in row. After cooldown it let one call through,
+// and close again if it succeed
+type Breaker struct {
+	name     string
+	failures int
+	cooldown time.Duration
+
+	mu       sync.Mutex
+	state    state
+	fails    int
+	openedAt time.Time
+	probing  bool
+}
+
+func New(name string, failures int, cooldown time.Duration) *Breaker {
+	return &Breaker{name: name, failures: failures, cooldown: cooldown}
+}
+
+// Unavailable report if err mean backend is down or breaker is open, so there is no answer from it at all
+func Unavailable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return false
+	}
+	return st.Code() == codes.Unavailable || st.Code() == codes.DeadlineExceeded
+}
+
+// allow report if call can go to backend
+func (b *Breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case open:
+		if time.Since(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.setState(halfOpen)
+		b.probing = true
+		return true
+	case halfOpen:
+		// only one probe at a time
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// done record result of call let by allow. ctx is context of call: when caller itself gave up
+// (its deadline passed or it was cancelled) error says nothing about backend and is not counted.
+// So is error which is not gRPC status, it is made on client side
+func (b *Breaker) done(ctx context.Context, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if err != nil && ctx.Err() != nil {
+		return
+	}
+	if _, ok := status.FromError(err); !ok || status.Code(err) == codes.Canceled {
+		return
+	}
+	if !Unavailable(err) {
+		b.fails = 0
+		if b.state != closed {
+			b.setState(closed)
+		}
+		return
+	}
+
+	b.fails++
+	if b.state == halfOpen || b.fails >= b.failures {
+		b.openedAt = time.Now()
+		if b.state != open {
+			b.setState(open)
+		}
+	}
+}
+
+func (b *Breaker) setState(s state) {
+	slog.Warn("breaker state changed", "breaker", b.name, "from", b.state.String(), "to", s.String())
+	b.state = s
+}
+
+// UnaryClientInterceptor reject calls with ErrOpen while breaker is open.
+// It must be before retry interceptor, so all retries of call count as one failure
+func (b *Breaker) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if !b.allow() {
+			return ErrOpen
+		}
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		b.done(ctx, err)
+		return err
+	}
+}
@@ -0,0 +1,53 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBreaker(t *testing.T) {
+	b := New("test", 2, time.Hour)
+	call := b.UnaryClientInterceptor()
+	fail := func(err error) grpc.UnaryInvoker {
+		return func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error { return err }
+	}
+
+	unavailable := status.Error(codes.Unavailable, "down")
+	_ = call(context.Background(), "/m", nil, nil, nil, fail(unavailable))
+	if b.state != closed {
+		t.Fatal("opened after one failure")
+	}
+
+	// own timeout and cancel of caller are not failures of backend
+	expired, cancel := context.WithTimeout(context.Background(), -time.Second)
+	defer cancel()
+	_ = call(expired, "/m", nil, nil, nil, fail(status.Error(codes.DeadlineExceeded, "deadline")))
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	_ = call(cancelled, "/m", nil, nil, nil, fail(status.Error(codes.Canceled, "canceled")))
+	_ = call(context.Background(), "/m", nil, nil, nil, fail(errors.New("not a status")))
+	if b.state != closed || b.fails != 1 {
+		t.Fatalf("errors of caller are counted: state %s, fails %d", b.state, b.fails)
+	}
+
+	// timeout of backend while caller still wait is failure
+	_ = call(context.Background(), "/m", nil, nil, nil, fail(status.Error(codes.DeadlineExceeded, "deadline")))
+	if b.state != open {
+		t.Fatal("not opened after failures in row")
+	}
+	if err := call(context.Background(), "/m", nil, nil, nil, fail(nil)); !errors.Is(err, ErrOpen) {
+		t.Fatalf("open breaker let call: %v", err)
+	}
+
+	// after cooldown one probe close it
+	b.openedAt = time.Now().Add(-2 * time.Hour)
+	if err := call(context.Background(), "/m", nil, nil, nil, fail(nil)); err != nil || b.state != closed {
+		t.Fatalf("probe: %v, state %s", err, b.state)
+	}
+}
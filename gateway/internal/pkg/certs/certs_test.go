@@ -0,0 +1,80 @@
+package certs
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestDevTrust(t *testing.T) {
+	dir := t.TempDir()
+	server, err := Dev(Config{DevDir: dir}, "product-service")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := Dev(Config{DevDir: dir}, "gateway")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server.CAFile != client.CAFile {
+		t.Fatal("services in one dir must have one CA")
+	}
+
+	f, err := load(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, pool := f.current()
+	if pool == nil || cert.Leaf.DNSNames[0] != "gateway" {
+		t.Fatalf("bad dev certificate %v", cert.Leaf.DNSNames)
+	}
+
+	// second run reuse files
+	again, err := Dev(Config{DevDir: dir}, "gateway")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := mustLoad(t, again).cert.Leaf.SerialNumber; got.Cmp(cert.Leaf.SerialNumber) != 0 {
+		t.Fatal("dev certificate is created again")
+	}
+}
+
+func TestReload(t *testing.T) {
+	old := reloadInterval
+	reloadInterval = 0
+	t.Cleanup(func() { reloadInterval = old })
+
+	cfg, err := Dev(Config{DevDir: t.TempDir()}, "gateway")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := mustLoad(t, cfg)
+	before, _ := f.current()
+
+	if err := os.Remove(cfg.CertFile); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Dev(cfg, "gateway"); err != nil {
+		t.Fatal(err)
+	}
+	future := time.Now().Add(time.Minute)
+	for _, name := range []string{cfg.CertFile, cfg.KeyFile} {
+		if err := os.Chtimes(name, future, future); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	after, _ := f.current()
+	if after.Leaf.SerialNumber.Cmp(before.Leaf.SerialNumber) == 0 {
+		t.Fatal("certificate is not reloaded")
+	}
+}
+
+func mustLoad(t *testing.T, cfg Config) *files {
+	t.Helper()
+	f, err := load(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
@@ -0,0 +1,93 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gateway/internal/utils/format"
+	"log/slog"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// Component is part of service with its own start and stop. Run block while component work and
+// return when Stop is called, nil Run is for components which are only closed (connection to redis).
+// Nil Stop is for components with nothing to close
+type Component struct {
+	Name string
+	Run  func() error
+	Stop func(ctx context.Context) error
+}
+
+// Manager start components in order of Add and stop them in reverse order, so server is stopped
+// before connections which it use
+type Manager struct {
+	timeout    time.Duration
+	components []Component
+}
+
+// New construct manager. timeout is time to stop all components, after it they are closed hard
+func New(timeout time.Duration) *Manager {
+	return &Manager{timeout: timeout}
+}
+
+func (m *Manager) Add(c Component) {
+	m.components = append(m.components, c)
+}
+
+// Run start components and block until SIGTERM, SIGINT, done of ctx or fail of any component.
+// Then all components are stopped. Error is fail of component and errors of stop
+func (m *Manager) Run(ctx context.Context) error {
+	const op = "lifecycle.Run"
+
+	ctx, cancel := signal.NotifyContext(ctx, syscall.SIGTERM, syscall.SIGINT)
+	defer cancel()
+
+	failed := make(chan error, len(m.components))
+	for _, c := range m.components {
+		if c.Run == nil {
+			continue
+		}
+		go func() {
+			if err := c.Run(); err != nil {
+				failed <- fmt.Errorf("%s: %w", c.Name, err)
+			}
+		}()
+		slog.Info("component started", "op", op, "component", c.Name)
+	}
+
+	var runErr error
+	select {
+	case <-ctx.Done():
+		slog.Info("shutting down", "op", op, "timeout", m.timeout)
+	case runErr = <-failed:
+		slog.Error("component failed, shutting down", "op", op, "err", runErr)
+	}
+	return errors.Join(runErr, m.Shutdown())
+}
+
+// Shutdown stop components in reverse order. Every Stop get same ctx with timeout of manager,
+// so slow drain of server leave less time for others, but they are still called
+func (m *Manager) Shutdown() error {
+	const op = "lifecycle.Shutdown"
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+
+	var errs []error
+	for i := len(m.components) - 1; i >= 0; i-- {
+		c := m.components[i]
+		if c.Stop == nil {
+			continue
+		}
+		start := time.Now()
+		if err := c.Stop(ctx); err != nil {
+			slog.Error("stop component", "op", op, "component", c.Name, "err", err)
+			errs = append(errs, fmt.Errorf("%s: %w", c.Name, err))
+			continue
+		}
+		slog.Info("component stopped", "op", op, "component", c.Name, "duration", time.Since(start))
+	}
+	return format.Error(op, errors.Join(errs...))
+}
@@ -0,0 +1,66 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestRunStopInReverseOrder(t *testing.T) {
+	var stopped []string
+	m := New(time.Second)
+	for _, name := range []string{"db", "cache", "server"} {
+		m.Add(Component{Name: name, Stop: func(context.Context) error {
+			stopped = append(stopped, name)
+			return nil
+		}})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := m.Run(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(stopped, []string{"server", "cache", "db"}) {
+		t.Fatalf("stop order = %v", stopped)
+	}
+}
+
+func TestRunFailedComponent(t *testing.T) {
+	var closed bool
+	m := New(time.Second)
+	m.Add(Component{Name: "db", Stop: func(context.Context) error {
+		closed = true
+		return nil
+	}})
+	m.Add(Component{Name: "server", Run: func() error { return errors.New("port is busy") }})
+
+	if err := m.Run(context.Background()); err == nil {
+		t.Fatal("want error of failed component")
+	}
+	if !closed {
+		t.Fatal("other components are not stopped")
+	}
+}
+
+func TestShutdownTimeout(t *testing.T) {
+	var closed bool
+	m := New(10 * time.Millisecond)
+	m.Add(Component{Name: "db", Stop: func(context.Context) error {
+		closed = true
+		return nil
+	}})
+	m.Add(Component{Name: "server", Stop: func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}})
+
+	if err := m.Shutdown(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v", err)
+	}
+	if !closed {
+		t.Fatal("component after slow one is not stopped")
+	}
+}
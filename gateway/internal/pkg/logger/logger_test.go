@@ -0,0 +1,38 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+func TestWith(t *testing.T) {
+	var buf bytes.Buffer
+	l := slog.New(handler{slog.NewJSONHandler(&buf, nil)})
+
+	ctx := With(context.Background(), "request_id", "r1")
+	ctx = With(ctx, "admin", "bob")
+	l.InfoContext(ctx, "request")
+
+	var rec map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatal(err)
+	}
+	if rec["request_id"] != "r1" || rec["admin"] != "bob" {
+		t.Fatalf("fields of context are lost: %v", rec)
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	if l, _ := ParseLevel("", "DEV"); l != slog.LevelDebug {
+		t.Fatalf("DEV default = %s", l)
+	}
+	if l, _ := ParseLevel("warn", ""); l != slog.LevelWarn {
+		t.Fatalf("warn = %s", l)
+	}
+	if _, err := ParseLevel("loud", ""); err == nil {
+		t.Fatal("bad level is accepted")
+	}
+}
@@ -0,0 +1,151 @@
+package redis
+
+import (
+	"context"
+	"gateway/internal/utils/format"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+const (
+	sessionsKey     = "admin:session:"
+	userSessionsKey = "admin:sessions:"
+	loginFailsKey   = "admin:fails:"
+)
+
+const (
+	// MaxLoginFails is count of wrong passwords after which login is locked for LockoutTTL
+	MaxLoginFails = 5
+	// MaxLoginFailsIP is the same limit for one client address over all logins
+	MaxLoginFailsIP = 20
+	LockoutTTL      = 15 * time.Minute
+)
+
+// SetSession store session of admin. Session id is also kept in set of admin sessions
+// so all of them can be dropped on password change
+func (c *Client) SetSession(sid, login string, ttl time.Duration) error {
+	const (
+		op = "redis.SetSession"
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	_, err := c.Rdb.TxPipelined(ctx, func(p redis.Pipeliner) error {
+		p.Set(ctx, sessionsKey+sid, login, ttl)
+		p.SAdd(ctx, userSessionsKey+login, sid)
+		p.Expire(ctx, userSessionsKey+login, ttl)
+		return nil
+	})
+	return format.Error(op, err)
+}
+
+// GetSession return login of session owner
+func (c *Client) GetSession(sid string) (string, error) {
+	const (
+		op = "redis.GetSession"
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	login, err := c.Rdb.Get(ctx, sessionsKey+sid).Result()
+	if err == redis.Nil {
+		return "", format.Error(op, ErrNotFound)
+	}
+	if err != nil {
+		return "", format.Error(op, err)
+	}
+	return login, nil
+}
+
+func (c *Client) DeleteSession(sid string) error {
+	const (
+		op = "redis.DeleteSession"
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	login, err := c.Rdb.GetDel(ctx, sessionsKey+sid).Result()
+	if err == redis.Nil {
+		return nil
+	}
+	if err != nil {
+		return format.Error(op, err)
+	}
+	return format.Error(op, c.Rdb.SRem(ctx, userSessionsKey+login, sid).Err())
+}
+
+// DeleteAdminSessions log out admin everywhere
+func (c *Client) DeleteAdminSessions(login string) error {
+	const (
+		op = "redis.DeleteAdminSessions"
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	sids, err := c.Rdb.SMembers(ctx, userSessionsKey+login).Result()
+	if err != nil {
+		return format.Error(op, err)
+	}
+
+	keys := []string{userSessionsKey + login}
+	for _, sid := range sids {
+		keys = append(keys, sessionsKey+sid)
+	}
+	return format.Error(op, c.Rdb.Del(ctx, keys...).Err())
+}
+
+// AddLoginFail count login attempt before password is checked and return count with this attempt
+// and time left until counter reset. Counter live LockoutTTL since first attempt. Attempts are
+// counted first, so parallel requests can not all pass check while bcrypt is slow
+func (c *Client) AddLoginFail(key string) (int64, time.Duration, error) {
+	const (
+		op = "redis.AddLoginFail"
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	var (
+		incr *redis.IntCmd
+		ttl  *redis.DurationCmd
+	)
+	_, err := c.Rdb.TxPipelined(ctx, func(p redis.Pipeliner) error {
+		incr = p.Incr(ctx, loginFailsKey+key)
+		p.ExpireNX(ctx, loginFailsKey+key, LockoutTTL)
+		ttl = p.TTL(ctx, loginFailsKey+key)
+		return nil
+	})
+	if err != nil {
+		return 0, 0, format.Error(op, err)
+	}
+	return incr.Val(), ttl.Val(), nil
+}
+
+// undoLoginFailScript decrement counter only while it exists. DECR of expired counter would create
+// new one without ttl, which never reset
+const undoLoginFailScript = `
+if redis.call("EXISTS", KEYS[1]) == 1 then
+	return redis.call("DECR", KEYS[1])
+end
+return 0
+`
+
+// UndoLoginFail take back attempt counted by AddLoginFail when password was right
+func (c *Client) UndoLoginFail(key string) error {
+	const (
+		op = "redis.UndoLoginFail"
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	return format.Error(op, c.Rdb.Eval(ctx, undoLoginFailScript, []string{loginFailsKey + key}).Err())
+}
+
+func (c *Client) ResetLoginFails(key string) error {
+	const (
+		op = "redis.ResetLoginFails"
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	return format.Error(op, c.Rdb.Del(ctx, loginFailsKey+key).Err())
+}
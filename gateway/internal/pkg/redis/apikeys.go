@@ -0,0 +1,131 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"gateway/internal/utils/format"
+	"gateway/internal/views"
+	"github.com/redis/go-redis/v9"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	apiKeysKey     = "apikeys"
+	apiKeysUsesKey = "apikeys:uses"
+	apiKeysLastKey = "apikeys:lastused"
+)
+
+// GetAPIKey return key with hash of secret and usage counters
+func (c *Client) GetAPIKey(id string) (*views.APIKey, error) {
+	const (
+		op = "redis.GetAPIKey"
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	raw, err := c.Rdb.HGet(ctx, apiKeysKey, id).Result()
+	if err == redis.Nil {
+		return nil, format.Error(op, ErrNotFound)
+	}
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+
+	var k views.APIKey
+	if err := json.Unmarshal([]byte(raw), &k); err != nil {
+		return nil, format.Error(op, err)
+	}
+	return &k, nil
+}
+
+// GetAllAPIKeys return keys sorted by creation time without hashes
+func (c *Client) GetAllAPIKeys() ([]views.APIKey, error) {
+	const (
+		op = "redis.GetAllAPIKeys"
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	all, err := c.Rdb.HGetAll(ctx, apiKeysKey).Result()
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	uses, err := c.Rdb.HGetAll(ctx, apiKeysUsesKey).Result()
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	last, err := c.Rdb.HGetAll(ctx, apiKeysLastKey).Result()
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+
+	out := make([]views.APIKey, 0, len(all))
+	for id, raw := range all {
+		var k views.APIKey
+		if err := json.Unmarshal([]byte(raw), &k); err != nil {
+			return nil, format.Error(op, err)
+		}
+		k.Hash = ""
+		k.Uses, _ = strconv.ParseInt(uses[id], 10, 64)
+		k.LastUsed, _ = strconv.ParseInt(last[id], 10, 64)
+		out = append(out, k)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt < out[j].CreatedAt })
+	return out, nil
+}
+
+func (c *Client) SetAPIKey(k *views.APIKey) error {
+	const (
+		op = "redis.SetAPIKey"
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	bytes, err := json.Marshal(k)
+	if err != nil {
+		return format.Error(op, err)
+	}
+	return format.Error(op, c.Rdb.HSet(ctx, apiKeysKey, k.Id, bytes).Err())
+}
+
+// DeleteAPIKey revoke key together with its counters
+func (c *Client) DeleteAPIKey(id string) error {
+	const (
+		op = "redis.DeleteAPIKey"
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	var del *redis.IntCmd
+	_, err := c.Rdb.TxPipelined(ctx, func(p redis.Pipeliner) error {
+		del = p.HDel(ctx, apiKeysKey, id)
+		p.HDel(ctx, apiKeysUsesKey, id)
+		p.HDel(ctx, apiKeysLastKey, id)
+		return nil
+	})
+	if err != nil {
+		return format.Error(op, err)
+	}
+	if del.Val() == 0 {
+		return format.Error(op, ErrNotFound)
+	}
+	return nil
+}
+
+// TouchAPIKey increment usage counter of key and remember time of use
+func (c *Client) TouchAPIKey(id string) error {
+	const (
+		op = "redis.TouchAPIKey"
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	_, err := c.Rdb.Pipelined(ctx, func(p redis.Pipeliner) error {
+		p.HIncrBy(ctx, apiKeysUsesKey, id, 1)
+		p.HSet(ctx, apiKeysLastKey, id, time.Now().Unix())
+		return nil
+	})
+	return format.Error(op, err)
+}
@@ -0,0 +1,337 @@
+package redis
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"gateway/config"
+	"gateway/internal/utils/format"
+	"gateway/internal/views"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+const (
+	cachePrefix = "cache:"
+	tagPrefix   = "tag:"
+	// lastPrefix keep last known good copy of every cached value. It is not tagged, so it
+	// survive invalidation and is served only when product-service is down
+	lastPrefix = "last:"
+	// genKey is counter of invalidations, invPrefix+tag keep number of last invalidation of tag.
+	// Value loaded before that is not written to cache
+	genKey    = "cache:gen"
+	invPrefix = "inv:"
+)
+
+// invTTL is how long number of tag invalidation is kept. It must be longer than any load
+const invTTL = 10 * LoadTimeout
+
+// LastGoodTTL is how long last known good copy is kept
+const LastGoodTTL = 24 * time.Hour
+
+// TTL of cached value, it is set in config by family and read from cfg.Live()
+type TTL = config.CacheTTL
+
+// entry is how value is stored in redis. Fresh is unix millis when value become stale
+type entry struct {
+	Value json.RawMessage `json:"v"`
+	Fresh int64           `json:"f"`
+}
+
+// Tags for values which depend on many products. Any product change that can
+// add product to such result invalidate them
+const (
+	TagProductsAll  = "products:all"
+	TagSearch       = "products:search"
+	TagDictionaries = "dictionaries"
+)
+
+// Tags of entities embedded into cached value. They are invalidated when entity itself is changed
+func TagProduct(id string) string  { return "product:" + id }
+func TagPhoto(id string) string    { return "photo:" + id }
+func TagCategory(id string) string { return "category:" + id }
+func TagBrand(id string) string    { return "brand:" + id }
+func TagCountry(id string) string  { return "country:" + id }
+func TagMaterial(id string) string { return "material:" + id }
+func TagColor(id string) string    { return "color:" + id }
+
+// Tags of results which depend on set of products in category or brand. They are invalidated
+// when product is added to, changed in or removed from category or brand
+func TagCategoryMembers(id string) string { return "members:category:" + id }
+func TagBrandMembers(id string) string    { return "members:brand:" + id }
+
+// ProductChangeTags return tags to invalidate when product is created, changed or deleted.
+// categories and brands are old and new ones of product
+func ProductChangeTags(id string, categories, brands []string) []string {
+	tags := []string{TagProduct(id), TagProductsAll, TagSearch, TagDictionaries}
+	for _, c := range categories {
+		if c != "" {
+			tags = append(tags, TagCategoryMembers(c))
+		}
+	}
+	for _, b := range brands {
+		if b != "" {
+			tags = append(tags, TagBrandMembers(b))
+		}
+	}
+	return tags
+}
+
+// FilterTags return tags of filter result: its products and categories or brands it is limited to
+func FilterTags(f *views.ProductFilter, list []views.Product) []string {
+	tags := ListTags(list)
+	switch {
+	case len(f.Category) > 0:
+		for _, c := range f.Category {
+			tags = append(tags, TagCategoryMembers(c))
+		}
+	case len(f.Brand) > 0:
+		for _, b := range f.Brand {
+			tags = append(tags, TagBrandMembers(b))
+		}
+	default:
+		tags = append(tags, TagProductsAll)
+	}
+	return tags
+}
+
+// ProductTags return tags of everything embedded into cached product
+func ProductTags(p *views.Product) []string {
+	tags := []string{TagProduct(p.Id)}
+	if p.Brand.Id != "" {
+		tags = append(tags, TagBrand(p.Brand.Id))
+	}
+	if p.Category.Id != "" {
+		tags = append(tags, TagCategory(p.Category.Id))
+	}
+	if p.Country.Id != "" {
+		tags = append(tags, TagCountry(p.Country.Id))
+	}
+	for _, m := range p.Materials {
+		tags = append(tags, TagMaterial(m.Id))
+	}
+	for _, c := range p.Colors {
+		tags = append(tags, TagColor(c.Id))
+	}
+	for _, ph := range p.Gallery {
+		tags = append(tags, TagPhoto(ph.Id))
+	}
+	for _, seem := range p.Seems {
+		tags = append(tags, TagProduct(seem.Id))
+	}
+	return tags
+}
+
+// ListTags return tags of every product in list
+func ListTags(list []views.Product) []string {
+	seen := make(map[string]bool)
+	var tags []string
+	for i := range list {
+		for _, t := range ProductTags(&list[i]) {
+			if !seen[t] {
+				seen[t] = true
+				tags = append(tags, t)
+			}
+		}
+	}
+	return tags
+}
+
+// HashKey make short cache key from any request parameters
+func HashKey(prefix string, params any) string {
+	b, _ := json.Marshal(params)
+	sum := sha256.Sum256(b)
+	return prefix + hex.EncodeToString(sum[:16])
+}
+
+// invalidateScript delete every key of every tag and tag sets themselves, and mark tags
+// with new invalidation number. Every key script touch must be in KEYS, so members of tag sets
+// are read before and passed too: KEYS are gen counter, n tag sets, n invalidation marks and
+// members. ARGV[1] is ttl of the mark in millis, ARGV[2] is n. If set got new member since it
+// was read, nothing is changed and -1 is returned, caller read members again
+const invalidateScript = `
+local n = tonumber(ARGV[2])
+local known = {}
+for i = 2 + 2 * n, #KEYS do
+	known[KEYS[i]] = true
+end
+for i = 1, n do
+	for _, key in ipairs(redis.call("SMEMBERS", KEYS[1 + i])) do
+		if not known[key] then
+			return -1
+		end
+	end
+end
+local gen = redis.call("INCR", KEYS[1])
+for i = 1, n do
+	redis.call("SET", KEYS[1 + n + i], gen, "PX", ARGV[1])
+end
+local deleted = 0
+for i = 2 + 2 * n, #KEYS, 500 do
+	deleted = deleted + redis.call("DEL", unpack(KEYS, i, math.min(i + 499, #KEYS)))
+end
+for i = 1, n do
+	redis.call("DEL", KEYS[1 + i])
+end
+return deleted
+`
+
+// invalidateAttempts is how many times members of tags are read again when they change
+// during invalidation
+const invalidateAttempts = 5
+
+// GetCache read cached value into dst, even stale one. Return ErrNotFound on miss
+func (c *Client) GetCache(key string, dst any) error {
+	const (
+		op = "redis.GetCache"
+	)
+	e, err := c.getEntry(key)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			countCache(context.Background(), key, cacheMiss)
+		}
+		return format.Error(op, err)
+	}
+	countCache(context.Background(), key, cacheHit)
+	return format.Error(op, json.Unmarshal(e.Value, dst))
+}
+
+func (c *Client) getEntry(key string) (*entry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	raw, err := c.Rdb.Get(ctx, cachePrefix+key).Bytes()
+	if err == redis.Nil {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var e entry
+	if err := json.Unmarshal(raw, &e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// SetCache store value and add key to every tag set, so InvalidateTags on any of them drop the value
+func (c *Client) SetCache(key string, v any, ttl TTL, tags ...string) error {
+	const (
+		op = "redis.SetCache"
+	)
+	value, err := json.Marshal(v)
+	if err != nil {
+		return format.Error(op, err)
+	}
+	return format.Error(op, c.setEntry(key, value, ttl, tags, -1))
+}
+
+// setEntryScript write value and add it to tag sets, unless any tag was invalidated after
+// invalidation number ARGV[5] (negative means no check). KEYS are cache key, last good key,
+// n tag sets and n invalidation marks. Return 0 when value was not written
+const setEntryScript = `
+local n = tonumber(ARGV[6])
+local gen = tonumber(ARGV[5])
+if gen >= 0 then
+	for i = 1, n do
+		if tonumber(redis.call("GET", KEYS[2 + n + i]) or "0") > gen then
+			return 0
+		end
+	end
+end
+redis.call("SET", KEYS[1], ARGV[1], "PX", ARGV[3])
+redis.call("SET", KEYS[2], ARGV[2], "PX", ARGV[4])
+for i = 1, n do
+	redis.call("SADD", KEYS[2 + i], KEYS[1])
+	redis.call("PEXPIRE", KEYS[2 + i], ARGV[3], "GT")
+	redis.call("PEXPIRE", KEYS[2 + i], ARGV[3], "NX")
+end
+return 1
+`
+
+// setEntry store value with its tags. gen is invalidation number read before value was loaded,
+// if any of tags was invalidated since then value is already stale and is not stored
+func (c *Client) setEntry(key string, value []byte, ttl TTL, tags []string, gen int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	bytes, err := json.Marshal(entry{Value: value, Fresh: time.Now().Add(ttl.Soft).UnixMilli()})
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, 2+2*len(tags))
+	keys = append(keys, cachePrefix+key, lastPrefix+key)
+	for _, t := range tags {
+		keys = append(keys, tagPrefix+t)
+	}
+	for _, t := range tags {
+		keys = append(keys, invPrefix+tagPrefix+t)
+	}
+
+	return c.Rdb.Eval(ctx, setEntryScript, keys,
+		bytes, value, ttl.Hard.Milliseconds(), LastGoodTTL.Milliseconds(), gen, len(tags)).Err()
+}
+
+// generation return current invalidation number, -1 if it can not be read
+func (c *Client) generation() int64 {
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	gen, err := c.Rdb.Get(ctx, genKey).Int64()
+	if err == redis.Nil {
+		return 0
+	}
+	if err != nil {
+		return -1
+	}
+	return gen
+}
+
+// InvalidateTags drop all values cached with any of tags. It is atomic, so all replicas see it at once
+func (c *Client) InvalidateTags(tags ...string) error {
+	const (
+		op = "redis.InvalidateTags"
+	)
+	if len(tags) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	for range invalidateAttempts {
+		keys := make([]string, 0, 1+2*len(tags))
+		keys = append(keys, genKey)
+		for _, t := range tags {
+			keys = append(keys, tagPrefix+t)
+		}
+		for _, t := range tags {
+			keys = append(keys, invPrefix+tagPrefix+t)
+		}
+
+		seen := make(map[string]bool)
+		for _, t := range tags {
+			members, err := c.Rdb.SMembers(ctx, tagPrefix+t).Result()
+			if err != nil {
+				return format.Error(op, err)
+			}
+			for _, m := range members {
+				if !seen[m] {
+					seen[m] = true
+					keys = append(keys, m)
+				}
+			}
+		}
+
+		n, err := c.Rdb.Eval(ctx, invalidateScript, keys, invTTL.Milliseconds(), len(tags)).Int64()
+		if err != nil {
+			return format.Error(op, err)
+		}
+		if n >= 0 {
+			return nil
+		}
+	}
+	return format.Error(op, errors.New("tags are changed during every attempt"))
+}
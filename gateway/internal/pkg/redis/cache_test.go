@@ -0,0 +1,98 @@
+package redis
+
+import (
+	"gateway/config"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+func newTestClient(t *testing.T) (*Client, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	c := New(&config.Config{RedisAddr: mr.Addr()})
+	t.Cleanup(func() { _ = c.Close() })
+	return c, mr
+}
+
+var testTTL = TTL{Soft: time.Minute, Hard: time.Hour}
+
+func TestInvalidateTags(t *testing.T) {
+	c, mr := newTestClient(t)
+
+	if err := c.SetCache("p1", "one", testTTL, TagProduct("1"), TagProductsAll); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SetCache("p2", "two", testTTL, TagProduct("2")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.InvalidateTags(TagProductsAll); err != nil {
+		t.Fatal(err)
+	}
+
+	var v string
+	if err := c.GetCache("p1", &v); err == nil {
+		t.Fatal("value of invalidated tag is still cached")
+	}
+	if err := c.GetCache("p2", &v); err != nil || v != "two" {
+		t.Fatalf("value of other tag: %q %v", v, err)
+	}
+	if mr.Exists(tagPrefix + TagProductsAll) {
+		t.Fatal("tag set is not deleted")
+	}
+	// last good copy is not tagged and survive invalidation
+	if !mr.Exists(lastPrefix + "p1") {
+		t.Fatal("last good copy is deleted")
+	}
+}
+
+func TestInvalidateDuringLoad(t *testing.T) {
+	c, _ := newTestClient(t)
+
+	// value is loaded, tag is invalidated before it is written
+	gen := c.generation()
+	if err := c.InvalidateTags(TagProduct("1")); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.setEntry("p1", []byte(`"old"`), testTTL, []string{TagProduct("1")}, gen); err != nil {
+		t.Fatal(err)
+	}
+	var v string
+	if err := c.GetCache("p1", &v); err == nil {
+		t.Fatal("value loaded before invalidation is cached")
+	}
+
+	// invalidation of other tag do not block it
+	gen = c.generation()
+	if err := c.InvalidateTags(TagProduct("2")); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.setEntry("p1", []byte(`"new"`), testTTL, []string{TagProduct("1")}, gen); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.GetCache("p1", &v); err != nil || v != "new" {
+		t.Fatalf("value is not cached: %q %v", v, err)
+	}
+}
+
+func TestInvalidateChangedTag(t *testing.T) {
+	c, mr := newTestClient(t)
+
+	if err := c.SetCache("p1", "one", testTTL, TagProductsAll); err != nil {
+		t.Fatal(err)
+	}
+	// members are read by caller, set got new one before script run
+	keys := []string{genKey, tagPrefix + TagProductsAll, invPrefix + tagPrefix + TagProductsAll, cachePrefix + "p1"}
+	if _, err := mr.SAdd(tagPrefix+TagProductsAll, cachePrefix+"p2"); err != nil {
+		t.Fatal(err)
+	}
+	n, err := c.Rdb.Eval(t.Context(), invalidateScript, keys, invTTL.Milliseconds(), 1).Int64()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != -1 || !mr.Exists(cachePrefix+"p1") {
+		t.Fatalf("script run with stale members: %d", n)
+	}
+}
@@ -1,12 +1,18 @@
 package redis
 
 import (
+	"context"
 	"gateway/config"
+	"gateway/internal/utils/format"
 	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
 )
 
 type Client struct {
 	Rdb *redis.Client
+	cfg *config.Config
+	// sf coalesce concurrent loads of same cache key inside one replica
+	sf singleflight.Group
 }
 
 func New(cfg *config.Config) *Client {
@@ -14,5 +20,17 @@ func New(cfg *config.Config) *Client {
 		Addr:     cfg.RedisAddr,
 		Password: cfg.RedisPw,
 		DB:       0,
-	})}
+	}), cfg: cfg}
+}
+
+// Close close connections to redis
+func (c *Client) Close() error {
+	const op = "redis.Close"
+	return format.Error(op, c.Rdb.Close())
+}
+
+// Ping check that redis answer
+func (c *Client) Ping(ctx context.Context) error {
+	const op = "redis.Ping"
+	return format.Error(op, c.Rdb.Ping(ctx).Err())
 }
@@ -0,0 +1,202 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gateway/internal/pkg/breaker"
+	"gateway/internal/utils/format"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/xid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"log/slog"
+	"time"
+)
+
+const lockPrefix = "lock:"
+
+var tracer = otel.Tracer("gateway/redis")
+
+const (
+	// LoadTimeout limit one call of loader. Loader does not use request context, because
+	// its result is shared with other requests and background refresh outlive request
+	LoadTimeout = 3 * time.Second
+	// lockTTL must be longer than LoadTimeout, so lock is not lost while value is computed
+	lockTTL = LoadTimeout + 2*time.Second
+	// lockWait is how long replica without lock wait for value computed by other replica
+	lockWait = LoadTimeout
+	lockPoll = 50 * time.Millisecond
+)
+
+// Loader compute fresh value and return tags it depends on
+type Loader func(ctx context.Context) (any, []string, error)
+
+// releaseScript delete lock only if it is still held by us
+const releaseScript = `
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`
+
+// Fetch read cached value into dst or compute it with load.
+//
+// Fresh value is returned at once. Stale value (older than ttl.Soft) is returned too, and one request
+// refresh it in background. On miss concurrent requests in replica share one load (singleflight), and
+// across replicas only one holding redis lock call load, others wait for its result.
+//
+// If load fail because backend is unavailable, last known good copy is read into dst and
+// Fetch return true, so caller can tell client that response is stale
+func (c *Client) Fetch(ctx context.Context, key string, dst any, ttl TTL, load Loader) (bool, error) {
+	const (
+		op = "redis.Fetch"
+	)
+	ctx, span := tracer.Start(ctx, "cache.Fetch", trace.WithAttributes(attribute.String("cache.family", family(key))))
+	defer span.End()
+
+	e, err := c.getEntry(key)
+	switch {
+	case err == nil:
+		if time.Now().UnixMilli() >= e.Fresh {
+			countCache(ctx, key, cacheStale)
+			c.refresh(ctx, key, ttl, load)
+		} else {
+			countCache(ctx, key, cacheHit)
+		}
+		return false, format.Error(op, json.Unmarshal(e.Value, dst))
+	case !errors.Is(err, ErrNotFound):
+		// redis is down, do not put all load on product-service at once anyway
+		slog.ErrorContext(ctx, op, "err", err)
+	}
+
+	countCache(ctx, key, cacheMiss)
+	v, err, _ := c.sf.Do(key, func() (any, error) {
+		return c.load(ctx, key, ttl, load)
+	})
+	if err == nil {
+		return false, format.Error(op, json.Unmarshal(v.([]byte), dst))
+	}
+	if !breaker.Unavailable(err) {
+		return false, format.Error(op, err)
+	}
+
+	last, lerr := c.getLast(key)
+	if lerr != nil {
+		return false, format.Error(op, err)
+	}
+	countCache(ctx, key, cacheLast)
+	slog.ErrorContext(ctx, op, "err", fmt.Errorf("serve last known good %s: %w", key, err))
+	return true, format.Error(op, json.Unmarshal(last, dst))
+}
+
+func (c *Client) getLast(key string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	raw, err := c.Rdb.Get(ctx, lastPrefix+key).Bytes()
+	if err == redis.Nil {
+		return nil, ErrNotFound
+	}
+	return raw, err
+}
+
+// refresh start reload of stale value in background. Only one request in replica and one
+// replica in cluster do it
+func (c *Client) refresh(ctx context.Context, key string, ttl TTL, load Loader) {
+	const (
+		op = "redis.refresh"
+	)
+	// result is not awaited, channel of DoChan is buffered so nothing leaks
+	c.sf.DoChan("refresh:"+key, func() (any, error) {
+		token, ok := c.lock(key)
+		if !ok {
+			// other replica is refreshing, stale value is good enough meanwhile
+			return nil, nil
+		}
+		defer c.unlock(key, token)
+		if _, err := c.compute(ctx, key, ttl, load); err != nil {
+			slog.ErrorContext(ctx, op, "err", err)
+		}
+		return nil, nil
+	})
+}
+
+// load compute missing value. If other replica hold lock wait for its result, and compute it
+// ourselves only if it does not appear in lockWait
+func (c *Client) load(ctx context.Context, key string, ttl TTL, load Loader) ([]byte, error) {
+	token, ok := c.lock(key)
+	if ok {
+		defer c.unlock(key, token)
+		return c.compute(ctx, key, ttl, load)
+	}
+
+	deadline := time.Now().Add(lockWait)
+	for time.Now().Before(deadline) {
+		time.Sleep(lockPoll)
+		if e, err := c.getEntry(key); err == nil {
+			return e.Value, nil
+		}
+	}
+	return c.compute(ctx, key, ttl, load)
+}
+
+func (c *Client) compute(ctx context.Context, key string, ttl TTL, load Loader) ([]byte, error) {
+	const (
+		op = "redis.compute"
+	)
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), LoadTimeout)
+	defer cancel()
+
+	// taken before load, so invalidation which happen while loading is seen by setEntry
+	// and value read before it is not written back
+	gen := c.generation()
+
+	v, tags, err := load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	value, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.setEntry(key, value, ttl, tags, gen); err != nil {
+		slog.ErrorContext(ctx, op, "err", err)
+	}
+	return value, nil
+}
+
+// lock try to take redis lock of key. Return token needed to release it
+func (c *Client) lock(key string) (string, bool) {
+	const (
+		op = "redis.lock"
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	token := xid.New().String()
+	ok, err := c.Rdb.SetNX(ctx, lockPrefix+key, token, lockTTL).Result()
+	if err != nil {
+		// without redis there is nobody to coordinate with
+		slog.ErrorContext(ctx, op, "err", err)
+		return "", true
+	}
+	return token, ok
+}
+
+func (c *Client) unlock(key, token string) {
+	const (
+		op = "redis.unlock"
+	)
+	if token == "" {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	if err := c.Rdb.Eval(ctx, releaseScript, []string{lockPrefix + key}, token).Err(); err != nil {
+		slog.ErrorContext(ctx, op, "err", err)
+	}
+}
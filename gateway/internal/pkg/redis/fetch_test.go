@@ -0,0 +1,134 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"gateway/config"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// slowLoader count calls and answer after pause, so concurrent requests overlap
+func slowLoader(calls *atomic.Int32, v string) Loader {
+	return func(context.Context) (any, []string, error) {
+		calls.Add(1)
+		time.Sleep(100 * time.Millisecond)
+		return v, []string{TagProductsAll}, nil
+	}
+}
+
+func TestFetchLoadOnce(t *testing.T) {
+	c, mr := newTestClient(t)
+	// second replica share redis, but not singleflight
+	other := New(&config.Config{RedisAddr: mr.Addr()})
+	t.Cleanup(func() { _ = other.Close() })
+
+	var calls atomic.Int32
+	load := slowLoader(&calls, "value")
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := range 20 {
+		client := c
+		if i%2 == 1 {
+			client = other
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var v string
+			if _, err := client.Fetch(context.Background(), "k", &v, testTTL, load); err != nil {
+				errs <- err
+				return
+			}
+			if v != "value" {
+				errs <- errors.New("wrong value " + v)
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("loader is called %d times", n)
+	}
+	if mr.Exists(lockPrefix + "k") {
+		t.Fatal("lock is not released")
+	}
+}
+
+func TestFetchStale(t *testing.T) {
+	c, _ := newTestClient(t)
+	ttl := TTL{Soft: 50 * time.Millisecond, Hard: time.Hour}
+
+	var calls atomic.Int32
+	var v string
+	if _, err := c.Fetch(context.Background(), "k", &v, ttl, slowLoader(&calls, "old")); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(ttl.Soft)
+
+	// stale value is answered at once and only one refresh run
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var v string
+			if _, err := c.Fetch(context.Background(), "k", &v, ttl, slowLoader(&calls, "new")); err != nil || v != "old" {
+				t.Errorf("stale fetch: %q %v", v, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if err := c.GetCache("k", &v); err == nil && v == "new" {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if v != "new" {
+		t.Fatal("stale value is not refreshed")
+	}
+	if n := calls.Load(); n != 2 {
+		t.Fatalf("loader is called %d times", n)
+	}
+}
+
+func TestFetchLastGood(t *testing.T) {
+	c, _ := newTestClient(t)
+
+	var calls atomic.Int32
+	var v string
+	if _, err := c.Fetch(context.Background(), "k", &v, testTTL, slowLoader(&calls, "good")); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.InvalidateTags(TagProductsAll); err != nil {
+		t.Fatal(err)
+	}
+
+	down := func(context.Context) (any, []string, error) {
+		return nil, nil, status.Error(codes.Unavailable, "circuit breaker is open")
+	}
+	stale, err := c.Fetch(context.Background(), "k", &v, testTTL, down)
+	if err != nil || !stale || v != "good" {
+		t.Fatalf("backend down: stale %v, %q %v", stale, v, err)
+	}
+
+	// other errors are not hidden by old copy
+	bad := func(context.Context) (any, []string, error) {
+		return nil, nil, status.Error(codes.InvalidArgument, "bad")
+	}
+	if _, err := c.Fetch(context.Background(), "k", &v, testTTL, bad); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("client error: %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"gateway/internal/utils/format"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+const idempotencyKey = "idempotency:"
+
+// idempotencyLockTTL is how long key stay taken by request which is still running. If gateway
+// die in the middle, key is freed after it and retry can go
+const idempotencyLockTTL = 1 * time.Minute
+
+// IdempotentResponse is saved response of request with Idempotency-Key. Hash is hash of request,
+// so key reused with other request can be told apart. Done is false while request is running.
+// Unknown is set when request failed so that it is not known if change was made
+type IdempotentResponse struct {
+	Hash    string              `json:"hash"`
+	Done    bool                `json:"done"`
+	Unknown bool                `json:"unknown,omitempty"`
+	Status  int                 `json:"status,omitempty"`
+	Header  map[string][]string `json:"header,omitempty"`
+	Body    []byte              `json:"body,omitempty"`
+}
+
+// StartIdempotent take key for request with hash. If key is already taken it return false and
+// what is stored under it: finished response or running request
+func (c *Client) StartIdempotent(key, hash string) (*IdempotentResponse, bool, error) {
+	const (
+		op = "redis.StartIdempotent"
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	bytes, err := json.Marshal(IdempotentResponse{Hash: hash})
+	if err != nil {
+		return nil, false, format.Error(op, err)
+	}
+	ok, err := c.Rdb.SetNX(ctx, idempotencyKey+key, bytes, idempotencyLockTTL).Result()
+	if err != nil {
+		return nil, false, format.Error(op, err)
+	}
+	if ok {
+		return nil, true, nil
+	}
+
+	raw, err := c.Rdb.Get(ctx, idempotencyKey+key).Bytes()
+	if err == redis.Nil {
+		// finished request was dropped in between, nobody hold key now
+		return c.StartIdempotent(key, hash)
+	}
+	if err != nil {
+		return nil, false, format.Error(op, err)
+	}
+	var r IdempotentResponse
+	if err := json.Unmarshal(raw, &r); err != nil {
+		return nil, false, format.Error(op, err)
+	}
+	return &r, false, nil
+}
+
+// FinishIdempotent save response of request, so retries with same key get it for ttl
+func (c *Client) FinishIdempotent(key string, r *IdempotentResponse, ttl time.Duration) error {
+	const (
+		op = "redis.FinishIdempotent"
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	r.Done = true
+	bytes, err := json.Marshal(r)
+	if err != nil {
+		return format.Error(op, err)
+	}
+	return format.Error(op, c.Rdb.Set(ctx, idempotencyKey+key, bytes, ttl).Err())
+}
+
+// UnknownIdempotent keep key of request with unknown result for ttl, so retry does not repeat
+// change which may be already made
+func (c *Client) UnknownIdempotent(key, hash string, ttl time.Duration) error {
+	const (
+		op = "redis.UnknownIdempotent"
+	)
+	return format.Error(op, c.FinishIdempotent(key, &IdempotentResponse{Hash: hash, Unknown: true}, ttl))
+}
+
+// DropIdempotent free key of failed request, so retry is executed again
+func (c *Client) DropIdempotent(key string) error {
+	const (
+		op = "redis.DropIdempotent"
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	return format.Error(op, c.Rdb.Del(ctx, idempotencyKey+key).Err())
+}
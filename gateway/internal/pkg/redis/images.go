@@ -0,0 +1,77 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"gateway/internal/utils/format"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// imageRefsKey keep how many photos of products use each stored image. Upload itself is not a use,
+// so same bytes uploaded twice and put into two products give 2, and into one product give 1
+const imageRefsKey = "images:refs"
+
+// releaseImageScript decrement reference counter and drop field when nobody use image
+const releaseImageScript = `
+local n = redis.call("HINCRBY", KEYS[1], ARGV[1], -1)
+if n <= 0 then
+	redis.call("HDEL", KEYS[1], ARGV[1])
+end
+return n
+`
+
+// AcquireImages increment reference counter of every name, repeated name is counted every time
+func (c *Client) AcquireImages(names ...string) error {
+	const (
+		op = "redis.AcquireImages"
+	)
+	if len(names) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	_, err := c.Rdb.TxPipelined(ctx, func(p redis.Pipeliner) error {
+		for _, n := range names {
+			p.HIncrBy(ctx, imageRefsKey, n, 1)
+		}
+		return nil
+	})
+	return format.Error(op, err)
+}
+
+// ReleaseImage decrement reference counter of stored image and return new value.
+// Value <= 0 means that no product use the image
+func (c *Client) ReleaseImage(name string) (int64, error) {
+	const (
+		op = "redis.ReleaseImage"
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	n, err := c.Rdb.Eval(ctx, releaseImageScript, []string{imageRefsKey}, name).Int64()
+	if err != nil {
+		return 0, format.Error(op, err)
+	}
+	return n, nil
+}
+
+// ImageRefs return how many uses of image are counted
+func (c *Client) ImageRefs(name string) (int64, error) {
+	const (
+		op = "redis.ImageRefs"
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	n, err := c.Rdb.HGet(ctx, imageRefsKey, name).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, format.Error(op, err)
+	}
+	return n, nil
+}
@@ -0,0 +1,39 @@
+package redis
+
+import (
+	"context"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Results of cache lookup
+const (
+	cacheHit   = "hit"
+	cacheStale = "stale"
+	cacheMiss  = "miss"
+	// cacheLast is miss which was answered with last known good copy, because product-service is down
+	cacheLast = "last_good"
+)
+
+var cacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "cache_requests_total",
+	Help: "Cache lookups by key family and result (hit, stale, miss, last_good).",
+}, []string{"family", "result"})
+
+// family is first part of key: "product:1" -> product
+func family(key string) string {
+	if i := strings.Index(key, ":"); i > 0 {
+		return key[:i]
+	}
+	return key
+}
+
+// countCache count lookup and mark span of it with result
+func countCache(ctx context.Context, key, result string) {
+	cacheRequests.WithLabelValues(family(key), result).Inc()
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("cache.result", result))
+}
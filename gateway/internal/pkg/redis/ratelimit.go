@@ -0,0 +1,53 @@
+package redis
+
+import (
+	"context"
+	"gateway/internal/utils/format"
+	"strconv"
+	"time"
+)
+
+const rateLimitKey = "ratelimit:"
+
+// tokenBucketScript refill bucket by time passed since last call and take one token.
+// Time is taken from redis, so all gateway instances share the same clock.
+// Return {1, 0} if request is allowed or {0, ms to wait}
+const tokenBucketScript = `
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local t = redis.call("TIME")
+local now = t[1] * 1000 + math.floor(t[2] / 1000)
+
+local b = redis.call("HMGET", KEYS[1], "tokens", "ts")
+local tokens = tonumber(b[1]) or burst
+local ts = tonumber(b[2]) or now
+tokens = math.min(burst, tokens + (now - ts) / 1000 * rate)
+
+local allowed, wait = 0, 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	wait = math.ceil((1 - tokens) / rate * 1000)
+end
+
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "ts", now)
+redis.call("PEXPIRE", KEYS[1], math.ceil(burst / rate * 1000) + 1000)
+return {allowed, wait}
+`
+
+// Allow take token from bucket of key. If there are no tokens it return false and time until next one
+func (c *Client) Allow(key string, rate float64, burst int) (bool, time.Duration, error) {
+	const (
+		op = "redis.Allow"
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	res, err := c.Rdb.Eval(ctx, tokenBucketScript, []string{rateLimitKey + key},
+		strconv.FormatFloat(rate, 'f', -1, 64), burst).Int64Slice()
+	if err != nil {
+		return false, 0, format.Error(op, err)
+	}
+	return res[0] == 1, time.Duration(res[1]) * time.Millisecond, nil
+}
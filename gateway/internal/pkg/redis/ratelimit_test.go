@@ -0,0 +1,50 @@
+package redis
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAllow(t *testing.T) {
+	c, mr := newTestClient(t)
+	now := time.Now()
+	mr.SetTime(now)
+
+	for i := range 3 {
+		if ok, _, err := c.Allow("k", 1, 3); err != nil || !ok {
+			t.Fatalf("request %d of burst is refused: %v", i, err)
+		}
+	}
+	ok, wait, err := c.Allow("k", 1, 3)
+	if err != nil || ok {
+		t.Fatalf("request over burst is allowed: %v", err)
+	}
+	if wait <= 0 || wait > time.Second {
+		t.Fatalf("wait: %s", wait)
+	}
+
+	// other key has own bucket
+	if ok, _, _ := c.Allow("other", 1, 3); !ok {
+		t.Fatal("other key is limited")
+	}
+
+	// one token is back after 1/rate
+	mr.SetTime(now.Add(time.Second))
+	if ok, _, _ := c.Allow("k", 1, 3); !ok {
+		t.Fatal("token is not refilled")
+	}
+	if ok, _, _ := c.Allow("k", 1, 3); ok {
+		t.Fatal("more tokens than refilled")
+	}
+
+	// bucket do not grow over burst
+	mr.SetTime(now.Add(time.Hour))
+	for range 3 {
+		if ok, _, _ := c.Allow("k", 1, 3); !ok {
+			t.Fatal("burst is refused after long pause")
+		}
+	}
+	if ok, _, _ := c.Allow("k", 1, 3); ok {
+		t.Fatal("bucket is bigger than burst")
+	}
+}
@@ -0,0 +1,73 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gateway/internal/utils/format"
+	"gateway/internal/views"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+const uploadsKey = "uploads:"
+
+// UploadsTTL is time after last chunk when unfinished upload count as abandoned
+const UploadsTTL = 24 * time.Hour
+
+var ErrNotFound = errors.New("not found")
+
+func (c *Client) GetUpload(id string) (*views.FileUpload, error) {
+	const (
+		op = "redis.GetUpload"
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	raw, err := c.Rdb.Get(ctx, uploadsKey+id).Result()
+	if err == redis.Nil {
+		return nil, format.Error(op, ErrNotFound)
+	}
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+
+	var u views.FileUpload
+	if err := json.Unmarshal([]byte(raw), &u); err != nil {
+		return nil, format.Error(op, err)
+	}
+	return &u, nil
+}
+
+// SetUpload save state of resumable upload and prolong its life for UploadsTTL
+func (c *Client) SetUpload(u *views.FileUpload) error {
+	const (
+		op = "redis.SetUpload"
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	bytes, err := json.Marshal(u)
+	if err != nil {
+		return format.Error(op, fmt.Errorf("failed to marshal: %w", err))
+	}
+
+	if err := c.Rdb.Set(ctx, uploadsKey+u.Id, bytes, UploadsTTL).Err(); err != nil {
+		return format.Error(op, err)
+	}
+	return nil
+}
+
+func (c *Client) DeleteUpload(id string) error {
+	const (
+		op = "redis.DeleteUpload"
+	)
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	if err := c.Rdb.Del(ctx, uploadsKey+id).Err(); err != nil {
+		return format.Error(op, err)
+	}
+	return nil
+}
@@ -17,9 +17,10 @@ func ToColorViewList(c *productsRPC.ColorList) []views.Color {
 	var list []views.Color
 	for _, x := range c.Colors {
 		list = append(list, views.Color{
-			Id:   x.Id,
-			Name: x.Name,
-			Hex:  x.Hex,
+			Id:      x.Id,
+			Name:    x.Name,
+			Hex:     x.Hex,
+			Version: x.Version,
 		})
 	}
 	return list
@@ -29,8 +30,9 @@ func ToMaterialViewList(m *productsRPC.MaterialList) []views.Material {
 	var list []views.Material
 	for _, x := range m.Materials {
 		list = append(list, views.Material{
-			Id:    x.Id,
-			Title: x.Title,
+			Id:      x.Id,
+			Title:   x.Title,
+			Version: x.Version,
 		})
 	}
 	return list
@@ -43,6 +45,7 @@ func ToCountryViewList(c *productsRPC.CountryList) []views.Country {
 			Id:       x.Id,
 			Title:    x.Title,
 			Friendly: x.Friendly,
+			Version:  x.Version,
 		})
 	}
 	return list
@@ -52,10 +55,11 @@ func ToCategoryViewList(in *productsRPC.CategoryList) []views.Category {
 	var list []views.Category
 	for _, c := range in.Categories {
 		list = append(list, views.Category{
-			Id:    c.Id,
-			Title: c.Title,
-			Uri:   c.Uri,
-			Img:   c.GetImg(),
+			Id:      c.Id,
+			Title:   c.Title,
+			Uri:     c.Uri,
+			Img:     c.GetImg(),
+			Version: c.Version,
 		})
 	}
 	return list
@@ -76,6 +80,8 @@ func ToDictionariesView(in *productsRPC.Dictionaries) *views.Dictionaries {
 		MaxHeight:  int(in.MaxHeight),
 		MinDepth:   int(in.MinDepth),
 		MaxDepth:   int(in.MaxDepth),
+		Version:    in.GetVersion(),
+		UpdatedAt:  in.GetUpdatedAt(),
 	}
 }
 func ToDictionariesViewFromByCategory(in *productsRPC.DictionariesByCategory) *views.Dictionaries {
@@ -93,6 +99,8 @@ func ToDictionariesViewFromByCategory(in *productsRPC.DictionariesByCategory) *v
 		MaxHeight:  int(in.MaxHeight),
 		MinDepth:   int(in.MinDepth),
 		MaxDepth:   int(in.MaxDepth),
+		Version:    in.GetVersion(),
+		UpdatedAt:  in.GetUpdatedAt(),
 	}
 }
 
@@ -121,9 +129,12 @@ func ToProductView(req *productsRPC.Product) *views.Product {
 		Materials:   ToMaterialView(req.GetMaterials()),
 		Colors:      ToColorView(req.GetColors()),
 		Photos:      req.GetPhotos(),
+		Gallery:     ToPhotoViewList(req.GetGallery()),
 		Seems:       ToViewsProductSlice(req.GetSeems()),
 		Price:       int(req.GetPrice()),
 		Description: req.GetDescription(),
+		Version:     req.GetVersion(),
+		UpdatedAt:   req.GetUpdatedAt(),
 	}
 }
 func ToViewsProductSlice(in []*productsRPC.Product) []views.Product {
@@ -161,8 +172,9 @@ func ToBrandList(in *productsRPC.BrandList) []views.Brand {
 	var list []views.Brand
 	for _, b := range in.Brands {
 		list = append(list, views.Brand{
-			Id:   b.Id,
-			Name: b.Name,
+			Id:      b.Id,
+			Name:    b.Name,
+			Version: b.Version,
 		})
 	}
 	return list
@@ -179,3 +191,32 @@ func ToColorPhotoList(in *productsRPC.ProductColorPhotosList) []views.ProductCol
 	}
 	return list
 }
+
+func ToPhotoView(p *productsRPC.ProductPhoto) views.ProductPhoto {
+	return views.ProductPhoto{
+		Id:        p.GetId(),
+		ProductId: p.GetProductId(),
+		File:      p.GetFile(),
+		Position:  int(p.GetPosition()),
+		Alt:       p.GetAlt(),
+		IsMain:    p.GetIsMain(),
+		ColorId:   p.GetColorId(),
+	}
+}
+
+func ToPhotoViewList(in []*productsRPC.ProductPhoto) []views.ProductPhoto {
+	var out []views.ProductPhoto
+	for _, p := range in {
+		out = append(out, ToPhotoView(p))
+	}
+	return out
+}
+
+func ToAdminView(u *productsRPC.AdminUser) views.AdminUser {
+	return views.AdminUser{
+		Login:        u.GetLogin(),
+		Role:         u.GetRole(),
+		PasswordHash: u.GetPasswordHash(),
+		CreatedAt:    u.GetCreatedAt(),
+	}
+}
@@ -6,33 +6,34 @@ import (
 )
 
 func ToBrandRPC(b *views.Brand) *productsRPC.Brand {
-	return &productsRPC.Brand{Id: b.Id, Name: b.Name}
+	return &productsRPC.Brand{Id: b.Id, Name: b.Name, Version: b.Version}
 }
 
 func ToCategoryRPC(c *views.Category) *productsRPC.Category {
-	return &productsRPC.Category{Id: c.Id, Title: c.Title, Uri: c.Uri, Img: c.Img}
+	return &productsRPC.Category{Id: c.Id, Title: c.Title, Uri: c.Uri, Img: c.Img, Version: c.Version}
 }
 
 func ToCountryRPC(c *views.Country) *productsRPC.Country {
-	return &productsRPC.Country{Id: c.Id, Title: c.Title, Friendly: c.Friendly}
+	return &productsRPC.Country{Id: c.Id, Title: c.Title, Friendly: c.Friendly, Version: c.Version}
 }
 
 func ToColorRPC(c *views.Color) *productsRPC.Color {
-	return &productsRPC.Color{Id: c.Id, Name: c.Name, Hex: c.Hex}
+	return &productsRPC.Color{Id: c.Id, Name: c.Name, Hex: c.Hex, Version: c.Version}
 }
 
 func ToMaterialRPC(m *views.Material) *productsRPC.Material {
-	return &productsRPC.Material{Id: m.Id, Title: m.Title}
+	return &productsRPC.Material{Id: m.Id, Title: m.Title, Version: m.Version}
 }
 
 func ToCategoryList(cl *productsRPC.CategoryList) []views.Category {
 	var res []views.Category
 	for _, c := range cl.Categories {
 		res = append(res, views.Category{
-			Id:    c.GetId(),
-			Title: c.GetTitle(),
-			Uri:   c.GetUri(),
-			Img:   c.GetImg(),
+			Id:      c.GetId(),
+			Title:   c.GetTitle(),
+			Uri:     c.GetUri(),
+			Img:     c.GetImg(),
+			Version: c.GetVersion(),
 		})
 	}
 	return res
@@ -77,6 +78,9 @@ func ToProductIdRPC(p *views.ProductId) *productsRPC.ProductId {
 		Seems:       p.Seems,
 		Price:       int32(p.Price),
 		Description: p.Description,
+		Version:     p.Version,
+		// photos absent in request keep gallery, empty list clear it
+		ReplacePhotos: p.Photos != nil,
 	}
 }
 
@@ -97,6 +101,7 @@ func ToProductIdRPCFromProduct(p *views.Product) *productsRPC.ProductId {
 		Seems:       getIdSeem(p.Seems),
 		Price:       int32(p.Price),
 		Description: p.Description,
+		Version:     p.Version,
 	}
 }
 
@@ -135,3 +140,24 @@ func ToColorPhotoIdRPC(in *views.ProductColorPhotosId) *productsRPC.ProductColor
 		ColorId:   in.ColorId,
 	}
 }
+
+func ToPhotoRPC(p *views.ProductPhoto) *productsRPC.ProductPhoto {
+	return &productsRPC.ProductPhoto{
+		Id:        p.Id,
+		ProductId: p.ProductId,
+		File:      p.File,
+		Position:  int32(p.Position),
+		Alt:       p.Alt,
+		IsMain:    p.IsMain,
+		ColorId:   p.ColorId,
+	}
+}
+
+func ToAdminRPC(u *views.AdminUser) *productsRPC.AdminUser {
+	return &productsRPC.AdminUser{
+		Login:        u.Login,
+		Role:         u.Role,
+		PasswordHash: u.PasswordHash,
+		CreatedAt:    u.CreatedAt,
+	}
+}
@@ -1,7 +1,6 @@
 package format
 
 import (
-	"encoding/json"
 	"fmt"
 )
 
@@ -12,21 +11,3 @@ func Error(op string, err error) error {
 	}
 	return fmt.Errorf("OP: %s: ERROR: %w", op, err)
 }
-
-func Struct(in any) string {
-	j, err := json.MarshalIndent(in, "", "🦷")
-	if err != nil {
-		return "Cant print struct " + err.Error()
-	}
-	return string(j)
-}
-
-func Params(op string, params ...interface{}) string {
-	res := op
-
-	for i, p := range params {
-		res += fmt.Sprintf("%d: %s", i, p)
-	}
-
-	return res
-}
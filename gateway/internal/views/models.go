@@ -7,21 +7,26 @@ type ProductSearch struct {
 }
 
 type Product struct {
-	Id          string     `json:"id"`
-	Title       string     `json:"title"`
-	Article     string     `json:"article"`
-	Brand       Brand      `json:"brand"`
-	Category    Category   `json:"category"`
-	Country     Country    `json:"country"`
-	Width       int        `json:"width"`
-	Height      int        `json:"height"`
-	Depth       int        `json:"depth"`
-	Materials   []Material `json:"materials"`
-	Colors      []Color    `json:"colors"`
-	Photos      []string   `json:"photos"`
-	Seems       []Product  `json:"seems"`
-	Price       int        `json:"price"`
-	Description string     `json:"description"`
+	Id          string         `json:"id"`
+	Title       string         `json:"title"`
+	Article     string         `json:"article"`
+	Brand       Brand          `json:"brand"`
+	Category    Category       `json:"category"`
+	Country     Country        `json:"country"`
+	Width       int            `json:"width"`
+	Height      int            `json:"height"`
+	Depth       int            `json:"depth"`
+	Materials   []Material     `json:"materials"`
+	Colors      []Color        `json:"colors"`
+	Photos      []string       `json:"photos"`
+	Gallery     []ProductPhoto `json:"gallery"`
+	Seems       []Product      `json:"seems"`
+	Price       int            `json:"price"`
+	Description string         `json:"description"`
+	// Version grow on every change of product itself, UpdatedAt (unix seconds) on any change
+	// of what is returned with product
+	Version   int64 `json:"version"`
+	UpdatedAt int64 `json:"updated_at"`
 }
 
 type ProductId struct {
@@ -36,39 +41,46 @@ type ProductId struct {
 	Depth       int      `json:"depth"`
 	Materials   []string `json:"materials"`
 	Colors      []string `json:"colors"`
-	Photos      []string `json:"photos"`
+	Photos      []string `json:"photos"` // on update absent photos keep gallery, empty list clear it
 	Seems       []string `json:"seems"`
 	Price       int      `json:"price"`
 	Description string   `json:"description"`
+	// Version is version of product update is based on. If-Match header may be used instead
+	Version int64 `json:"version"`
 }
 
 type Brand struct {
-	Id   string `json:"id"`
-	Name string `json:"name"`
+	Id      string `json:"id"`
+	Name    string `json:"name"`
+	Version int64  `json:"version,omitempty"`
 }
 
 type Category struct {
-	Id    string `json:"id"`
-	Title string `json:"title"`
-	Uri   string `json:"uri"`
-	Img   string `json:"img"`
+	Id      string `json:"id"`
+	Title   string `json:"title"`
+	Uri     string `json:"uri"`
+	Img     string `json:"img"`
+	Version int64  `json:"version,omitempty"`
 }
 
 type Country struct {
 	Id       string `json:"id"`
 	Title    string `json:"title"`
 	Friendly string `json:"friendly"`
+	Version  int64  `json:"version,omitempty"`
 }
 
 type Material struct {
-	Id    string `json:"id"`
-	Title string `json:"title"`
+	Id      string `json:"id"`
+	Title   string `json:"title"`
+	Version int64  `json:"version,omitempty"`
 }
 
 type Color struct {
-	Id   string `json:"id"`
-	Name string `json:"name"`
-	Hex  string `json:"hex"`
+	Id      string `json:"id"`
+	Name    string `json:"name"`
+	Hex     string `json:"hex"`
+	Version int64  `json:"version,omitempty"`
 }
 
 type ProductFilter struct {
@@ -106,9 +118,114 @@ type Dictionaries struct {
 	MaxHeight int `json:"max_height"`
 	MinDepth  int `json:"min_depth"`
 	MaxDepth  int `json:"max_depth"`
+
+	// Version and UpdatedAt (unix seconds) of whole catalog
+	Version   int64 `json:"version"`
+	UpdatedAt int64 `json:"updated_at"`
 }
 type ProductColorPhotos struct {
 	ProductId string   `json:"product_id"`
 	ColorId   string   `json:"color_id"`
 	Photos    []string `json:"photos"`
 }
+
+type FileUploadResult struct {
+	File  string `json:"file"`
+	Name  string `json:"name,omitempty"`
+	Mime  string `json:"mime,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+type FileUpload struct {
+	Id     string `json:"id"`
+	Length int64  `json:"length"`
+	Offset int64  `json:"offset"`
+	Name   string `json:"name,omitempty"`
+	Mime   string `json:"mime,omitempty"`
+}
+
+type ProductPhoto struct {
+	Id        string `json:"id"`
+	ProductId string `json:"product_id"`
+	File      string `json:"file"`
+	Position  int    `json:"position"`
+	Alt       string `json:"alt"`
+	IsMain    bool   `json:"is_main"`
+	ColorId   string `json:"color_id"`
+}
+
+type ProductPhotosOrder struct {
+	ProductId string   `json:"product_id"`
+	Ids       []string `json:"ids"`
+}
+
+type AdminUser struct {
+	Login        string `json:"login"`
+	Role         string `json:"role"`
+	PasswordHash string `json:"password_hash,omitempty"`
+	CreatedAt    int64  `json:"created_at"`
+}
+
+type AdminCredentials struct {
+	Login    string `json:"login"`
+	Password string `json:"password"`
+	Role     string `json:"role,omitempty"`
+}
+
+type AdminRole struct {
+	Role string `json:"role"`
+}
+
+type AdminPasswordChange struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+type ProductPrice struct {
+	Price int `json:"price"`
+	// Version is optional here (also If-Match). Without it price is applied to current product
+	Version int64 `json:"version"`
+}
+
+type APIKey struct {
+	Id        string   `json:"id"`
+	Name      string   `json:"name"`
+	Scopes    []string `json:"scopes"`
+	Hash      string   `json:"hash,omitempty"`
+	CreatedBy string   `json:"created_by"`
+	CreatedAt int64    `json:"created_at"`
+	Uses      int64    `json:"uses"`
+	LastUsed  int64    `json:"last_used"`
+}
+
+type APIKeyCreate struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+type APIKeyIssued struct {
+	Id  string `json:"id"`
+	Key string `json:"key"`
+}
+
+// ErrorResponse is body of every error answer of api. Error is for people, Code is for programs:
+// reason from product-service or name of http status like NOT_FOUND
+type ErrorResponse struct {
+	Error     string            `json:"error"`
+	Code      string            `json:"code"`
+	RequestId string            `json:"request_id,omitempty"`
+	Fields    []FieldError      `json:"fields,omitempty"`
+	Details   map[string]string `json:"details,omitempty"`
+}
+
+// FieldError tell what is wrong with one field of request
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Health is answer of /healthz and /readyz. Checks has "ok" or error for every dependency
+type Health struct {
+	Status string            `json:"status" example:"ok"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
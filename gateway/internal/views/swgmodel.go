@@ -9,7 +9,11 @@ type (
 	}
 
 	SWGErrorResponse struct {
-		Error string `json:"error" example:"something went wrong"`
+		Error     string            `json:"error" example:"something went wrong"`
+		Code      string            `json:"code" example:"NOT_FOUND"`
+		RequestId string            `json:"request_id" example:"hYbqXWkAqkLzEoVxXlxBcfOQnxtfGJqZ"`
+		Fields    []FieldError      `json:"fields,omitempty"`
+		Details   map[string]string `json:"details,omitempty"`
 	}
 
 	SWGFileUploadResponse struct {
@@ -1,13 +1,16 @@
 package main
 
 import (
+	"context"
 	"log"
+	"log/slog"
 	"os"
-	"os/signal"
 	"productService/config"
 	"productService/internal/grpc"
+	"productService/internal/metrics"
+	"productService/internal/pkg/lifecycle"
 	"productService/internal/pkg/psql"
-	"syscall"
+	"productService/internal/pkg/tracing"
 )
 
 func main() {
@@ -15,22 +18,32 @@ func main() {
 	const vol = "photo color"
 
 	cfg := config.MustSetup()
+	config.Watch()
+	shutdown, err := tracing.Setup(context.Background(), cfg.Tracing, "product-service")
+	if err != nil {
+		log.Panic(err)
+	}
 
 	db := psql.MustConnect(cfg)
-	api := psql.Driver{Driver: db.Driver}
-
-	a := grpc.New(cfg, api)
-	go a.MustRun()
-	log.Printf("service started and ready to work. Vol.%s\n", vol)
-
-	stop := make(chan os.Signal, 1)
-	signal.Notify(stop, syscall.SIGTERM, syscall.SIGINT)
-	sign := <-stop
+	api := psql.Driver{Driver: psql.Traced(db.Driver)}
 
-	a.Stop()
-	if err := db.Disconnect(); err != nil {
-		log.Println(err)
+	a, err := grpc.New(cfg, api, db.Driver)
+	if err != nil {
+		log.Panic(err)
 	}
-
-	log.Println(op, "stop signal", sign)
+	m := metrics.New(cfg.MetricsPort, db.Driver)
+
+	// stopped in reverse order: calls in flight finish before db is closed
+	lc := lifecycle.New(cfg.ShutdownTimeout)
+	lc.Add(lifecycle.Component{Name: "tracing", Stop: shutdown})
+	lc.Add(lifecycle.Component{Name: "postgres", Stop: func(context.Context) error { return db.Disconnect() }})
+	lc.Add(lifecycle.Component{Name: "metrics", Run: m.Run, Stop: m.Stop})
+	lc.Add(lifecycle.Component{Name: "grpc", Run: a.Run, Stop: a.Stop})
+	slog.Info("service started and ready to work", "vol", vol)
+
+	if err := lc.Run(context.Background()); err != nil {
+		slog.Error("stopped with error", "op", op, "err", err)
+		os.Exit(1)
+	}
+	slog.Info("stopped", "op", op)
 }
@@ -1,45 +1,210 @@
 package config
 
 import (
+	"errors"
 	"flag"
+	"fmt"
+	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/viper"
+	"io/fs"
 	"log"
+	"log/slog"
+	"net/url"
+	"productService/internal/pkg/certs"
+	"productService/internal/pkg/logger"
+	"productService/internal/pkg/tracing"
 	"productService/internal/utils/format"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
 )
 
 type Config struct {
 	ConnStr string `mapstructure:"conn_str"`
 	Port    int    `mapstructure:"port"`
 	Mode    string `mapstructure:"mode"`
+	// MetricsPort is port of http server with /metrics for prometheus
+	MetricsPort int `mapstructure:"metrics_port"`
+	// TLS of gRPC server. With ca_file client certificates are required (mTLS)
+	TLS certs.Config `mapstructure:"tls"`
+	// ActorPeers is common names of client certificates which may send acting admin of call.
+	// Default is gateway. Without mTLS actor is trusted only when it is signed with ActorSecret
+	ActorPeers []string `mapstructure:"actor_peers"`
+	// ActorSecret check signature of acting admin, it must be same as actor_secret of gateway
+	ActorSecret string `mapstructure:"actor_secret"`
+	// Tracing is export of OpenTelemetry spans
+	Tracing tracing.Config `mapstructure:"tracing"`
+	// LogLevel is debug, info, warn or error. Default is debug in DEV mode and info in others.
+	// It is only setting applied without restart, see Watch
+	LogLevel string `mapstructure:"log_level"`
+	// ShutdownTimeout is time to finish calls in flight after SIGTERM, then they are canceled
+	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
 }
 
-// MustSetup return config and panic if error
+// EnvPrefix is prefix of env variables which override config file: PRODUCTS_CONN_STR, PRODUCTS_PORT
+const EnvPrefix = "PRODUCTS"
+
+// v is viper of loaded config, Watch reread file with it
+var v *viper.Viper
+
+// MustSetup return config and exit with error if it is bad
 func MustSetup() *Config {
 	cfg, err := setup()
 	if err != nil {
-		log.Panic(err)
+		log.Fatalln("bad config:", err)
 	}
 	return cfg
 }
 
-// setup create config structure
+// setup create config structure from file and env variables
 func setup() (*Config, error) {
 	const op = "config.setup"
 	configPath := flag.String("config", "./config/local.yaml", "path to config file")
 	flag.Parse()
 
-	viper.SetConfigFile(*configPath)
-	var cfg Config
-	if err := viper.ReadInConfig(); err != nil {
+	v = newViper(*configPath)
+	if err := v.ReadInConfig(); err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, format.Error(op, err)
+		}
+		// all settings can come from env, in docker secrets are passed so
+		log.Println("config file is not found, using env only:", *configPath)
+	}
+	cfg, err := unmarshal(v)
+	if err != nil {
 		return nil, format.Error(op, err)
 	}
-	if err := viper.Unmarshal(&cfg); err != nil {
+	if err := logger.Setup(cfg.Mode, cfg.LogLevel); err != nil {
+		return nil, format.Error(op, err)
+	}
+	if err := cfg.Validate(); err != nil {
 		return nil, format.Error(op, err)
 	}
 
 	if cfg.Mode == "DEV" {
-		log.Println(format.Struct(cfg))
+		slog.Debug("config", "cfg", cfg)
+	}
+
+	return cfg, nil
+}
+
+func newViper(path string) *viper.Viper {
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetEnvPrefix(EnvPrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	bindEnv(v, reflect.TypeOf(Config{}), "")
+	return v
+}
+
+// bindEnv bind env variable to every field with mapstructure tag, so it override file even when
+// key is missing there
+func bindEnv(v *viper.Viper, t reflect.Type, prefix string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		key := f.Tag.Get("mapstructure")
+		if key == "" || key == "-" {
+			continue
+		}
+		if f.Type.Kind() == reflect.Struct && f.Type != reflect.TypeOf(time.Duration(0)) {
+			bindEnv(v, f.Type, prefix+key+".")
+			continue
+		}
+		_ = v.BindEnv(prefix + key)
 	}
+}
 
+// unmarshal read config from viper and fill defaults
+func unmarshal(v *viper.Viper) (*Config, error) {
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, err
+	}
+	if cfg.MetricsPort == 0 {
+		cfg.MetricsPort = 9090
+	}
+	if cfg.ShutdownTimeout <= 0 {
+		cfg.ShutdownTimeout = 15 * time.Second
+	}
+	if len(cfg.ActorPeers) == 0 {
+		cfg.ActorPeers = []string{"gateway"}
+	}
 	return &cfg, nil
 }
+
+// Validate check required settings and ranges. Error has all bad settings, one per line
+func (cfg *Config) Validate() error {
+	var errs []error
+	bad := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	if cfg.ConnStr == "" {
+		bad("conn_str is required")
+	}
+	if cfg.Port < 1 || cfg.Port > 65535 {
+		bad("port must be from 1 to 65535, got %d", cfg.Port)
+	}
+	if cfg.MetricsPort < 1 || cfg.MetricsPort > 65535 || cfg.MetricsPort == cfg.Port {
+		bad("metrics_port must be from 1 to 65535 and differ from port, got %d", cfg.MetricsPort)
+	}
+	if _, err := logger.ParseLevel(cfg.LogLevel, cfg.Mode); err != nil {
+		bad("log_level: %v", err)
+	}
+	if err := cfg.TLS.Validate(true); err != nil {
+		bad("tls: %v", err)
+	}
+	// without client certificates nobody can be trusted to send acting admin but signed one
+	if mtls := cfg.TLS.Enabled && (cfg.TLS.CAFile != "" || cfg.TLS.Dev); !mtls && cfg.ActorSecret == "" {
+		bad("actor_secret is required when tls client certificates are not checked")
+	}
+	if r := cfg.Tracing.SampleRatio; r < 0 || r > 1 {
+		bad("tracing.sample_ratio must be from 0 to 1, got %g", r)
+	}
+	return errors.Join(errs...)
+}
+
+// LogValue hide password of conn_str when config is logged
+func (cfg *Config) LogValue() slog.Value {
+	type plain Config
+	c := plain(*cfg)
+	c.ConnStr = redactConnStr(c.ConnStr)
+	if c.ActorSecret != "" {
+		c.ActorSecret = "***"
+	}
+	return slog.AnyValue(c)
+}
+
+var passwordRegexp = regexp.MustCompile(`password=\S+`)
+
+// redactConnStr hide password in url (postgres://user:pw@host/db) and in key=value form
+func redactConnStr(s string) string {
+	if u, err := url.Parse(s); err == nil && u.User != nil {
+		return u.Redacted()
+	}
+	return passwordRegexp.ReplaceAllString(s, "password=xxxxx")
+}
+
+// Watch apply log level when config file is changed. Only log level is reloaded, change of
+// other settings (conn_str, ports, tls, actor, tracing) need restart of service
+func Watch() {
+	const op = "config.Watch"
+	if v == nil || v.ConfigFileUsed() == "" {
+		return
+	}
+	v.OnConfigChange(func(e fsnotify.Event) {
+		next, err := unmarshal(v)
+		if err == nil {
+			err = next.Validate()
+		}
+		if err != nil {
+			slog.Error("config is not reloaded", "op", op, "err", err)
+			return
+		}
+		lvl, _ := logger.ParseLevel(next.LogLevel, next.Mode)
+		logger.Level.Set(lvl)
+		slog.Info("config reloaded, only log_level is applied", "op", op, "log_level", lvl.String())
+	})
+	v.WatchConfig()
+}
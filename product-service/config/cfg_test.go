@@ -0,0 +1,133 @@
+package config
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"productService/internal/pkg/logger"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeConfig(t *testing.T, path, file string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(file), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEnvOverride(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cfg.yaml")
+	writeConfig(t, path, "conn_str: postgres://u:from-file@db/products\nport: 8008\nmode: DEV\n")
+	t.Setenv("PRODUCTS_CONN_STR", "postgres://u:from-env@db/products")
+	t.Setenv("PRODUCTS_SHUTDOWN_TIMEOUT", "5s")
+	t.Setenv("PRODUCTS_TLS_ENABLED", "true")
+	t.Setenv("PRODUCTS_TRACING_SAMPLE_RATIO", "0.5")
+
+	v := newViper(path)
+	if err := v.ReadInConfig(); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := unmarshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.ConnStr != "postgres://u:from-env@db/products" || cfg.ShutdownTimeout != 5*time.Second ||
+		!cfg.TLS.Enabled || cfg.Tracing.SampleRatio != 0.5 {
+		t.Fatalf("env is not applied: %+v", cfg)
+	}
+	if cfg.Port != 8008 || cfg.Mode != "DEV" {
+		t.Fatalf("file is not applied: %+v", cfg)
+	}
+	// defaults
+	if cfg.MetricsPort != 9090 || len(cfg.ActorPeers) != 1 || cfg.ActorPeers[0] != "gateway" {
+		t.Fatalf("defaults are not set: %+v", cfg)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cfg := &Config{Port: 70000, MetricsPort: 9090, LogLevel: "loud"}
+	cfg.Tracing.SampleRatio = 2
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("want error")
+	}
+	for _, want := range []string{"conn_str", "port", "log_level", "actor_secret", "sample_ratio"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error does not mention %s: %v", want, err)
+		}
+	}
+
+	cfg = &Config{ConnStr: "postgres://db/products", Port: 8008, MetricsPort: 9090, ActorSecret: "secret"}
+	if err := cfg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg.MetricsPort = cfg.Port
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "metrics_port") {
+		t.Fatalf("same metrics_port: %v", err)
+	}
+
+	// with client certificates actor is trusted by peer, secret is not needed
+	cfg.MetricsPort = 9090
+	cfg.ActorSecret = ""
+	cfg.TLS.Enabled, cfg.TLS.Dev = true, true
+	if err := cfg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLogValueRedact(t *testing.T) {
+	for _, conn := range []string{
+		"postgres://user:db-secret@db:5432/products",
+		"host=db user=user password=db-secret dbname=products",
+	} {
+		var buf bytes.Buffer
+		cfg := &Config{ConnStr: conn, ActorSecret: "actor-secret"}
+		slog.New(slog.NewJSONHandler(&buf, nil)).Info("config", "cfg", cfg)
+
+		if strings.Contains(buf.String(), "secret") {
+			t.Fatalf("secret in log: %s", buf.String())
+		}
+		if !strings.Contains(buf.String(), "products") {
+			t.Fatalf("config is not logged: %s", buf.String())
+		}
+	}
+}
+
+func TestWatchLogLevel(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cfg.yaml")
+	base := "conn_str: postgres://db/products\nport: 8008\nactor_secret: secret\n"
+	writeConfig(t, path, base+"log_level: info\n")
+
+	v = newViper(path)
+	if err := v.ReadInConfig(); err != nil {
+		t.Fatal(err)
+	}
+	logger.Level.Set(slog.LevelInfo)
+	Watch()
+
+	waitLevel := func(want slog.Level) bool {
+		deadline := time.Now().Add(2 * time.Second)
+		for time.Now().Before(deadline) {
+			if logger.Level.Level() == want {
+				return true
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+		return false
+	}
+
+	writeConfig(t, path, base+"log_level: error\n")
+	if !waitLevel(slog.LevelError) {
+		t.Fatalf("log level is not reloaded: %s", logger.Level.Level())
+	}
+
+	// bad config is not applied
+	writeConfig(t, path, "port: 8008\nlog_level: debug\n")
+	if waitLevel(slog.LevelDebug) {
+		t.Fatal("log level of bad config is applied")
+	}
+}
@@ -3,65 +3,132 @@ package grpc
 import (
 	"context"
 	productsRPC "github.com/autumnterror/volha-proto/gen/products"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
-	"log"
+	"log/slog"
+	"productService/internal/pkg/validate"
 	"productService/internal/utils/convert"
-	"productService/internal/utils/format"
 	"productService/internal/views"
+	"slices"
 )
 
 // ---------- Product ----------
 
 func (s *ServerAPI) CreateProduct(ctx context.Context, req *productsRPC.ProductId) (*emptypb.Empty, error) {
 	const op = "productsRPC.ServerAPI.CreateProduct"
-	log.Println(format.String(op, req))
+	slog.DebugContext(ctx, op, "req", req)
 	return handleCRUDResponse(ctx, op, func() error {
-		return s.API.CreateProduct(convert.ToProductViewId(req))
+		p := convert.ToProductViewId(req)
+		if err := s.validateProduct(ctx, p); err != nil {
+			return err
+		}
+		return s.API.CreateProduct(ctx, p)
 	})
 }
 
 func (s *ServerAPI) UpdateProduct(ctx context.Context, req *productsRPC.ProductId) (*emptypb.Empty, error) {
 	const op = "productsRPC.ServerAPI.UpdateProduct"
-	log.Println(format.String(op, req))
+	slog.DebugContext(ctx, op, "req", req)
 	return handleCRUDResponse(ctx, op, func() error {
-		return s.API.UpdateProduct(convert.ToProductViewId(req), req.GetId())
+		p, err := s.restrictProductUpdate(ctx, convert.ToProductViewId(req))
+		if err != nil {
+			return err
+		}
+		if err := s.validateProduct(ctx, p); err != nil {
+			return err
+		}
+		return s.API.UpdateProduct(ctx, p, req.GetId())
 	})
 }
 
 func (s *ServerAPI) DeleteProduct(ctx context.Context, req *productsRPC.Id) (*emptypb.Empty, error) {
 	const op = "productsRPC.ServerAPI.DeleteProduct"
-	log.Println(format.String(op, req))
+	slog.DebugContext(ctx, op, "req", req)
 	return handleCRUDResponse(ctx, op, func() error {
-		return s.API.DeleteProduct(req.GetId())
+		return s.API.DeleteProduct(ctx, req.GetId())
 	})
 }
 
+// restrictProductUpdate reject update which change fields acting admin is not allowed to change.
+// Pricing can change only price, content managers everything except price
+func (s *ServerAPI) restrictProductUpdate(ctx context.Context, p *views.ProductId) (*views.ProductId, error) {
+	catalog, price := can(ctx, PermProductsWrite), can(ctx, PermPriceWrite)
+	if catalog && price {
+		return p, nil
+	}
+
+	current, err := s.API.GetProductById(ctx, p.Id)
+	if err != nil {
+		return nil, err
+	}
+	old := convert.ToProductViewIdFromProduct(convert.ToRPCProduct(current))
+
+	if !catalog {
+		// pricing role change prices only, request with other changed fields is rejected
+		if !sameExceptPrice(old, p) {
+			return nil, status.Error(codes.PermissionDenied, "only price can be changed")
+		}
+		old.Price = p.Price
+		old.Version = p.Version
+		return old, nil
+	}
+	if p.Price != old.Price {
+		return nil, status.Error(codes.PermissionDenied, "price can not be changed")
+	}
+	return p, nil
+}
+
+// sameExceptPrice compare products without price and version. Order of lists is not important,
+// except photos which are gallery order. Photos of b are compared only when b replace them
+func sameExceptPrice(a, b *views.ProductId) bool {
+	set := func(s []string) []string {
+		return slices.Sorted(slices.Values(s))
+	}
+	return a.Id == b.Id && a.Title == b.Title && a.Article == b.Article &&
+		a.Brand == b.Brand && a.Category == b.Category && a.Country == b.Country &&
+		a.Width == b.Width && a.Height == b.Height && a.Depth == b.Depth &&
+		a.Description == b.Description &&
+		slices.Equal(set(a.Materials), set(b.Materials)) &&
+		slices.Equal(set(a.Colors), set(b.Colors)) &&
+		slices.Equal(set(a.Seems), set(b.Seems)) &&
+		(!b.ReplacePhotos || slices.Equal(a.Photos, b.Photos))
+}
+
 // ---------- Brand ----------
 
 func (s *ServerAPI) CreateBrand(ctx context.Context, req *productsRPC.Brand) (*emptypb.Empty, error) {
 	const op = "productsRPC.CreateBrand"
-	log.Println(format.String(op, req))
+	slog.DebugContext(ctx, op, "req", req)
 	return handleCRUDResponse(ctx, op, func() error {
-		return s.API.CreateBrand(&views.Brand{Id: req.Id, Name: req.Name})
+		b := &views.Brand{Id: req.Id, Name: req.Name}
+		if err := validate.Brand(b).Err(); err != nil {
+			return err
+		}
+		return s.API.CreateBrand(ctx, b)
 	})
 }
 func (s *ServerAPI) UpdateBrand(ctx context.Context, req *productsRPC.Brand) (*emptypb.Empty, error) {
 	const op = "productsRPC.UpdateBrand"
-	log.Println(format.String(op, req))
+	slog.DebugContext(ctx, op, "req", req)
 	return handleCRUDResponse(ctx, op, func() error {
-		return s.API.UpdateBrand(&views.Brand{Id: req.Id, Name: req.Name}, req.Id)
+		b := &views.Brand{Id: req.Id, Name: req.Name, Version: req.Version}
+		if err := validate.Brand(b).Err(); err != nil {
+			return err
+		}
+		return s.API.UpdateBrand(ctx, b, req.Id)
 	})
 }
 func (s *ServerAPI) DeleteBrand(ctx context.Context, req *productsRPC.Id) (*emptypb.Empty, error) {
 	const op = "productsRPC.DeleteBrand"
-	log.Println(format.String(op, req))
+	slog.DebugContext(ctx, op, "req", req)
 	return handleCRUDResponse(ctx, op, func() error {
-		return s.API.DeleteBrand(req.Id)
+		return s.API.DeleteBrand(ctx, req.Id)
 	})
 }
 func (s *ServerAPI) GetAllBrands(ctx context.Context, _ *emptypb.Empty) (*productsRPC.BrandList, error) {
 	const op = "productsRPC.GetAllBrands"
-	log.Println(op)
+	slog.DebugContext(ctx, op)
 	data, err := handleListResponse(ctx, op, s.API.GetAllBrands, convert.ToBrandList)
 	if err != nil {
 		return nil, err
@@ -73,28 +140,36 @@ func (s *ServerAPI) GetAllBrands(ctx context.Context, _ *emptypb.Empty) (*produc
 
 func (s *ServerAPI) CreateCategory(ctx context.Context, req *productsRPC.Category) (*emptypb.Empty, error) {
 	const op = "productsRPC.CreateCategory"
-	log.Println(format.String(op, req))
+	slog.DebugContext(ctx, op, "req", req)
 	return handleCRUDResponse(ctx, op, func() error {
-		return s.API.CreateCategory(&views.Category{Id: req.Id, Title: req.Title, Uri: req.Uri, Img: req.Img})
+		c := &views.Category{Id: req.Id, Title: req.Title, Uri: req.Uri, Img: req.Img}
+		if err := validate.Category(c).Err(); err != nil {
+			return err
+		}
+		return s.API.CreateCategory(ctx, c)
 	})
 }
 func (s *ServerAPI) UpdateCategory(ctx context.Context, req *productsRPC.Category) (*emptypb.Empty, error) {
 	const op = "productsRPC.UpdateCategory"
-	log.Println(format.String(op, req))
+	slog.DebugContext(ctx, op, "req", req)
 	return handleCRUDResponse(ctx, op, func() error {
-		return s.API.UpdateCategory(&views.Category{Id: req.Id, Title: req.Title, Uri: req.Uri, Img: req.Img}, req.Id)
+		c := &views.Category{Id: req.Id, Title: req.Title, Uri: req.Uri, Img: req.Img, Version: req.Version}
+		if err := validate.Category(c).Err(); err != nil {
+			return err
+		}
+		return s.API.UpdateCategory(ctx, c, req.Id)
 	})
 }
 func (s *ServerAPI) DeleteCategory(ctx context.Context, req *productsRPC.Id) (*emptypb.Empty, error) {
 	const op = "productsRPC.DeleteCategory"
-	log.Println(format.String(op, req))
+	slog.DebugContext(ctx, op, "req", req)
 	return handleCRUDResponse(ctx, op, func() error {
-		return s.API.DeleteCategory(req.Id)
+		return s.API.DeleteCategory(ctx, req.Id)
 	})
 }
 func (s *ServerAPI) GetAllCategories(ctx context.Context, _ *emptypb.Empty) (*productsRPC.CategoryList, error) {
 	const op = "productsRPC.GetAllCategories"
-	log.Println(op)
+	slog.DebugContext(ctx, op)
 	data, err := handleListResponse(ctx, op, s.API.GetAllCategories, convert.ToCategoryList)
 	if err != nil {
 		return nil, err
@@ -106,28 +181,36 @@ func (s *ServerAPI) GetAllCategories(ctx context.Context, _ *emptypb.Empty) (*pr
 
 func (s *ServerAPI) CreateCountry(ctx context.Context, req *productsRPC.Country) (*emptypb.Empty, error) {
 	const op = "productsRPC.CreateCountry"
-	log.Println(format.String(op, req))
+	slog.DebugContext(ctx, op, "req", req)
 	return handleCRUDResponse(ctx, op, func() error {
-		return s.API.CreateCountry(&views.Country{Id: req.Id, Title: req.Title, Friendly: req.Friendly})
+		c := &views.Country{Id: req.Id, Title: req.Title, Friendly: req.Friendly}
+		if err := validate.Country(c).Err(); err != nil {
+			return err
+		}
+		return s.API.CreateCountry(ctx, c)
 	})
 }
 func (s *ServerAPI) UpdateCountry(ctx context.Context, req *productsRPC.Country) (*emptypb.Empty, error) {
 	const op = "productsRPC.UpdateCountry"
-	log.Println(format.String(op, req))
+	slog.DebugContext(ctx, op, "req", req)
 	return handleCRUDResponse(ctx, op, func() error {
-		return s.API.UpdateCountry(&views.Country{Id: req.Id, Title: req.Title, Friendly: req.Friendly}, req.Id)
+		c := &views.Country{Id: req.Id, Title: req.Title, Friendly: req.Friendly, Version: req.Version}
+		if err := validate.Country(c).Err(); err != nil {
+			return err
+		}
+		return s.API.UpdateCountry(ctx, c, req.Id)
 	})
 }
 func (s *ServerAPI) DeleteCountry(ctx context.Context, req *productsRPC.Id) (*emptypb.Empty, error) {
 	const op = "productsRPC.DeleteCountry"
-	log.Println(format.String(op, req))
+	slog.DebugContext(ctx, op, "req", req)
 	return handleCRUDResponse(ctx, op, func() error {
-		return s.API.DeleteCountry(req.Id)
+		return s.API.DeleteCountry(ctx, req.Id)
 	})
 }
 func (s *ServerAPI) GetAllCountries(ctx context.Context, _ *emptypb.Empty) (*productsRPC.CountryList, error) {
 	const op = "productsRPC.GetAllCountries"
-	log.Println(op)
+	slog.DebugContext(ctx, op)
 	data, err := handleListResponse(ctx, op, s.API.GetAllCountries, convert.ToCountryList)
 	if err != nil {
 		return nil, err
@@ -139,28 +222,36 @@ func (s *ServerAPI) GetAllCountries(ctx context.Context, _ *emptypb.Empty) (*pro
 
 func (s *ServerAPI) CreateMaterial(ctx context.Context, req *productsRPC.Material) (*emptypb.Empty, error) {
 	const op = "productsRPC.CreateMaterial"
-	log.Println(format.String(op, req))
+	slog.DebugContext(ctx, op, "req", req)
 	return handleCRUDResponse(ctx, op, func() error {
-		return s.API.CreateMaterial(&views.Material{Id: req.Id, Title: req.Title})
+		m := &views.Material{Id: req.Id, Title: req.Title}
+		if err := validate.Material(m).Err(); err != nil {
+			return err
+		}
+		return s.API.CreateMaterial(ctx, m)
 	})
 }
 func (s *ServerAPI) UpdateMaterial(ctx context.Context, req *productsRPC.Material) (*emptypb.Empty, error) {
 	const op = "productsRPC.UpdateMaterial"
-	log.Println(format.String(op, req))
+	slog.DebugContext(ctx, op, "req", req)
 	return handleCRUDResponse(ctx, op, func() error {
-		return s.API.UpdateMaterial(&views.Material{Id: req.Id, Title: req.Title}, req.Id)
+		m := &views.Material{Id: req.Id, Title: req.Title, Version: req.Version}
+		if err := validate.Material(m).Err(); err != nil {
+			return err
+		}
+		return s.API.UpdateMaterial(ctx, m, req.Id)
 	})
 }
 func (s *ServerAPI) DeleteMaterial(ctx context.Context, req *productsRPC.Id) (*emptypb.Empty, error) {
 	const op = "productsRPC.DeleteMaterial"
-	log.Println(format.String(op, req))
+	slog.DebugContext(ctx, op, "req", req)
 	return handleCRUDResponse(ctx, op, func() error {
-		return s.API.DeleteMaterial(req.Id)
+		return s.API.DeleteMaterial(ctx, req.Id)
 	})
 }
 func (s *ServerAPI) GetAllMaterials(ctx context.Context, _ *emptypb.Empty) (*productsRPC.MaterialList, error) {
 	const op = "productsRPC.GetAllMaterials"
-	log.Println(op)
+	slog.DebugContext(ctx, op)
 	data, err := handleListResponse(ctx, op, s.API.GetAllMaterials, convert.ToMaterialList)
 	if err != nil {
 		return nil, err
@@ -172,28 +263,36 @@ func (s *ServerAPI) GetAllMaterials(ctx context.Context, _ *emptypb.Empty) (*pro
 
 func (s *ServerAPI) CreateColor(ctx context.Context, req *productsRPC.Color) (*emptypb.Empty, error) {
 	const op = "productsRPC.CreateColor"
-	log.Println(format.String(op, req))
+	slog.DebugContext(ctx, op, "req", req)
 	return handleCRUDResponse(ctx, op, func() error {
-		return s.API.CreateColor(&views.Color{Id: req.Id, Name: req.Name, Hex: req.Hex})
+		c := &views.Color{Id: req.Id, Name: req.Name, Hex: req.Hex}
+		if err := validate.Color(c).Err(); err != nil {
+			return err
+		}
+		return s.API.CreateColor(ctx, c)
 	})
 }
 func (s *ServerAPI) UpdateColor(ctx context.Context, req *productsRPC.Color) (*emptypb.Empty, error) {
 	const op = "productsRPC.UpdateColor"
-	log.Println(format.String(op, req))
+	slog.DebugContext(ctx, op, "req", req)
 	return handleCRUDResponse(ctx, op, func() error {
-		return s.API.UpdateColor(&views.Color{Id: req.Id, Name: req.Name, Hex: req.Hex}, req.Id)
+		c := &views.Color{Id: req.Id, Name: req.Name, Hex: req.Hex, Version: req.Version}
+		if err := validate.Color(c).Err(); err != nil {
+			return err
+		}
+		return s.API.UpdateColor(ctx, c, req.Id)
 	})
 }
 func (s *ServerAPI) DeleteColor(ctx context.Context, req *productsRPC.Id) (*emptypb.Empty, error) {
 	const op = "productsRPC.DeleteColor"
-	log.Println(format.String(op, req))
+	slog.DebugContext(ctx, op, "req", req)
 	return handleCRUDResponse(ctx, op, func() error {
-		return s.API.DeleteColor(req.Id)
+		return s.API.DeleteColor(ctx, req.Id)
 	})
 }
 func (s *ServerAPI) GetAllColors(ctx context.Context, _ *emptypb.Empty) (*productsRPC.ColorList, error) {
 	const op = "productsRPC.GetAllColors"
-	log.Println(op)
+	slog.DebugContext(ctx, op)
 	data, err := handleListResponse(ctx, op, s.API.GetAllColors, convert.ToColorList)
 	if err != nil {
 		return nil, err
@@ -205,40 +304,146 @@ func (s *ServerAPI) GetAllColors(ctx context.Context, _ *emptypb.Empty) (*produc
 
 func (s *ServerAPI) CreateProductColorPhotos(ctx context.Context, req *productsRPC.ProductColorPhotos) (*emptypb.Empty, error) {
 	const op = "productsRPC.CreateProductColorPhotos"
-	log.Println(format.String(op, req))
+	slog.DebugContext(ctx, op, "req", req)
 	return handleCRUDResponse(ctx, op, func() error {
-		return s.API.CreateProductColorPhotos(&views.ProductColorPhotos{
+		p := &views.ProductColorPhotos{
 			ProductId: req.ProductId,
 			ColorId:   req.ColorId,
 			Photos:    req.Photos,
-		})
+		}
+		if err := s.validateColorPhotos(ctx, p); err != nil {
+			return err
+		}
+		return s.API.CreateProductColorPhotos(ctx, p)
 	})
 }
 func (s *ServerAPI) UpdateProductColorPhotos(ctx context.Context, req *productsRPC.ProductColorPhotos) (*emptypb.Empty, error) {
 	const op = "productsRPC.UpdateProductColorPhotos"
-	log.Println(format.String(op, req))
+	slog.DebugContext(ctx, op, "req", req)
 	return handleCRUDResponse(ctx, op, func() error {
-		return s.API.UpdateProductColorPhotos(&views.ProductColorPhotos{
+		p := &views.ProductColorPhotos{
 			ProductId: req.ProductId,
 			ColorId:   req.ColorId,
 			Photos:    req.Photos,
-		})
+		}
+		if err := s.validateColorPhotos(ctx, p); err != nil {
+			return err
+		}
+		return s.API.UpdateProductColorPhotos(ctx, p)
 	})
 }
 func (s *ServerAPI) DeleteProductColorPhotos(ctx context.Context, req *productsRPC.ProductColorPhotosId) (*emptypb.Empty, error) {
 	const op = "productsRPC.DeleteProductColorPhotos"
-	log.Println(format.String(op, req))
+	slog.DebugContext(ctx, op, "req", req)
 	return handleCRUDResponse(ctx, op, func() error {
-		return s.API.DeleteProductColorPhotos(req.ProductId, req.ColorId)
+		return s.API.DeleteProductColorPhotos(ctx, req.ProductId, req.ColorId)
 	})
 }
 
 func (s *ServerAPI) GetAllProductColorPhotos(ctx context.Context, _ *emptypb.Empty) (*productsRPC.ProductColorPhotosList, error) {
 	const op = "productsRPC.GetAllProductColorPhotos"
-	log.Println(op)
+	slog.DebugContext(ctx, op)
 	data, err := handleListResponse(ctx, op, s.API.GetAllProductColorPhotos, convert.ToProductColorList)
 	if err != nil {
 		return nil, err
 	}
 	return data.(*productsRPC.ProductColorPhotosList), nil
 }
+
+// ---------- Product photos ----------
+
+func (s *ServerAPI) AddProductPhoto(ctx context.Context, req *productsRPC.ProductPhoto) (*emptypb.Empty, error) {
+	const op = "productsRPC.AddProductPhoto"
+	slog.DebugContext(ctx, op, "req", req)
+	return handleCRUDResponse(ctx, op, func() error {
+		p := convert.ToProductPhotoView(req)
+		if err := s.validatePhoto(ctx, p, validate.NewProductPhoto(p)); err != nil {
+			return err
+		}
+		return s.API.AddProductPhoto(ctx, p)
+	})
+}
+func (s *ServerAPI) UpdateProductPhoto(ctx context.Context, req *productsRPC.ProductPhoto) (*emptypb.Empty, error) {
+	const op = "productsRPC.UpdateProductPhoto"
+	slog.DebugContext(ctx, op, "req", req)
+	return handleCRUDResponse(ctx, op, func() error {
+		p := convert.ToProductPhotoView(req)
+		if err := s.validatePhoto(ctx, p, validate.ProductPhoto(p)); err != nil {
+			return err
+		}
+		return s.API.UpdateProductPhoto(ctx, p)
+	})
+}
+func (s *ServerAPI) ReorderProductPhotos(ctx context.Context, req *productsRPC.ProductPhotosOrder) (*emptypb.Empty, error) {
+	const op = "productsRPC.ReorderProductPhotos"
+	slog.DebugContext(ctx, op, "req", req)
+	return handleCRUDResponse(ctx, op, func() error {
+		return s.API.ReorderProductPhotos(ctx, req.GetProductId(), req.GetIds())
+	})
+}
+func (s *ServerAPI) GetProductPhotos(ctx context.Context, req *productsRPC.Id) (*productsRPC.ProductPhotoList, error) {
+	const op = "productsRPC.GetProductPhotos"
+	slog.DebugContext(ctx, op)
+	data, err := handleListResponse(ctx, op, func(ctx context.Context) ([]views.ProductPhoto, error) {
+		return s.API.GetProductPhotos(ctx, req.GetId())
+	}, convert.ToProductPhotoList)
+	if err != nil {
+		return nil, err
+	}
+	return data.(*productsRPC.ProductPhotoList), nil
+}
+
+// ---------- Admins ----------
+// request is not logged here, it has password hash
+
+func (s *ServerAPI) GetAdmin(ctx context.Context, req *productsRPC.Id) (*productsRPC.AdminUser, error) {
+	const op = "productsRPC.GetAdmin"
+	slog.DebugContext(ctx, op, "login", req.GetId())
+
+	u, err := s.API.GetAdmin(ctx, req.GetId())
+	if err != nil {
+		slog.ErrorContext(ctx, op, "err", err)
+		return nil, callStatus(ctx, err)
+	}
+
+	slog.DebugContext(ctx, op, "result", "success")
+	return convert.ToRPCAdmin(u), nil
+}
+func (s *ServerAPI) GetAllAdmins(ctx context.Context, _ *emptypb.Empty) (*productsRPC.AdminUserList, error) {
+	const op = "productsRPC.GetAllAdmins"
+	slog.DebugContext(ctx, op)
+	data, err := handleListResponse(ctx, op, s.API.GetAllAdmins, convert.ToAdminList)
+	if err != nil {
+		return nil, err
+	}
+	return data.(*productsRPC.AdminUserList), nil
+}
+func (s *ServerAPI) CreateAdmin(ctx context.Context, req *productsRPC.AdminUser) (*emptypb.Empty, error) {
+	const op = "productsRPC.CreateAdmin"
+	slog.DebugContext(ctx, op, "login", req.GetLogin(), "role", req.GetRole())
+	return handleCRUDResponse(ctx, op, func() error {
+		u := convert.ToAdminView(req)
+		if err := validate.NewAdmin(u).Err(); err != nil {
+			return err
+		}
+		return s.API.CreateAdmin(ctx, u)
+	})
+}
+func (s *ServerAPI) UpdateAdmin(ctx context.Context, req *productsRPC.AdminUser) (*emptypb.Empty, error) {
+	const op = "productsRPC.UpdateAdmin"
+	slog.DebugContext(ctx, op, "login", req.GetLogin(), "role", req.GetRole())
+	return handleCRUDResponse(ctx, op, func() error {
+		u := convert.ToAdminView(req)
+		if err := validate.Admin(u).Err(); err != nil {
+			return err
+		}
+		return s.API.UpdateAdmin(ctx, u)
+	})
+}
+func (s *ServerAPI) DeleteAdmin(ctx context.Context, req *productsRPC.Id) (*emptypb.Empty, error) {
+	const op = "productsRPC.DeleteAdmin"
+	slog.DebugContext(ctx, op, "login", req.GetId())
+	return handleCRUDResponse(ctx, op, func() error {
+		return s.API.DeleteAdmin(ctx, req.GetId())
+	})
+}
@@ -1,38 +1,62 @@
 package grpc
 
 import (
+	"context"
 	"fmt"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/keepalive"
-	"log"
+	"log/slog"
 	"net"
 	"productService/config"
+	"productService/internal/pkg/certs"
 	"productService/internal/pkg/psql"
 	"productService/internal/utils/format"
 )
 
 type App struct {
 	gRPCServer *grpc.Server
+	health     *health.Server
+	db         Pinger
+	watch      context.Context
+	cancel     context.CancelFunc
 	cfg        *config.Config
 	API        psql.Repository
 }
 
-// New construct new App structure
+// New construct new App structure. db is checked by grpc.health.v1 service
 func New(
 	cfg *config.Config,
 	API psql.Repository,
-) *App {
-	s := grpc.NewServer(
+	db Pinger,
+) (*App, error) {
+	const op = "grpc.New"
+
+	creds, err := certs.Server(cfg.TLS, "product-service")
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+
+	s := grpc.NewServer(append(serverOptions(newActorTrust(cfg.ActorPeers, cfg.ActorSecret)),
+		grpc.Creds(creds),
 		grpc.KeepaliveParams(keepalive.ServerParameters{
 			MaxConnectionIdle: 0,
 		}),
-	)
+	)...)
 	Register(s, API)
+	hs := health.NewServer()
+	healthpb.RegisterHealthServer(s, hs)
+	watch, cancel := context.WithCancel(context.Background())
 	return &App{
 		gRPCServer: s,
+		health:     hs,
+		db:         db,
+		watch:      watch,
+		cancel:     cancel,
 		cfg:        cfg,
 		API:        API,
-	}
+	}, nil
 }
 
 // MustRun running gRPC server and panic if error
@@ -49,7 +73,9 @@ func (a *App) Run() error {
 	if err != nil {
 		return format.Error(op, err)
 	}
-	log.Println(op, "grpc server is running", a.cfg.Port)
+	slog.Info("grpc server is running", "op", op, "port", a.cfg.Port)
+
+	go watchHealth(a.watch, a.health, a.db)
 
 	if err := a.gRPCServer.Serve(l); err != nil {
 		return format.Error(op, err)
@@ -58,8 +84,26 @@ func (a *App) Run() error {
 	return nil
 }
 
-func (a *App) Stop() {
+// Stop wait calls in flight until ctx is done, then cancel the rest
+func (a *App) Stop(ctx context.Context) error {
 	const op = "grpc.Stop"
-	a.gRPCServer.GracefulStop()
-	log.Println(op, "grpc server is stop", a.cfg.Port)
+	a.cancel()
+	// clients see NOT_SERVING while calls in flight are finished
+	a.health.Shutdown()
+
+	done := make(chan struct{})
+	go func() {
+		a.gRPCServer.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		// Watch streams of health and slow calls do not end by itself
+		a.gRPCServer.Stop()
+		<-done
+		slog.Warn("calls in flight are canceled", "op", op)
+	}
+	slog.Info("grpc server is stop", "op", op, "port", a.cfg.Port)
+	return nil
 }
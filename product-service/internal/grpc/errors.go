@@ -0,0 +1,157 @@
+package grpc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"productService/internal/pkg/psql"
+	"productService/internal/pkg/validate"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// ErrorDomain is domain of ErrorInfo details of product-service errors
+const ErrorDomain = "product-service"
+
+// Reasons of ErrorInfo details. Gateway give them to clients as machine readable codes
+const (
+	ReasonNotFound           = "NOT_FOUND"
+	ReasonAlreadyExists      = "ALREADY_EXISTS"
+	ReasonReferenceViolation = "REFERENCE_VIOLATION"
+	ReasonInvalidArgument    = "INVALID_ARGUMENT"
+	// VersionConflictReason metadata has current version of row under "current_version"
+	VersionConflictReason = "VERSION_CONFLICT"
+)
+
+// postgres error codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+	pgNotNullViolation    = "23502"
+	pgCheckViolation      = "23514"
+	pgDataException       = "22"
+)
+
+// toStatus classify error of repository into gRPC status with details. Errors which already
+// are statuses (permission denied and so on) are returned as is. Message of status is shown to
+// clients by gateway, so it never has op names, sql or text of postgres, they are only logged
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	var se interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &se) && se.GRPCStatus().Code() != codes.Unknown {
+		// own status of error, not status.FromError which put whole wrapped text into message
+		return se.GRPCStatus().Err()
+	}
+
+	var vc *psql.VersionConflict
+	if errors.As(err, &vc) {
+		return withDetails(codes.Aborted, vc.Error(), info(VersionConflictReason, map[string]string{
+			"current_version": strconv.FormatInt(vc.Current, 10),
+		}))
+	}
+
+	var ve *validate.Errors
+	if errors.As(err, &ve) {
+		violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(ve.Violations))
+		for _, v := range ve.Violations {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: v.Field, Description: v.Message})
+		}
+		return withDetails(codes.InvalidArgument, ve.Error(),
+			info(ReasonInvalidArgument, nil),
+			&errdetails.BadRequest{FieldViolations: violations})
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqStatus(pqErr)
+	}
+
+	switch {
+	case errors.Is(err, psql.ErrNotFound), errors.Is(err, sql.ErrNoRows):
+		return withDetails(codes.NotFound, "not found", info(ReasonNotFound, nil))
+	case errors.Is(err, psql.ErrInvalid):
+		return withDetails(codes.InvalidArgument, message(err), info(ReasonInvalidArgument, nil))
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, "deadline exceeded")
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, "canceled")
+	}
+	return status.Error(codes.Internal, "internal error")
+}
+
+// message cut op names of format.Error from err: "OP: psql.X: ERROR: no search parameter" -> "no search parameter"
+func message(err error) string {
+	msg := err.Error()
+	if i := strings.LastIndex(msg, "ERROR: "); i >= 0 {
+		msg = msg[i+len("ERROR: "):]
+	}
+	return msg
+}
+
+func pqStatus(e *pq.Error) error {
+	column := e.Column
+	if column == "" {
+		column = detailColumn(e.Detail)
+	}
+	// text and detail of postgres have values and names of constraints, only column goes to client
+	meta := map[string]string{"field": column}
+
+	switch code := string(e.Code); {
+	case code == pgUniqueViolation:
+		return withDetails(codes.AlreadyExists, fieldMessage(column, "already exists"),
+			info(ReasonAlreadyExists, meta),
+			&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: column, Description: "already exists"},
+			}})
+	case code == pgForeignKeyViolation:
+		return withDetails(codes.FailedPrecondition, fieldMessage(column, "refers to missing object or is still in use"),
+			info(ReasonReferenceViolation, meta),
+			&errdetails.PreconditionFailure{Violations: []*errdetails.PreconditionFailure_Violation{
+				{Type: "REFERENCE", Subject: column, Description: "reference violation"},
+			}})
+	case code == pgNotNullViolation, code == pgCheckViolation, strings.HasPrefix(code, pgDataException):
+		return withDetails(codes.InvalidArgument, fieldMessage(column, "has invalid value"),
+			info(ReasonInvalidArgument, meta),
+			&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: column, Description: "invalid value"},
+			}})
+	}
+	return status.Error(codes.Internal, "internal error")
+}
+
+func fieldMessage(column, msg string) string {
+	if column == "" {
+		return "value " + msg
+	}
+	return column + " " + msg
+}
+
+// detailColumn take column from detail of constraint error like "Key (name)=(Ikea) already exists."
+func detailColumn(detail string) string {
+	start := strings.Index(detail, "(")
+	end := strings.Index(detail, ")=")
+	if start < 0 || end < start {
+		return ""
+	}
+	return detail[start+1 : end]
+}
+
+func info(reason string, meta map[string]string) *errdetails.ErrorInfo {
+	return &errdetails.ErrorInfo{Reason: reason, Domain: ErrorDomain, Metadata: meta}
+}
+
+func withDetails(c codes.Code, msg string, details ...protoadapt.MessageV1) error {
+	st := status.New(c, msg)
+	if withInfo, err := st.WithDetails(details...); err == nil {
+		st = withInfo
+	}
+	return st.Err()
+}
@@ -0,0 +1,66 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"productService/internal/pkg/psql"
+	"productService/internal/pkg/validate"
+	"productService/internal/utils/format"
+	"productService/internal/views"
+	"testing"
+
+	"github.com/lib/pq"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestToStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		code   codes.Code
+		reason string
+	}{
+		{"not found", format.Error("op", fmt.Errorf("brand with id 1: %w", psql.ErrNotFound)), codes.NotFound, ReasonNotFound},
+		{"unique", &pq.Error{Code: "23505", Detail: "Key (article)=(12345678) already exists."}, codes.AlreadyExists, ReasonAlreadyExists},
+		{"foreign key", format.Error("op", &pq.Error{Code: "23503", Table: "products"}), codes.FailedPrecondition, ReasonReferenceViolation},
+		{"bad input", &pq.Error{Code: "22P02", Message: "invalid input syntax for type integer"}, codes.InvalidArgument, ReasonInvalidArgument},
+		{"version", &psql.VersionConflict{Current: 3}, codes.Aborted, VersionConflictReason},
+		{"validation", format.Error("op", validate.Product(&views.ProductId{})), codes.InvalidArgument, ReasonInvalidArgument},
+		{"status", status.Error(codes.PermissionDenied, "no"), codes.PermissionDenied, ""},
+		{"other", errors.New("boom"), codes.Internal, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := status.Convert(toStatus(tt.err))
+			if st.Code() != tt.code {
+				t.Fatalf("code = %s, want %s", st.Code(), tt.code)
+			}
+			var reason string
+			for _, d := range st.Details() {
+				if i, ok := d.(*errdetails.ErrorInfo); ok {
+					reason = i.GetReason()
+				}
+			}
+			if reason != tt.reason {
+				t.Fatalf("reason = %q, want %q", reason, tt.reason)
+			}
+		})
+	}
+
+	// message is shown to clients, it must not have op names or text of postgres
+	_, err := handleCRUDResponse(context.Background(), "productsRPC.ServerAPI.CreateBrand", func() error {
+		return format.Error("psql.CreateBrand", &pq.Error{Code: "23505", Constraint: "brands_name_key",
+			Message: "duplicate key value", Detail: "Key (name)=(Ikea) already exists."})
+	})
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.AlreadyExists || st.Message() != "name already exists" {
+		t.Fatalf("status = %v", err)
+	}
+
+	if got := detailColumn("Key (article)=(12345678) already exists."); got != "article" {
+		t.Fatalf("detailColumn = %q", got)
+	}
+}
@@ -0,0 +1,56 @@
+package grpc
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	productsRPC "github.com/autumnterror/volha-proto/gen/products"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Pinger is database checked by health service, *sql.DB
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+const (
+	healthInterval = 5 * time.Second
+	healthTimeout  = 2 * time.Second
+)
+
+// watchHealth ping database every healthInterval and report result in grpc.health.v1, for whole
+// server and for products service. It return when ctx is done
+func watchHealth(ctx context.Context, hs *health.Server, db Pinger) {
+	const op = "grpc.watchHealth"
+
+	last := healthpb.HealthCheckResponse_UNKNOWN
+	ticker := time.NewTicker(healthInterval)
+	defer ticker.Stop()
+	for {
+		pctx, cancel := context.WithTimeout(ctx, healthTimeout)
+		err := db.PingContext(pctx)
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+
+		st := healthpb.HealthCheckResponse_SERVING
+		if err != nil {
+			st = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		if st != last {
+			slog.Info("health changed", "op", op, "status", st.String(), "err", err)
+			last = st
+		}
+		hs.SetServingStatus("", st)
+		hs.SetServingStatus(productsRPC.Products_ServiceDesc.ServiceName, st)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
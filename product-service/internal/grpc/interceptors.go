@@ -0,0 +1,159 @@
+package grpc
+
+import (
+	"context"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"log/slog"
+	"productService/internal/pkg/logger"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	grpcmiddleware "github.com/grpc-ecosystem/go-grpc-middleware/v2"
+	grpcrecovery "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/recovery"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Metadata keys with http request of call. Gateway send them with every call
+const (
+	RequestIdKey = "x-request-id"
+	RouteKey     = "x-route"
+)
+
+var (
+	handled = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_handled_total",
+		Help: "Total number of RPCs completed on the server, by method and code.",
+	}, []string{"grpc_method", "grpc_code"})
+	handling = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_server_handling_seconds",
+		Help:    "Latency of RPCs handled by the server, by method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"grpc_method"})
+)
+
+// serverOptions is chain of interceptors of server. Order is important: request fields are first so
+// all logs of call have them, access log and metrics are outside of recovery, so panic is seen by
+// them as Internal, and actor is checked by trust right before authorize
+func serverOptions(trust actorTrust) []grpc.ServerOption {
+	recovery := grpcrecovery.WithRecoveryHandlerContext(recovered)
+	return []grpc.ServerOption{
+		// span of call continue trace of gateway, its context come in metadata
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(
+			requestFields,
+			accessLog,
+			metrics,
+			grpcrecovery.UnaryServerInterceptor(recovery),
+			trust.verifyActor,
+			authorize,
+		),
+		grpc.ChainStreamInterceptor(
+			requestFieldsStream,
+			accessLogStream,
+			metricsStream,
+			grpcrecovery.StreamServerInterceptor(recovery),
+		),
+	}
+}
+
+// RequestId return id of request from incoming metadata, or empty string
+func RequestId(ctx context.Context) string {
+	return incoming(ctx, RequestIdKey)
+}
+
+func incoming(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if v := md.Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+// withFields add fields of http request and method to every log record of call
+func withFields(ctx context.Context, fullMethod string) context.Context {
+	args := []any{"grpc_method", methodName(fullMethod)}
+	for _, f := range []struct{ name, key string }{
+		{"request_id", RequestIdKey},
+		{"route", RouteKey},
+		{"admin", ActorLoginKey},
+	} {
+		if v := incoming(ctx, f.key); v != "" {
+			args = append(args, f.name, v)
+		}
+	}
+	return logger.With(ctx, args...)
+}
+
+func requestFields(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	return handler(withFields(ctx, info.FullMethod), req)
+}
+
+func requestFieldsStream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	wrapped := grpcmiddleware.WrapServerStream(ss)
+	wrapped.WrappedContext = withFields(ss.Context(), info.FullMethod)
+	return handler(srv, wrapped)
+}
+
+// recovered turn panic of handler into Internal, so one bad request does not kill whole service
+func recovered(ctx context.Context, p any) error {
+	const op = "grpc.recovered"
+	slog.ErrorContext(ctx, "panic", "op", op, "panic", p, "stack", string(debug.Stack()))
+	return status.Error(codes.Internal, "internal error")
+}
+
+func accessLog(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	logCall(ctx, start, err)
+	return resp, err
+}
+
+func accessLogStream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	logCall(ss.Context(), start, err)
+	return err
+}
+
+func logCall(ctx context.Context, start time.Time, err error) {
+	code := status.Code(err)
+	level := slog.LevelInfo
+	if code == codes.Internal || code == codes.Unknown || code == codes.DataLoss {
+		level = slog.LevelError
+	}
+	slog.Log(ctx, level, "grpc call", "code", code.String(), "duration", time.Since(start))
+}
+
+func metrics(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	observe(info.FullMethod, start, err)
+	return resp, err
+}
+
+func metricsStream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	observe(info.FullMethod, start, err)
+	return err
+}
+
+func observe(fullMethod string, start time.Time, err error) {
+	method := methodName(fullMethod)
+	handling.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	handled.WithLabelValues(method, status.Code(err).String()).Inc()
+}
+
+// methodName cut service from full method: /products.Products/GetProduct -> GetProduct
+func methodName(fullMethod string) string {
+	return fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+}
@@ -0,0 +1,35 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	grpcrecovery "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/recovery"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestRecoveryAndMetrics(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/products.Products/TestPanic"}
+	recovery := grpcrecovery.UnaryServerInterceptor(grpcrecovery.WithRecoveryHandlerContext(recovered))
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIdKey, "req-1"))
+	if id := RequestId(ctx); id != "req-1" {
+		t.Fatalf("request id = %q", id)
+	}
+
+	_, err := metrics(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+		return recovery(ctx, req, info, func(context.Context, any) (any, error) {
+			panic("boom")
+		})
+	})
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("code = %s, want Internal", status.Code(err))
+	}
+	if n := testutil.ToFloat64(handled.WithLabelValues("TestPanic", codes.Internal.String())); n != 1 {
+		t.Fatalf("handled = %v, want 1", n)
+	}
+}
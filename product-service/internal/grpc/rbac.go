@@ -0,0 +1,120 @@
+package grpc
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Metadata keys with acting admin. Gateway set them for every admin request, from other clients
+// they are rejected by verifyActor
+const (
+	ActorLoginKey = "x-actor-login"
+	ActorRoleKey  = "x-actor-role"
+	// ActorPermsKey is comma separated permissions of api key. It is used instead of role
+	ActorPermsKey = "x-actor-perms"
+)
+
+type Perm string
+
+// Same permissions and roles as in gateway/internal/pkg/auth/roles.go
+const (
+	PermCatalogWrite  Perm = "catalog:write"
+	PermProductsWrite Perm = "products:write"
+	PermPriceWrite    Perm = "price:write"
+	PermDelete        Perm = "catalog:delete"
+	// PermUsers is needed to read and change admins. Gateway read them with it before anyone is logged in
+	PermUsers Perm = "users:manage"
+)
+
+var rolePerms = map[string][]Perm{
+	"owner":   {PermCatalogWrite, PermProductsWrite, PermPriceWrite, PermDelete, PermUsers},
+	"content": {PermCatalogWrite, PermProductsWrite},
+	"pricing": {PermPriceWrite},
+}
+
+// methodPerms is list of permissions one of which is needed to call method. Methods not listed here are read only
+// and public. Admins are not public even for reading, they have password hashes. UpdateProduct with price:write only can change nothing but price, see restrictProductUpdate
+var methodPerms = map[string][]Perm{
+	"CreateProduct":            {PermProductsWrite},
+	"UpdateProduct":            {PermProductsWrite, PermPriceWrite},
+	"DeleteProduct":            {PermDelete},
+	"CreateBrand":              {PermCatalogWrite},
+	"UpdateBrand":              {PermCatalogWrite},
+	"DeleteBrand":              {PermDelete},
+	"CreateCategory":           {PermCatalogWrite},
+	"UpdateCategory":           {PermCatalogWrite},
+	"DeleteCategory":           {PermDelete},
+	"CreateCountry":            {PermCatalogWrite},
+	"UpdateCountry":            {PermCatalogWrite},
+	"DeleteCountry":            {PermDelete},
+	"CreateMaterial":           {PermCatalogWrite},
+	"UpdateMaterial":           {PermCatalogWrite},
+	"DeleteMaterial":           {PermDelete},
+	"CreateColor":              {PermCatalogWrite},
+	"UpdateColor":              {PermCatalogWrite},
+	"DeleteColor":              {PermDelete},
+	"CreateProductColorPhotos": {PermCatalogWrite},
+	"UpdateProductColorPhotos": {PermCatalogWrite},
+	"DeleteProductColorPhotos": {PermDelete},
+	"AddProductPhoto":          {PermProductsWrite},
+	"UpdateProductPhoto":       {PermProductsWrite},
+	"ReorderProductPhotos":     {PermProductsWrite},
+	"RemoveProductPhoto":       {PermProductsWrite},
+	"GetAdmin":                 {PermUsers},
+	"GetAllAdmins":             {PermUsers},
+	"CreateAdmin":              {PermUsers},
+	"UpdateAdmin":              {PermUsers},
+	"DeleteAdmin":              {PermUsers},
+}
+
+func actor(ctx context.Context) (login, role string) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", ""
+	}
+	if v := md.Get(ActorLoginKey); len(v) > 0 {
+		login = v[0]
+	}
+	if v := md.Get(ActorRoleKey); len(v) > 0 {
+		role = v[0]
+	}
+	return login, role
+}
+
+func can(ctx context.Context, p Perm) bool {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(ActorPermsKey); len(v) > 0 {
+			return slices.Contains(strings.Split(v[0], ","), string(p))
+		}
+	}
+	_, role := actor(ctx)
+	return slices.Contains(rolePerms[role], p)
+}
+
+// authorize is unary interceptor that reject write methods when acting admin has no permission for them
+func authorize(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	const op = "grpc.authorize"
+
+	method := methodName(info.FullMethod)
+	perms, ok := methodPerms[method]
+	if !ok {
+		return handler(ctx, req)
+	}
+
+	for _, p := range perms {
+		if can(ctx, p) {
+			return handler(ctx, req)
+		}
+	}
+
+	login, role := actor(ctx)
+	slog.ErrorContext(ctx, op, "err", status.Errorf(codes.PermissionDenied, "%s (%s) can not call %s", login, role, method))
+	return nil, status.Errorf(codes.PermissionDenied, "role %q can not call %s", role, method)
+}
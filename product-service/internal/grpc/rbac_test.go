@@ -0,0 +1,92 @@
+package grpc
+
+import (
+	"context"
+	"productService/internal/pkg/psql"
+	"productService/internal/views"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func withActor(role, perms string) context.Context {
+	kv := []string{ActorLoginKey, "someone", ActorRoleKey, role}
+	if perms != "" {
+		kv = append(kv, ActorPermsKey, perms)
+	}
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(kv...))
+}
+
+func TestAuthorize(t *testing.T) {
+	ok := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+
+	for _, tt := range []struct {
+		name   string
+		ctx    context.Context
+		method string
+		want   codes.Code
+	}{
+		{"anonymous read", context.Background(), "GetAllBrands", codes.OK},
+		{"anonymous write", context.Background(), "CreateBrand", codes.PermissionDenied},
+		{"content write", withActor("content", ""), "CreateBrand", codes.OK},
+		{"content delete", withActor("content", ""), "DeleteBrand", codes.PermissionDenied},
+		{"pricing update product", withActor("pricing", ""), "UpdateProduct", codes.OK},
+		{"pricing create product", withActor("pricing", ""), "CreateProduct", codes.PermissionDenied},
+		{"owner delete", withActor("owner", ""), "DeleteProduct", codes.OK},
+		{"anonymous admins", context.Background(), "GetAdmin", codes.PermissionDenied},
+		{"content admins", withActor("content", ""), "GetAllAdmins", codes.PermissionDenied},
+		{"owner admins", withActor("owner", ""), "CreateAdmin", codes.OK},
+		{"gateway admins", withActor("", "users:manage"), "GetAdmin", codes.OK},
+		{"key perms instead of role", withActor("owner", "products:write"), "DeleteProduct", codes.PermissionDenied},
+	} {
+		info := &grpc.UnaryServerInfo{FullMethod: "/products.Products/" + tt.method}
+		if _, err := authorize(tt.ctx, nil, info, ok); status.Code(err) != tt.want {
+			t.Errorf("%s: %v", tt.name, err)
+		}
+	}
+}
+
+// productRepo answer GetProductById with product, other methods are not used
+type productRepo struct {
+	psql.Repository
+	product *views.Product
+}
+
+func (r productRepo) GetProductById(context.Context, string) (*views.Product, error) {
+	return r.product, nil
+}
+
+func TestRestrictProductUpdate(t *testing.T) {
+	current := &views.Product{Id: "p1", Title: "old", Article: "12345678", Price: 100, Version: 3}
+	s := &ServerAPI{API: productRepo{product: current}}
+	update := func(title string, price int) *views.ProductId {
+		return &views.ProductId{Id: "p1", Title: title, Article: "12345678", Price: price, Version: 3}
+	}
+
+	for _, tt := range []struct {
+		name  string
+		role  string
+		p     *views.ProductId
+		want  codes.Code
+		title string
+		price int
+	}{
+		{"owner change all", "owner", update("new", 200), codes.OK, "new", 200},
+		{"content change title", "content", update("new", 100), codes.OK, "new", 100},
+		{"content change price", "content", update("new", 200), codes.PermissionDenied, "", 0},
+		{"pricing change price", "pricing", update("old", 200), codes.OK, "old", 200},
+		{"pricing change title", "pricing", update("new", 200), codes.PermissionDenied, "", 0},
+	} {
+		p, err := s.restrictProductUpdate(withActor(tt.role, ""), tt.p)
+		if status.Code(err) != tt.want {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if err == nil && (p.Title != tt.title || p.Price != tt.price) {
+			t.Errorf("%s: got %q %d", tt.name, p.Title, p.Price)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"productService/internal/pkg/certs"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	serverCreds, err := certs.Server(certs.Config{Enabled: true, Dev: true, DevDir: dir}, "product-service")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l := bufconn.Listen(1 << 20)
+	s := grpc.NewServer(grpc.Creds(serverCreds))
+	healthpb.RegisterHealthServer(s, health.NewServer())
+	go func() { _ = s.Serve(l) }()
+	t.Cleanup(s.Stop)
+
+	check := func(creds credentials.TransportCredentials) error {
+		cc, err := grpc.NewClient("passthrough:///bufnet",
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return l.DialContext(ctx) }),
+			grpc.WithTransportCredentials(creds),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer cc.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		_, err = healthpb.NewHealthClient(cc).Check(ctx, &healthpb.HealthCheckRequest{})
+		return err
+	}
+
+	gateway, err := certs.Client(certs.Config{Enabled: true, Dev: true, DevDir: dir, ServerName: "product-service"}, "gateway")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := check(gateway); err != nil {
+		t.Fatalf("client with certificate: %v", err)
+	}
+
+	dev, err := certs.Dev(certs.Config{DevDir: dir}, "gateway")
+	if err != nil {
+		t.Fatal(err)
+	}
+	anonymous, err := certs.Client(certs.Config{Enabled: true, CAFile: dev.CAFile, ServerName: "product-service"}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := check(anonymous); err == nil {
+		t.Fatal("client without certificate is accepted")
+	}
+}
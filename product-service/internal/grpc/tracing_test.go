@@ -0,0 +1,63 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"productService/internal/pkg/tracing"
+	"testing"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestTracePropagation(t *testing.T) {
+	exp := tracetest.NewInMemoryExporter()
+	tp := tracing.NewProvider("test", 1, sdktrace.WithSyncer(exp))
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	l := bufconn.Listen(1 << 20)
+	s := grpc.NewServer(serverOptions(actorTrust{})...)
+	healthpb.RegisterHealthServer(s, health.NewServer())
+	go func() { _ = s.Serve(l) }()
+	t.Cleanup(s.Stop)
+
+	cc, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return l.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = cc.Close() })
+
+	ctx, root := tp.Tracer("test").Start(context.Background(), "http request")
+	if _, err := healthpb.NewHealthClient(cc).Check(ctx, &healthpb.HealthCheckRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	root.End()
+
+	var server *tracetest.SpanStub
+	spans := exp.GetSpans()
+	for i := range spans {
+		if spans[i].SpanKind == trace.SpanKindServer {
+			server = &spans[i]
+		}
+	}
+	if server == nil {
+		t.Fatalf("no server span in %d spans", len(spans))
+	}
+	if server.SpanContext.TraceID() != root.SpanContext().TraceID() {
+		t.Fatal("server span is not in trace of client")
+	}
+}
@@ -0,0 +1,113 @@
+package grpc
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"slices"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Metadata keys with signature of acting admin, gateway set them when actor_secret is configured
+const (
+	ActorTimeKey = "x-actor-ts"
+	ActorSigKey  = "x-actor-sig"
+)
+
+// actorMaxAge is how old signature of actor may be, it limit replay of sniffed call
+const actorMaxAge = time.Minute
+
+// actorTrust tell who may send acting admin. Peers are common names of client certificates checked
+// by mTLS, secret is key of HMAC signature of actor metadata
+type actorTrust struct {
+	peers  []string
+	secret []byte
+}
+
+func newActorTrust(peers []string, secret string) actorTrust {
+	t := actorTrust{peers: peers}
+	if secret != "" {
+		t.secret = []byte(secret)
+	}
+	return t
+}
+
+// verifyActor is unary interceptor that reject call with acting admin from untrusted client. Anyone
+// who reach port could set x-actor-* metadata, so it is accepted only from gateway: peer with
+// client certificate of one of peers names, or with valid signature. Call without actor go further
+// and only public methods are allowed to it by authorize
+func (t actorTrust) verifyActor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	const op = "grpc.verifyActor"
+
+	if !hasActor(ctx) || t.trustedPeer(ctx) {
+		return handler(ctx, req)
+	}
+	if err := t.checkSignature(ctx, info.FullMethod); err != nil {
+		slog.ErrorContext(ctx, op, "err", err)
+		return nil, status.Error(codes.Unauthenticated, "actor metadata from untrusted client")
+	}
+	return handler(ctx, req)
+}
+
+func hasActor(ctx context.Context) bool {
+	for _, key := range []string{ActorLoginKey, ActorRoleKey, ActorPermsKey} {
+		if incoming(ctx, key) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// trustedPeer check common name of client certificate verified by mTLS
+func (t actorTrust) trustedPeer(ctx context.Context) bool {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return false
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return false
+	}
+	return slices.Contains(t.peers, info.State.VerifiedChains[0][0].Subject.CommonName)
+}
+
+func (t actorTrust) checkSignature(ctx context.Context, method string) error {
+	if t.secret == nil {
+		return status.Error(codes.Unauthenticated, "client certificate is not trusted and actor_secret is not set")
+	}
+
+	ts := incoming(ctx, ActorTimeKey)
+	sec, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return status.Error(codes.Unauthenticated, "bad actor time")
+	}
+	if age := time.Since(time.Unix(sec, 0)); age > actorMaxAge || age < -actorMaxAge {
+		return status.Errorf(codes.Unauthenticated, "actor signature is %s old", age)
+	}
+
+	sig, err := hex.DecodeString(incoming(ctx, ActorSigKey))
+	if err != nil || !hmac.Equal(sig, signActor(t.secret, method, incoming(ctx, ActorLoginKey),
+		incoming(ctx, ActorRoleKey), incoming(ctx, ActorPermsKey), ts)) {
+		return status.Error(codes.Unauthenticated, "bad actor signature")
+	}
+	return nil
+}
+
+// signActor is HMAC of method and actor fields, same as in gateway/internal/grpc/products/actor.go
+func signActor(secret []byte, method, login, role, perms, ts string) []byte {
+	m := hmac.New(sha256.New, secret)
+	for _, s := range []string{method, login, role, perms, ts} {
+		m.Write([]byte(s))
+		m.Write([]byte{0})
+	}
+	return m.Sum(nil)
+}
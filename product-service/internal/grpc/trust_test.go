@@ -0,0 +1,66 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"strconv"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestVerifyActor(t *testing.T) {
+	const method = "/products.Products/DeleteProduct"
+	trust := newActorTrust([]string{"gateway"}, "secret")
+	info := &grpc.UnaryServerInfo{FullMethod: method}
+	ok := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+
+	fromPeer := func(cn string) context.Context {
+		state := tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: cn}}}}}
+		return peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{State: state}})
+	}
+	actor := func(ctx context.Context, kv ...string) context.Context {
+		return metadata.NewIncomingContext(ctx, metadata.Pairs(append([]string{ActorLoginKey, "root", ActorRoleKey, "owner"}, kv...)...))
+	}
+	signed := func(secret, method string, at time.Time) []string {
+		ts := strconv.FormatInt(at.Unix(), 10)
+		sig := signActor([]byte(secret), method, "root", "owner", "", ts)
+		return []string{ActorTimeKey, ts, ActorSigKey, hex.EncodeToString(sig)}
+	}
+
+	for _, tt := range []struct {
+		name string
+		ctx  context.Context
+		want codes.Code
+	}{
+		{"no actor", context.Background(), codes.OK},
+		{"gateway certificate", actor(fromPeer("gateway")), codes.OK},
+		{"other certificate", actor(fromPeer("dumper")), codes.Unauthenticated},
+		{"plain client", actor(context.Background()), codes.Unauthenticated},
+		{"signed", actor(context.Background(), signed("secret", method, time.Now())...), codes.OK},
+		{"wrong secret", actor(context.Background(), signed("other", method, time.Now())...), codes.Unauthenticated},
+		{"signed for other method", actor(context.Background(), signed("secret", "/products.Products/GetProduct", time.Now())...), codes.Unauthenticated},
+		{"old signature", actor(context.Background(), signed("secret", method, time.Now().Add(-2*actorMaxAge))...), codes.Unauthenticated},
+	} {
+		_, err := trust.verifyActor(tt.ctx, nil, info, ok)
+		if status.Code(err) != tt.want {
+			t.Errorf("%s: %v", tt.name, err)
+		}
+	}
+
+	// without secret only certificate is trusted
+	noSecret := newActorTrust([]string{"gateway"}, "")
+	ctx := actor(context.Background(), signed("", method, time.Now())...)
+	if _, err := noSecret.verifyActor(ctx, nil, info, ok); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("signature with empty secret is accepted: %v", err)
+	}
+}
@@ -0,0 +1,39 @@
+package grpc
+
+import (
+	"context"
+	"productService/internal/pkg/psql"
+	"productService/internal/pkg/validate"
+	"productService/internal/views"
+)
+
+// validateProduct check product before create or update, also against database
+func (s *ServerAPI) validateProduct(ctx context.Context, p *views.ProductId) error {
+	v := validate.Product(p)
+	if err := s.API.CheckProduct(ctx, p, v); err != nil {
+		return err
+	}
+	return v.Err()
+}
+
+func (s *ServerAPI) validateColorPhotos(ctx context.Context, p *views.ProductColorPhotos) error {
+	v := validate.ProductColorPhotos(p)
+	if err := s.API.CheckRefs(ctx, v, psql.RefProducts, "product_id", p.ProductId); err != nil {
+		return err
+	}
+	if err := s.API.CheckRefs(ctx, v, psql.RefColors, "color_id", p.ColorId); err != nil {
+		return err
+	}
+	return v.Err()
+}
+
+// validatePhoto check references of photo. Update does not send product, then only colour is looked up
+func (s *ServerAPI) validatePhoto(ctx context.Context, p *views.ProductPhoto, v *validate.Errors) error {
+	if err := s.API.CheckRefs(ctx, v, psql.RefProducts, "product_id", p.ProductId); err != nil {
+		return err
+	}
+	if err := s.API.CheckRefs(ctx, v, psql.RefColors, "color_id", p.ColorId); err != nil {
+		return err
+	}
+	return v.Err()
+}
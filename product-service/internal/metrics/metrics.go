@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"productService/internal/utils/format"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Server serve /metrics of default registry: gRPC server metrics, DB pool stats and go runtime
+type Server struct {
+	srv *http.Server
+}
+
+// New construct metrics server. Stats of db pool are registered here too
+func New(port int, db *sql.DB) *Server {
+	prometheus.MustRegister(collectors.NewDBStatsCollector(db, "products"))
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	return &Server{
+		srv: &http.Server{
+			Addr:              fmt.Sprintf(":%d", port),
+			Handler:           mux,
+			ReadHeaderTimeout: 5 * time.Second,
+		},
+	}
+}
+
+// MustRun running metrics server and panic if error
+func (s *Server) MustRun() {
+	if err := s.Run(); err != nil {
+		panic(err)
+	}
+}
+
+func (s *Server) Run() error {
+	const op = "metrics.Run"
+
+	slog.Info("metrics server is running", "op", op, "addr", s.srv.Addr)
+	if err := s.srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return format.Error(op, err)
+	}
+	return nil
+}
+
+func (s *Server) Stop(ctx context.Context) error {
+	const op = "metrics.Stop"
+	return format.Error(op, s.srv.Shutdown(ctx))
+}
@@ -0,0 +1,209 @@
+package certs
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Config of TLS between gateway and product-service. Server with CAFile require client certificate
+// signed by it (mTLS), client send its certificate when CertFile is set
+type Config struct {
+	Enabled  bool   `mapstructure:"enabled"`
+	CertFile string `mapstructure:"cert_file"`
+	KeyFile  string `mapstructure:"key_file"`
+	// CAFile is CA of other side: of client certificates on server, of server certificate on client
+	CAFile string `mapstructure:"ca_file"`
+	// ServerName is name in certificate of server, only for client. Default is host of address
+	ServerName string `mapstructure:"server_name"`
+	// Dev generate CA and certificates in DevDir when files are missing. Services with same DevDir
+	// trust each other, it is for local runs only
+	Dev    bool   `mapstructure:"dev"`
+	DevDir string `mapstructure:"dev_dir"`
+}
+
+// Validate check that files are set. server is true for config of gRPC server
+func (c Config) Validate(server bool) error {
+	if !c.Enabled || c.Dev {
+		return nil
+	}
+	if (c.CertFile == "") != (c.KeyFile == "") {
+		return errors.New("cert_file and key_file must be set together")
+	}
+	if server && c.CertFile == "" {
+		return errors.New("cert_file and key_file are required for server")
+	}
+	return nil
+}
+
+// Server return credentials of gRPC server, insecure ones when TLS is disabled. name is name of
+// service in dev certificate
+func Server(cfg Config, name string) (credentials.TransportCredentials, error) {
+	return newCreds(cfg, name, func(cert *tls.Certificate, pool *x509.CertPool) *tls.Config {
+		c := &tls.Config{MinVersion: tls.VersionTLS12, Certificates: []tls.Certificate{*cert}}
+		if pool != nil {
+			c.ClientCAs = pool
+			c.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+		return c
+	})
+}
+
+// Client return credentials of gRPC client, insecure ones when TLS is disabled. Without CAFile
+// certificate of server is checked by system roots
+func Client(cfg Config, name string) (credentials.TransportCredentials, error) {
+	return newCreds(cfg, name, func(cert *tls.Certificate, pool *x509.CertPool) *tls.Config {
+		c := &tls.Config{MinVersion: tls.VersionTLS12, RootCAs: pool, ServerName: cfg.ServerName}
+		if cert != nil {
+			c.Certificates = []tls.Certificate{*cert}
+		}
+		return c
+	})
+}
+
+func newCreds(cfg Config, name string, config func(*tls.Certificate, *x509.CertPool) *tls.Config) (credentials.TransportCredentials, error) {
+	const op = "certs.newCreds"
+	if !cfg.Enabled {
+		return insecure.NewCredentials(), nil
+	}
+	if cfg.Dev {
+		var err error
+		if cfg, err = Dev(cfg, name); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	f, err := load(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &creds{f: f, config: config}, nil
+}
+
+// reloadInterval is how often files are checked for change. New files are used by next handshake
+var reloadInterval = 10 * time.Second
+
+// files is certificate, key and CA which are read again when one of them is changed
+type files struct {
+	cfg Config
+
+	mu      sync.Mutex
+	checked time.Time
+	mod     time.Time
+	cert    *tls.Certificate
+	pool    *x509.CertPool
+}
+
+func load(cfg Config) (*files, error) {
+	f := &files{cfg: cfg, checked: time.Now()}
+	if err := f.read(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *files) read() error {
+	mod := f.modTime()
+	var cert *tls.Certificate
+	if f.cfg.CertFile != "" {
+		c, err := tls.LoadX509KeyPair(f.cfg.CertFile, f.cfg.KeyFile)
+		if err != nil {
+			return err
+		}
+		cert = &c
+	}
+	var pool *x509.CertPool
+	if f.cfg.CAFile != "" {
+		pem, err := os.ReadFile(f.cfg.CAFile)
+		if err != nil {
+			return err
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificates in %s", f.cfg.CAFile)
+		}
+	}
+	f.mod, f.cert, f.pool = mod, cert, pool
+	return nil
+}
+
+// modTime is time of latest change of files
+func (f *files) modTime() time.Time {
+	var mod time.Time
+	for _, name := range []string{f.cfg.CertFile, f.cfg.KeyFile, f.cfg.CAFile} {
+		if name == "" {
+			continue
+		}
+		if st, err := os.Stat(name); err == nil && st.ModTime().After(mod) {
+			mod = st.ModTime()
+		}
+	}
+	return mod
+}
+
+// current return certificate and CA, files are read again when they are changed. Bad new files
+// are logged and old ones are kept
+func (f *files) current() (*tls.Certificate, *x509.CertPool) {
+	const op = "certs.current"
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if time.Since(f.checked) >= reloadInterval {
+		f.checked = time.Now()
+		if f.modTime().After(f.mod) {
+			if err := f.read(); err != nil {
+				slog.Error("certificates are not reloaded", "op", op, "err", err)
+			} else {
+				slog.Info("certificates reloaded", "op", op, "cert", f.cfg.CertFile)
+			}
+		}
+	}
+	return f.cert, f.pool
+}
+
+// creds make TLS credentials of current files on every handshake, so reload of certificate does not
+// need restart. Connections which are open keep old certificate
+type creds struct {
+	f          *files
+	config     func(*tls.Certificate, *x509.CertPool) *tls.Config
+	serverName string
+}
+
+func (c *creds) tls() credentials.TransportCredentials {
+	cfg := c.config(c.f.current())
+	if c.serverName != "" {
+		cfg.ServerName = c.serverName
+	}
+	return credentials.NewTLS(cfg)
+}
+
+func (c *creds) ClientHandshake(ctx context.Context, authority string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return c.tls().ClientHandshake(ctx, authority, conn)
+}
+
+func (c *creds) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return c.tls().ServerHandshake(conn)
+}
+
+func (c *creds) Info() credentials.ProtocolInfo {
+	return c.tls().Info()
+}
+
+func (c *creds) Clone() credentials.TransportCredentials {
+	cp := *c
+	return &cp
+}
+
+func (c *creds) OverrideServerName(name string) error {
+	c.serverName = name
+	return nil
+}
@@ -0,0 +1,153 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// devValidity is lifetime of dev certificates, they are made again after it
+const devValidity = 365 * 24 * time.Hour
+
+// Dev fill files of cfg with dev CA and certificate of service name in cfg.DevDir, they are created
+// when missing. Certificate is for server and client and valid for name, localhost and 127.0.0.1
+func Dev(cfg Config, name string) (Config, error) {
+	const op = "certs.Dev"
+
+	dir := cfg.DevDir
+	if dir == "" {
+		dir = "./certs"
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return cfg, fmt.Errorf("%s: %w", op, err)
+	}
+	ca, caKey, err := devCA(dir)
+	if err != nil {
+		return cfg, fmt.Errorf("%s: %w", op, err)
+	}
+
+	cfg.CAFile = filepath.Join(dir, "ca.pem")
+	cfg.CertFile = filepath.Join(dir, name+".pem")
+	cfg.KeyFile = filepath.Join(dir, name+"-key.pem")
+	if c, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile); err == nil && c.Leaf != nil &&
+		time.Now().Before(c.Leaf.NotAfter) && c.Leaf.CheckSignatureFrom(ca) == nil {
+		return cfg, nil
+	}
+
+	tmpl := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: name},
+		DNSNames:    []string{name, "localhost"},
+		IPAddresses: []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+	}
+	if err := writeCert(cfg.CertFile, cfg.KeyFile, tmpl, ca, caKey); err != nil {
+		return cfg, fmt.Errorf("%s: %w", op, err)
+	}
+	slog.Warn("dev certificate is created, do not use it in production", "op", op, "cert", cfg.CertFile)
+	return cfg, nil
+}
+
+// devCA load CA of dir or create it. Services often start together, so only one of them create CA
+// and others wait for it
+func devCA(dir string) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	certFile, keyFile, lock := filepath.Join(dir, "ca.pem"), filepath.Join(dir, "ca-key.pem"), filepath.Join(dir, "ca.lock")
+
+	for deadline := time.Now().Add(10 * time.Second); ; {
+		if ca, key, err := loadCA(certFile, keyFile); err == nil {
+			return ca, key, nil
+		}
+		l, err := os.OpenFile(lock, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			_ = l.Close()
+			break
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return nil, nil, err
+		}
+		if time.Now().After(deadline) {
+			return nil, nil, fmt.Errorf("CA is not created by other service, remove %s if it is left", lock)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	defer os.Remove(lock)
+	// other service could create CA and remove its lock just before we took it
+	if ca, key, err := loadCA(certFile, keyFile); err == nil {
+		return ca, key, nil
+	}
+
+	tmpl := &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "volha dev CA"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+	}
+	if err := writeCert(certFile, keyFile, tmpl, nil, nil); err != nil {
+		return nil, nil, err
+	}
+	return loadCA(certFile, keyFile)
+}
+
+func loadCA(certFile, keyFile string) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	c, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, ok := c.PrivateKey.(*ecdsa.PrivateKey)
+	if !ok || c.Leaf == nil || !c.Leaf.IsCA || time.Now().After(c.Leaf.NotAfter) {
+		return nil, nil, errors.New("bad dev CA")
+	}
+	return c.Leaf, key, nil
+}
+
+// writeCert create key and certificate by tmpl signed by parent, self-signed when parent is nil.
+// Files are renamed in place after write, so other service never read half of them
+func writeCert(certFile, keyFile string, tmpl, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) error {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 127))
+	if err != nil {
+		return err
+	}
+	tmpl.SerialNumber = serial
+	tmpl.NotBefore = time.Now().Add(-time.Hour)
+	tmpl.NotAfter = time.Now().Add(devValidity)
+	if parent == nil {
+		parent, parentKey = tmpl, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		return err
+	}
+	keyDer, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return err
+	}
+	if err := writePEM(keyFile, "PRIVATE KEY", keyDer); err != nil {
+		return err
+	}
+	return writePEM(certFile, "CERTIFICATE", der)
+}
+
+func writePEM(name, typ string, der []byte) error {
+	tmp := name + ".tmp"
+	if err := os.WriteFile(tmp, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, name)
+}
@@ -0,0 +1,88 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Level of default logger. It can be changed while service is running
+var Level = new(slog.LevelVar)
+
+type ctxKey struct{}
+
+// Setup make slog logger default one: text in DEV mode, JSON otherwise. Empty level is debug in
+// DEV mode and info in others. Output of std log goes to this logger too
+func Setup(mode, level string) error {
+	lvl, err := ParseLevel(level, mode)
+	if err != nil {
+		return err
+	}
+	Level.Set(lvl)
+
+	opts := &slog.HandlerOptions{Level: Level}
+	var h slog.Handler = slog.NewJSONHandler(os.Stdout, opts)
+	if mode == "DEV" {
+		h = slog.NewTextHandler(os.Stdout, opts)
+	}
+	slog.SetDefault(slog.New(handler{h}))
+	return nil
+}
+
+// ParseLevel parse one of debug, info, warn, error
+func ParseLevel(level, mode string) (slog.Level, error) {
+	if level == "" {
+		if mode == "DEV" {
+			return slog.LevelDebug, nil
+		}
+		return slog.LevelInfo, nil
+	}
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(strings.ToUpper(level))); err != nil {
+		return 0, fmt.Errorf("bad log level %q: %w", level, err)
+	}
+	return lvl, nil
+}
+
+// With return ctx which add args to every record logged with it, like request id or admin login
+func With(ctx context.Context, args ...any) context.Context {
+	attrs, _ := ctx.Value(ctxKey{}).([]slog.Attr)
+	r := slog.Record{}
+	r.Add(args...)
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+	return context.WithValue(ctx, ctxKey{}, attrs[:len(attrs):len(attrs)])
+}
+
+// Attrs return args added to ctx by With
+func Attrs(ctx context.Context) []slog.Attr {
+	attrs, _ := ctx.Value(ctxKey{}).([]slog.Attr)
+	return attrs
+}
+
+// handler add attrs of context and id of trace to record, so logs can be found by trace
+type handler struct {
+	slog.Handler
+}
+
+func (h handler) Handle(ctx context.Context, r slog.Record) error {
+	r.AddAttrs(Attrs(ctx)...)
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		r.AddAttrs(slog.String("trace_id", sc.TraceID().String()))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return handler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h handler) WithGroup(name string) slog.Handler {
+	return handler{h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,99 @@
+package psql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"productService/internal/utils/format"
+	"productService/internal/views"
+)
+
+func (d Driver) GetAdmin(ctx context.Context, login string) (*views.AdminUser, error) {
+	const op = "PostgresDb.GetAdmin"
+
+	var u views.AdminUser
+	err := d.Driver.QueryRowContext(ctx, `
+		SELECT login, role, password_hash, EXTRACT(EPOCH FROM created_at)::BIGINT
+		FROM admin_users
+		WHERE login = $1
+	`, login).Scan(&u.Login, &u.Role, &u.PasswordHash, &u.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, format.Error(op, fmt.Errorf("admin %s: %w", login, ErrNotFound))
+	}
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	return &u, nil
+}
+
+// GetAllAdmins return admins sorted by login without password hashes
+func (d Driver) GetAllAdmins(ctx context.Context) ([]views.AdminUser, error) {
+	const op = "PostgresDb.GetAllAdmins"
+
+	rows, err := d.Driver.QueryContext(ctx, `
+		SELECT login, role, EXTRACT(EPOCH FROM created_at)::BIGINT
+		FROM admin_users
+		ORDER BY login
+	`)
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	defer rows.Close()
+
+	var out []views.AdminUser
+	for rows.Next() {
+		var u views.AdminUser
+		if err := rows.Scan(&u.Login, &u.Role, &u.CreatedAt); err != nil {
+			return nil, format.Error(op, err)
+		}
+		out = append(out, u)
+	}
+	return out, format.Error(op, rows.Err())
+}
+
+// CreateAdmin insert admin, busy login is unique violation. Zero CreatedAt mean now
+func (d Driver) CreateAdmin(ctx context.Context, u *views.AdminUser) error {
+	const op = "PostgresDb.CreateAdmin"
+
+	_, err := d.Driver.ExecContext(ctx, `
+		INSERT INTO admin_users (login, role, password_hash, created_at)
+		VALUES ($1, $2, $3, COALESCE(to_timestamp(NULLIF($4::BIGINT, 0)), now()))
+	`, u.Login, u.Role, u.PasswordHash, u.CreatedAt)
+	return format.Error(op, err)
+}
+
+// UpdateAdmin change role and password hash. Empty hash keep old one
+func (d Driver) UpdateAdmin(ctx context.Context, u *views.AdminUser) error {
+	const op = "PostgresDb.UpdateAdmin"
+
+	res, err := d.Driver.ExecContext(ctx, `
+		UPDATE admin_users
+		SET role = $2, password_hash = COALESCE(NULLIF($3, ''), password_hash)
+		WHERE login = $1
+	`, u.Login, u.Role, u.PasswordHash)
+	if err != nil {
+		return format.Error(op, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return format.Error(op, err)
+	} else if n == 0 {
+		return format.Error(op, fmt.Errorf("admin %s: %w", u.Login, ErrNotFound))
+	}
+	return nil
+}
+
+func (d Driver) DeleteAdmin(ctx context.Context, login string) error {
+	const op = "PostgresDb.DeleteAdmin"
+
+	res, err := d.Driver.ExecContext(ctx, `DELETE FROM admin_users WHERE login = $1`, login)
+	if err != nil {
+		return format.Error(op, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return format.Error(op, err)
+	} else if n == 0 {
+		return format.Error(op, fmt.Errorf("admin %s: %w", login, ErrNotFound))
+	}
+	return nil
+}
@@ -1,17 +1,18 @@
 package psql
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"productService/internal/utils/format"
 	"productService/internal/views"
 )
 
-func (d Driver) GetAllBrands() ([]views.Brand, error) {
+func (d Driver) GetAllBrands(ctx context.Context) ([]views.Brand, error) {
 	const op = "PostgresDb.GetAllBrands"
 
 	var list []views.Brand
-	rows, err := d.Driver.Query(`SELECT id, name FROM brands`)
+	rows, err := d.Driver.QueryContext(ctx, `SELECT id, name, version FROM brands`)
 	if err != nil {
 		return nil, format.Error(op, err)
 	}
@@ -19,8 +20,8 @@ func (d Driver) GetAllBrands() ([]views.Brand, error) {
 
 	for rows.Next() {
 		var b views.Brand
-		if err := rows.Scan(&b.Id, &b.Name); err != nil {
-			log.Println(format.Error(op, err))
+		if err := rows.Scan(&b.Id, &b.Name, &b.Version); err != nil {
+			slog.ErrorContext(ctx, op, "err", err)
 			continue
 		}
 		list = append(list, b)
@@ -29,11 +30,11 @@ func (d Driver) GetAllBrands() ([]views.Brand, error) {
 	return list, nil
 }
 
-func (d Driver) CreateBrand(b *views.Brand) error {
+func (d Driver) CreateBrand(ctx context.Context, b *views.Brand) error {
 	const op = "PostgresDb.CreateBrand"
 
 	query := `INSERT INTO brands (id, name) VALUES ($1, $2)`
-	_, err := d.Driver.Exec(query, b.Id, b.Name)
+	_, err := d.Driver.ExecContext(ctx, query, b.Id, b.Name)
 	if err != nil {
 		return format.Error(op, err)
 	}
@@ -41,11 +42,11 @@ func (d Driver) CreateBrand(b *views.Brand) error {
 	return nil
 }
 
-func (d Driver) UpdateBrand(b *views.Brand, id string) error {
+func (d Driver) UpdateBrand(ctx context.Context, b *views.Brand, id string) error {
 	const op = "PostgresDb.UpdateBrand"
 
-	query := `UPDATE brands SET name = $2 WHERE id = $1`
-	result, err := d.Driver.Exec(query, id, b.Name)
+	query := `UPDATE brands SET name = $2, version = version + 1 WHERE id = $1 AND version = $3`
+	result, err := d.Driver.ExecContext(ctx, query, id, b.Name, b.Version)
 	if err != nil {
 		return format.Error(op, err)
 	}
@@ -55,17 +56,17 @@ func (d Driver) UpdateBrand(b *views.Brand, id string) error {
 		return format.Error(op, err)
 	}
 	if rowsAffected == 0 {
-		return format.Error(op, fmt.Errorf("brand with id %s not found", id))
+		return format.Error(op, checkVersion(ctx, d.Driver, "brands", id, fmt.Errorf("brand with id %s: %w", id, ErrNotFound)))
 	}
 
 	return nil
 }
 
-func (d Driver) DeleteBrand(id string) error {
+func (d Driver) DeleteBrand(ctx context.Context, id string) error {
 	const op = "PostgresDb.DeleteBrand"
 
 	query := `DELETE FROM brands WHERE id = $1`
-	_, err := d.Driver.Exec(query, id)
+	_, err := d.Driver.ExecContext(ctx, query, id)
 	if err != nil {
 		return format.Error(op, err)
 	}
@@ -1,17 +1,18 @@
 package psql
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"productService/internal/utils/format"
 	"productService/internal/views"
 )
 
-func (d Driver) GetAllCategories() ([]views.Category, error) {
+func (d Driver) GetAllCategories(ctx context.Context) ([]views.Category, error) {
 	const op = "PostgresDb.GetAllCategories"
 	var list []views.Category
 
-	rows, err := d.Driver.Query(`SELECT id, title, uri, img FROM categories`)
+	rows, err := d.Driver.QueryContext(ctx, `SELECT id, title, uri, img, version FROM categories`)
 	if err != nil {
 		return nil, format.Error(op, err)
 	}
@@ -19,8 +20,8 @@ func (d Driver) GetAllCategories() ([]views.Category, error) {
 
 	for rows.Next() {
 		var c views.Category
-		if err := rows.Scan(&c.Id, &c.Title, &c.Uri, &c.Img); err != nil {
-			log.Println(format.Error(op, err))
+		if err := rows.Scan(&c.Id, &c.Title, &c.Uri, &c.Img, &c.Version); err != nil {
+			slog.ErrorContext(ctx, op, "err", err)
 			continue
 		}
 		list = append(list, c)
@@ -28,31 +29,34 @@ func (d Driver) GetAllCategories() ([]views.Category, error) {
 	return list, nil
 }
 
-func (d Driver) CreateCategory(c *views.Category) error {
+func (d Driver) CreateCategory(ctx context.Context, c *views.Category) error {
 	const op = "PostgresDb.CreateCategory"
 	query := `INSERT INTO categories (id, title, uri, img) VALUES ($1, $2, $3, $4)`
-	_, err := d.Driver.Exec(query, c.Id, c.Title, c.Uri, c.Img)
+	_, err := d.Driver.ExecContext(ctx, query, c.Id, c.Title, c.Uri, c.Img)
 	return format.Error(op, err)
 }
 
-func (d Driver) UpdateCategory(c *views.Category, id string) error {
+func (d Driver) UpdateCategory(ctx context.Context, c *views.Category, id string) error {
 	const op = "PostgresDb.UpdateCategory"
-	query := `UPDATE categories SET title = $2, uri = $3, img = $4 WHERE id = $1`
+	query := `UPDATE categories SET title = $2, uri = $3, img = $4, version = version + 1 WHERE id = $1 AND version = $5`
 
-	result, err := d.Driver.Exec(query, id, c.Title, c.Uri, c.Img)
+	result, err := d.Driver.ExecContext(ctx, query, id, c.Title, c.Uri, c.Img, c.Version)
 	if err != nil {
 		return format.Error(op, err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
-	if err != nil || rowsAffected == 0 {
-		return format.Error(op, fmt.Errorf("category with id %s not found", id))
+	if err != nil {
+		return format.Error(op, err)
+	}
+	if rowsAffected == 0 {
+		return format.Error(op, checkVersion(ctx, d.Driver, "categories", id, fmt.Errorf("category with id %s: %w", id, ErrNotFound)))
 	}
 	return nil
 }
 
-func (d Driver) DeleteCategory(id string) error {
+func (d Driver) DeleteCategory(ctx context.Context, id string) error {
 	const op = "PostgresDb.DeleteCategory"
-	_, err := d.Driver.Exec(`DELETE FROM categories WHERE id = $1`, id)
+	_, err := d.Driver.ExecContext(ctx, `DELETE FROM categories WHERE id = $1`, id)
 	return format.Error(op, err)
 }
@@ -1,17 +1,18 @@
 package psql
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"productService/internal/utils/format"
 	"productService/internal/views"
 )
 
-func (d Driver) GetAllColors() ([]views.Color, error) {
+func (d Driver) GetAllColors(ctx context.Context) ([]views.Color, error) {
 	const op = "PostgresDb.GetAllColors"
 	var list []views.Color
 
-	rows, err := d.Driver.Query(`SELECT id, name, hex FROM colors`)
+	rows, err := d.Driver.QueryContext(ctx, `SELECT id, name, hex, version FROM colors`)
 	if err != nil {
 		return nil, format.Error(op, err)
 	}
@@ -19,8 +20,8 @@ func (d Driver) GetAllColors() ([]views.Color, error) {
 
 	for rows.Next() {
 		var c views.Color
-		if err := rows.Scan(&c.Id, &c.Name, &c.Hex); err != nil {
-			log.Println(format.Error(op, err))
+		if err := rows.Scan(&c.Id, &c.Name, &c.Hex, &c.Version); err != nil {
+			slog.ErrorContext(ctx, op, "err", err)
 			continue
 		}
 		list = append(list, c)
@@ -28,31 +29,34 @@ func (d Driver) GetAllColors() ([]views.Color, error) {
 	return list, nil
 }
 
-func (d Driver) CreateColor(c *views.Color) error {
+func (d Driver) CreateColor(ctx context.Context, c *views.Color) error {
 	const op = "PostgresDb.CreateColor"
 	query := `INSERT INTO colors (id, name, hex) VALUES ($1, $2, $3)`
-	_, err := d.Driver.Exec(query, c.Id, c.Name, c.Hex)
+	_, err := d.Driver.ExecContext(ctx, query, c.Id, c.Name, c.Hex)
 	return format.Error(op, err)
 }
 
-func (d Driver) UpdateColor(c *views.Color, id string) error {
+func (d Driver) UpdateColor(ctx context.Context, c *views.Color, id string) error {
 	const op = "PostgresDb.UpdateColor"
-	query := `UPDATE colors SET name = $2, hex = $3 WHERE id = $1`
+	query := `UPDATE colors SET name = $2, hex = $3, version = version + 1 WHERE id = $1 AND version = $4`
 
-	result, err := d.Driver.Exec(query, id, c.Name, c.Hex)
+	result, err := d.Driver.ExecContext(ctx, query, id, c.Name, c.Hex, c.Version)
 	if err != nil {
 		return format.Error(op, err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
-	if err != nil || rowsAffected == 0 {
-		return format.Error(op, fmt.Errorf("color with id %s not found", id))
+	if err != nil {
+		return format.Error(op, err)
+	}
+	if rowsAffected == 0 {
+		return format.Error(op, checkVersion(ctx, d.Driver, "colors", id, fmt.Errorf("color with id %s: %w", id, ErrNotFound)))
 	}
 	return nil
 }
 
-func (d Driver) DeleteColor(id string) error {
+func (d Driver) DeleteColor(ctx context.Context, id string) error {
 	const op = "PostgresDb.DeleteColor"
-	_, err := d.Driver.Exec(`DELETE FROM colors WHERE id = $1`, id)
+	_, err := d.Driver.ExecContext(ctx, `DELETE FROM colors WHERE id = $1`, id)
 	return format.Error(op, err)
 }
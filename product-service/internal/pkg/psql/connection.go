@@ -5,6 +5,7 @@ import (
 	"errors"
 	_ "github.com/lib/pq"
 	"log"
+	"log/slog"
 	"productService/config"
 	"productService/internal/utils/format"
 )
@@ -34,7 +35,7 @@ func NewConnect(cfg *config.Config) (*PostgresDb, error) {
 		return nil, format.Error(op, err)
 	}
 
-	log.Print("Connection to postgresSQL is established")
+	slog.Info("connection to postgres is established")
 	return &PostgresDb{Driver: db}, nil
 }
 
@@ -50,6 +51,6 @@ func (d *PostgresDb) Disconnect() error {
 		return format.Error(op, errors.New("failed to disconnect"))
 	}
 
-	log.Print("Connection to postgresSQL terminated")
+	slog.Info("connection to postgres terminated")
 	return nil
 }
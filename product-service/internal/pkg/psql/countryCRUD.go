@@ -1,17 +1,18 @@
 package psql
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"productService/internal/utils/format"
 	"productService/internal/views"
 )
 
-func (d Driver) GetAllCountries() ([]views.Country, error) {
+func (d Driver) GetAllCountries(ctx context.Context) ([]views.Country, error) {
 	const op = "PostgresDb.GetAllCountries"
 	var list []views.Country
 
-	rows, err := d.Driver.Query(`SELECT id, title, friendly FROM countries`)
+	rows, err := d.Driver.QueryContext(ctx, `SELECT id, title, friendly, version FROM countries`)
 	if err != nil {
 		return nil, format.Error(op, err)
 	}
@@ -19,8 +20,8 @@ func (d Driver) GetAllCountries() ([]views.Country, error) {
 
 	for rows.Next() {
 		var c views.Country
-		if err := rows.Scan(&c.Id, &c.Title, &c.Friendly); err != nil {
-			log.Println(format.Error(op, err))
+		if err := rows.Scan(&c.Id, &c.Title, &c.Friendly, &c.Version); err != nil {
+			slog.ErrorContext(ctx, op, "err", err)
 			continue
 		}
 		list = append(list, c)
@@ -28,31 +29,34 @@ func (d Driver) GetAllCountries() ([]views.Country, error) {
 	return list, nil
 }
 
-func (d Driver) CreateCountry(c *views.Country) error {
+func (d Driver) CreateCountry(ctx context.Context, c *views.Country) error {
 	const op = "PostgresDb.CreateCountry"
 	query := `INSERT INTO countries (id, title, friendly) VALUES ($1, $2, $3)`
-	_, err := d.Driver.Exec(query, c.Id, c.Title, c.Friendly)
+	_, err := d.Driver.ExecContext(ctx, query, c.Id, c.Title, c.Friendly)
 	return format.Error(op, err)
 }
 
-func (d Driver) UpdateCountry(c *views.Country, id string) error {
+func (d Driver) UpdateCountry(ctx context.Context, c *views.Country, id string) error {
 	const op = "PostgresDb.UpdateCountry"
-	query := `UPDATE countries SET title = $2, friendly = $3 WHERE id = $1`
+	query := `UPDATE countries SET title = $2, friendly = $3, version = version + 1 WHERE id = $1 AND version = $4`
 
-	result, err := d.Driver.Exec(query, id, c.Title, c.Friendly)
+	result, err := d.Driver.ExecContext(ctx, query, id, c.Title, c.Friendly, c.Version)
 	if err != nil {
 		return format.Error(op, err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
-	if err != nil || rowsAffected == 0 {
-		return format.Error(op, fmt.Errorf("country with id %s not found", id))
+	if err != nil {
+		return format.Error(op, err)
+	}
+	if rowsAffected == 0 {
+		return format.Error(op, checkVersion(ctx, d.Driver, "countries", id, fmt.Errorf("country with id %s: %w", id, ErrNotFound)))
 	}
 	return nil
 }
 
-func (d Driver) DeleteCountry(id string) error {
+func (d Driver) DeleteCountry(ctx context.Context, id string) error {
 	const op = "PostgresDb.DeleteCountry"
-	_, err := d.Driver.Exec(`DELETE FROM countries WHERE id = $1`, id)
+	_, err := d.Driver.ExecContext(ctx, `DELETE FROM countries WHERE id = $1`, id)
 	return format.Error(op, err)
 }
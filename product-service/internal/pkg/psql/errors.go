@@ -0,0 +1,10 @@
+package psql
+
+import "errors"
+
+var (
+	// ErrNotFound is returned when requested row does not exist
+	ErrNotFound = errors.New("not found")
+	// ErrInvalid is returned when request can not be executed as it is
+	ErrInvalid = errors.New("invalid argument")
+)
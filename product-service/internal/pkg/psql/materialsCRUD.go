@@ -1,17 +1,18 @@
 package psql
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"productService/internal/utils/format"
 	"productService/internal/views"
 )
 
-func (d Driver) GetAllMaterials() ([]views.Material, error) {
+func (d Driver) GetAllMaterials(ctx context.Context) ([]views.Material, error) {
 	const op = "PostgresDb.GetAllMaterials"
 	var list []views.Material
 
-	rows, err := d.Driver.Query(`SELECT id, title FROM materials`)
+	rows, err := d.Driver.QueryContext(ctx, `SELECT id, title, version FROM materials`)
 	if err != nil {
 		return nil, format.Error(op, err)
 	}
@@ -19,8 +20,8 @@ func (d Driver) GetAllMaterials() ([]views.Material, error) {
 
 	for rows.Next() {
 		var m views.Material
-		if err := rows.Scan(&m.Id, &m.Title); err != nil {
-			log.Println(format.Error(op, err))
+		if err := rows.Scan(&m.Id, &m.Title, &m.Version); err != nil {
+			slog.ErrorContext(ctx, op, "err", err)
 			continue
 		}
 		list = append(list, m)
@@ -28,31 +29,34 @@ func (d Driver) GetAllMaterials() ([]views.Material, error) {
 	return list, nil
 }
 
-func (d Driver) CreateMaterial(m *views.Material) error {
+func (d Driver) CreateMaterial(ctx context.Context, m *views.Material) error {
 	const op = "PostgresDb.CreateMaterial"
 	query := `INSERT INTO materials (id, title) VALUES ($1, $2)`
-	_, err := d.Driver.Exec(query, m.Id, m.Title)
+	_, err := d.Driver.ExecContext(ctx, query, m.Id, m.Title)
 	return format.Error(op, err)
 }
 
-func (d Driver) UpdateMaterial(m *views.Material, id string) error {
+func (d Driver) UpdateMaterial(ctx context.Context, m *views.Material, id string) error {
 	const op = "PostgresDb.UpdateMaterial"
-	query := `UPDATE materials SET title = $2 WHERE id = $1`
+	query := `UPDATE materials SET title = $2, version = version + 1 WHERE id = $1 AND version = $3`
 
-	result, err := d.Driver.Exec(query, id, m.Title)
+	result, err := d.Driver.ExecContext(ctx, query, id, m.Title, m.Version)
 	if err != nil {
 		return format.Error(op, err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
-	if err != nil || rowsAffected == 0 {
-		return format.Error(op, fmt.Errorf("material with id %s not found", id))
+	if err != nil {
+		return format.Error(op, err)
+	}
+	if rowsAffected == 0 {
+		return format.Error(op, checkVersion(ctx, d.Driver, "materials", id, fmt.Errorf("material with id %s: %w", id, ErrNotFound)))
 	}
 	return nil
 }
 
-func (d Driver) DeleteMaterial(id string) error {
+func (d Driver) DeleteMaterial(ctx context.Context, id string) error {
 	const op = "PostgresDb.DeleteMaterial"
-	_, err := d.Driver.Exec(`DELETE FROM materials WHERE id = $1`, id)
+	_, err := d.Driver.ExecContext(ctx, `DELETE FROM materials WHERE id = $1`, id)
 	return format.Error(op, err)
 }
@@ -1,6 +1,7 @@
 package psql
 
 import (
+	"context"
 	"github.com/stretchr/testify/assert"
 	"log"
 	"productService/config"
@@ -657,7 +658,8 @@ func TestCRUDProducts(t *testing.T) {
 	db, err := NewConnect(config.Test())
 	assert.NoError(t, err)
 
-	tx, err := db.Driver.Begin()
+	ctx := context.Background()
+	tx, err := db.Driver.BeginTx(ctx, nil)
 	assert.NoError(t, err)
 
 	//tx := db.Driver
@@ -676,28 +678,28 @@ func TestCRUDProducts(t *testing.T) {
 	material := &views.Material{Id: "mat1", Title: "Leather"}
 	color := &views.Color{Id: "color1", Name: "Red", Hex: "#FF0000"}
 
-	assert.NoError(t, driver.CreateBrand(brand))
-	assert.NoError(t, driver.CreateCategory(category))
-	assert.NoError(t, driver.CreateCountry(country))
-	assert.NoError(t, driver.CreateMaterial(material))
-	assert.NoError(t, driver.CreateColor(color))
-	cl, err := driver.GetAllColors()
+	assert.NoError(t, driver.CreateBrand(ctx, brand))
+	assert.NoError(t, driver.CreateCategory(ctx, category))
+	assert.NoError(t, driver.CreateCountry(ctx, country))
+	assert.NoError(t, driver.CreateMaterial(ctx, material))
+	assert.NoError(t, driver.CreateColor(ctx, color))
+	cl, err := driver.GetAllColors(ctx)
 	assert.NoError(t, err)
 	log.Println(cl)
 
-	b, err := driver.GetAllBrands()
+	b, err := driver.GetAllBrands(ctx)
 	assert.NoError(t, err)
 	log.Println(b)
 
-	ct, err := driver.GetAllCategories()
+	ct, err := driver.GetAllCategories(ctx)
 	assert.NoError(t, err)
 	log.Println(ct)
 
-	cn, err := driver.GetAllCountries()
+	cn, err := driver.GetAllCountries(ctx)
 	assert.NoError(t, err)
 	log.Println(cn)
 
-	m, err := driver.GetAllMaterials()
+	m, err := driver.GetAllMaterials(ctx)
 	assert.NoError(t, err)
 	log.Println(m)
 
@@ -719,29 +721,29 @@ func TestCRUDProducts(t *testing.T) {
 		Description: "Test description",
 	}
 
-	assert.NoError(t, driver.CreateProduct(product))
+	assert.NoError(t, driver.CreateProduct(ctx, product))
 
-	products, err := driver.GetAllProducts()
+	products, err := driver.GetAllProducts(ctx)
 	assert.NoError(t, err)
 	assert.NotEmpty(t, products)
 	log.Println("products after create:", products, "\n\n", "")
 
-	pr, err := driver.GetProductById("prod1")
+	pr, err := driver.GetProductById(ctx, "prod1")
 	assert.NoError(t, err)
 	assert.NotEmpty(t, pr)
 	log.Println("Get product by id: ", pr)
 
 	product.Title = "Updated ProductId"
 	product.Width = 80
-	assert.NoError(t, driver.UpdateProduct(product, product.Id))
+	assert.NoError(t, driver.UpdateProduct(ctx, product, product.Id))
 
-	products, err = driver.GetAllProducts()
+	products, err = driver.GetAllProducts(ctx)
 	assert.NoError(t, err)
 	log.Println("products after update:", products, "\n\n", "")
 
-	assert.NoError(t, driver.DeleteProduct(product.Id))
+	assert.NoError(t, driver.DeleteProduct(ctx, product.Id))
 
-	products, err = driver.GetAllProducts()
+	products, err = driver.GetAllProducts(ctx)
 	assert.NoError(t, err)
 	log.Println("products after delete:", products, "\n\n", "")
 }
@@ -1,14 +1,15 @@
 package psql
 
 import (
-	"log"
+	"context"
+	"log/slog"
 	"productService/internal/utils/format"
 	"productService/internal/views"
 	"strconv"
 	"strings"
 )
 
-func (d Driver) GetDictionariesByCategory(id string) (*views.Dictionaries, error) {
+func (d Driver) GetDictionariesByCategory(ctx context.Context, id string) (*views.Dictionaries, error) {
 	const op = "PostgresDb.GetDictionaries"
 
 	query := `
@@ -41,7 +42,7 @@ func (d Driver) GetDictionariesByCategory(id string) (*views.Dictionaries, error
 		SELECT 'stats', '', min_price, max_price, min_width || ',' || max_width || ',' || min_height || ',' || max_height || ',' || min_depth || ',' || max_depth FROM stats;
 	`
 
-	rows, err := d.Driver.Query(query, id)
+	rows, err := d.Driver.QueryContext(ctx, query, id)
 	if err != nil {
 		return nil, format.Error(op, err)
 	}
@@ -51,7 +52,7 @@ func (d Driver) GetDictionariesByCategory(id string) (*views.Dictionaries, error
 	for rows.Next() {
 		var typ, id, field1, field2, field3 string
 		if err := rows.Scan(&typ, &id, &field1, &field2, &field3); err != nil {
-			log.Println(format.Error(op, err))
+			slog.ErrorContext(ctx, op, "err", err)
 			continue
 		}
 
@@ -85,11 +86,12 @@ func (d Driver) GetDictionariesByCategory(id string) (*views.Dictionaries, error
 			}
 		}
 	}
+	fetchCatalogState(ctx, d.Driver, result)
 
 	return result, nil
 }
 
-func (d Driver) GetDictionaries() (*views.Dictionaries, error) {
+func (d Driver) GetDictionaries(ctx context.Context) (*views.Dictionaries, error) {
 	const op = "PostgresDb.GetDictionaries"
 
 	query := `
@@ -121,7 +123,7 @@ func (d Driver) GetDictionaries() (*views.Dictionaries, error) {
 		SELECT 'stats', '', min_price, max_price, min_width || ',' || max_width || ',' || min_height || ',' || max_height || ',' || min_depth || ',' || max_depth FROM stats;
 	`
 
-	rows, err := d.Driver.Query(query)
+	rows, err := d.Driver.QueryContext(ctx, query)
 	if err != nil {
 		return nil, format.Error(op, err)
 	}
@@ -131,7 +133,7 @@ func (d Driver) GetDictionaries() (*views.Dictionaries, error) {
 	for rows.Next() {
 		var typ, id, field1, field2, field3 string
 		if err := rows.Scan(&typ, &id, &field1, &field2, &field3); err != nil {
-			log.Println(format.Error(op, err))
+			slog.ErrorContext(ctx, op, "err", err)
 			continue
 		}
 
@@ -165,6 +167,21 @@ func (d Driver) GetDictionaries() (*views.Dictionaries, error) {
 			}
 		}
 	}
+	fetchCatalogState(ctx, d.Driver, result)
 
 	return result, nil
 }
+
+// fetchCatalogState set version and update time of whole catalog. They are computed from journal
+// of changes, see migration catalog_changes
+func fetchCatalogState(ctx context.Context, db SqlRepo, d *views.Dictionaries) {
+	const op = "PostgresDb.fetchCatalogState"
+	err := db.QueryRowContext(ctx, `
+		SELECT COALESCE(SUM(weight), 0)::bigint, COALESCE(extract(epoch FROM MAX(changed_at)), 0)::bigint
+		FROM catalog_changes
+	`).
+		Scan(&d.Version, &d.UpdatedAt)
+	if err != nil {
+		slog.ErrorContext(ctx, op, "err", err)
+	}
+}
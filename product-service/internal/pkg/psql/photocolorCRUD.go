@@ -1,18 +1,19 @@
 package psql
 
 import (
+	"context"
 	"fmt"
 	"github.com/lib/pq"
-	"log"
+	"log/slog"
 	"productService/internal/utils/format"
 	"productService/internal/views"
 )
 
-func (d Driver) GetAllProductColorPhotos() ([]views.ProductColorPhotos, error) {
+func (d Driver) GetAllProductColorPhotos(ctx context.Context) ([]views.ProductColorPhotos, error) {
 	const op = "PostgresDb.GetAllProductColorPhotos"
 	var list []views.ProductColorPhotos
 
-	rows, err := d.Driver.Query(`SELECT product_id, color_id, photos FROM product_color_photos`)
+	rows, err := d.Driver.QueryContext(ctx, `SELECT product_id, color_id, photos FROM product_color_photos`)
 	if err != nil {
 		return nil, format.Error(op, err)
 	}
@@ -21,7 +22,7 @@ func (d Driver) GetAllProductColorPhotos() ([]views.ProductColorPhotos, error) {
 	for rows.Next() {
 		var pcp views.ProductColorPhotos
 		if err := rows.Scan(&pcp.ProductId, &pcp.ColorId, pq.Array(&pcp.Photos)); err != nil {
-			log.Println(format.Error(op, err))
+			slog.ErrorContext(ctx, op, "err", err)
 			continue
 		}
 		list = append(list, pcp)
@@ -29,40 +30,43 @@ func (d Driver) GetAllProductColorPhotos() ([]views.ProductColorPhotos, error) {
 	return list, nil
 }
 
-func (d Driver) CreateProductColorPhotos(pcp *views.ProductColorPhotos) error {
+func (d Driver) CreateProductColorPhotos(ctx context.Context, pcp *views.ProductColorPhotos) error {
 	const op = "PostgresDb.CreateProductColorPhotos"
 	query := `INSERT INTO product_color_photos (product_id, color_id, photos) VALUES ($1, $2, $3)`
-	_, err := d.Driver.Exec(query, pcp.ProductId, pcp.ColorId, pq.Array(pcp.Photos))
+	_, err := d.Driver.ExecContext(ctx, query, pcp.ProductId, pcp.ColorId, pq.Array(pcp.Photos))
 	return format.Error(op, err)
 }
 
-func (d Driver) UpdateProductColorPhotos(pcp *views.ProductColorPhotos) error {
+func (d Driver) UpdateProductColorPhotos(ctx context.Context, pcp *views.ProductColorPhotos) error {
 	const op = "PostgresDb.UpdateProductColorPhotos"
 	query := `UPDATE product_color_photos SET photos = $3 WHERE product_id = $1 AND color_id = $2`
 
-	result, err := d.Driver.Exec(query, pcp.ProductId, pcp.ColorId, pq.Array(pcp.Photos))
+	result, err := d.Driver.ExecContext(ctx, query, pcp.ProductId, pcp.ColorId, pq.Array(pcp.Photos))
 	if err != nil {
 		return format.Error(op, err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
-	if err != nil || rowsAffected == 0 {
-		return format.Error(op, fmt.Errorf("record with product_id=%s and color_id=%s not found", pcp.ProductId, pcp.ColorId))
+	if err != nil {
+		return format.Error(op, err)
+	}
+	if rowsAffected == 0 {
+		return format.Error(op, fmt.Errorf("record with product_id=%s and color_id=%s: %w", pcp.ProductId, pcp.ColorId, ErrNotFound))
 	}
 	return nil
 }
 
-func (d Driver) DeleteProductColorPhotos(productId, colorId string) error {
+func (d Driver) DeleteProductColorPhotos(ctx context.Context, productId, colorId string) error {
 	const op = "PostgresDb.DeleteProductColorPhotos"
-	_, err := d.Driver.Exec(`DELETE FROM product_color_photos WHERE product_id = $1 AND color_id = $2`, productId, colorId)
+	_, err := d.Driver.ExecContext(ctx, `DELETE FROM product_color_photos WHERE product_id = $1 AND color_id = $2`, productId, colorId)
 	return format.Error(op, err)
 }
 
-func (d Driver) GetPhotosByProductAndColor(productId, colorId string) ([]string, error) {
+func (d Driver) GetPhotosByProductAndColor(ctx context.Context, productId, colorId string) ([]string, error) {
 	const op = "PostgresDb.GetPhotosByProductAndColor"
 	var photos []string
 
-	err := d.Driver.QueryRow(
+	err := d.Driver.QueryRowContext(ctx,
 		`SELECT photos FROM product_color_photos WHERE product_id = $1 AND color_id = $2`,
 		productId, colorId,
 	).Scan(pq.Array(&photos))
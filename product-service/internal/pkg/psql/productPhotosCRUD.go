@@ -0,0 +1,294 @@
+package psql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"productService/internal/utils/format"
+	"productService/internal/views"
+
+	"github.com/lib/pq"
+	"github.com/rs/xid"
+)
+
+func fetchPhotosByProductID(ctx context.Context, db SqlRepo, productID string) []views.ProductPhoto {
+	out, err := queryPhotos(ctx, db, productID)
+	if err != nil {
+		slog.ErrorContext(ctx, "fetchPhotos failed", "err", err)
+		return nil
+	}
+	return out
+}
+
+func queryPhotos(ctx context.Context, db SqlRepo, productID string) ([]views.ProductPhoto, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, product_id, file, position, alt, is_main, COALESCE(color_id, '')
+		FROM product_photos
+		WHERE product_id = $1
+		ORDER BY position, id
+	`, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []views.ProductPhoto
+	for rows.Next() {
+		var p views.ProductPhoto
+		if err := rows.Scan(&p.Id, &p.ProductId, &p.File, &p.Position, &p.Alt, &p.IsMain, &p.ColorId); err != nil {
+			slog.ErrorContext(ctx, "fetchPhotos scan failed", "err", err)
+			continue
+		}
+		out = append(out, p)
+	}
+	return out, rows.Err()
+}
+
+// syncLegacyPhotos rewrite products.photos from gallery so old clients see the same order
+func syncLegacyPhotos(ctx context.Context, db SqlRepo, productID string) error {
+	_, err := db.ExecContext(ctx, `
+		UPDATE products SET photos = ARRAY(
+			SELECT file FROM product_photos WHERE product_id = $1 ORDER BY position, id
+		)
+		WHERE id = $1
+	`, productID)
+	return err
+}
+
+// ensureMainPhoto make first photo main if product has photos but none of them is main
+func ensureMainPhoto(ctx context.Context, db SqlRepo, productID string) error {
+	_, err := db.ExecContext(ctx, `
+		UPDATE product_photos SET is_main = TRUE
+		WHERE id = (SELECT id FROM product_photos WHERE product_id = $1 ORDER BY position, id LIMIT 1)
+		  AND NOT EXISTS (SELECT 1 FROM product_photos WHERE product_id = $1 AND is_main)
+	`, productID)
+	return err
+}
+
+func setMainPhoto(ctx context.Context, db SqlRepo, productID, id string) error {
+	if _, err := db.ExecContext(ctx, `UPDATE product_photos SET is_main = FALSE WHERE product_id = $1 AND id <> $2 AND is_main`, productID, id); err != nil {
+		return err
+	}
+	_, err := db.ExecContext(ctx, `UPDATE product_photos SET is_main = TRUE WHERE product_id = $1 AND id = $2`, productID, id)
+	return err
+}
+
+// lockProduct lock row of product till end of transaction, so gallery of one product is changed
+// by one call at a time and MAX(position) is not read by two of them
+func lockProduct(ctx context.Context, db SqlRepo, productID string) error {
+	var id string
+	err := db.QueryRowContext(ctx, `SELECT id FROM products WHERE id = $1 FOR UPDATE`, productID).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("product with id %s: %w", productID, ErrNotFound)
+	}
+	return err
+}
+
+// photoProduct return id of product photo belong to
+func photoProduct(ctx context.Context, db SqlRepo, id string) (string, error) {
+	var productID string
+	err := db.QueryRowContext(ctx, `SELECT product_id FROM product_photos WHERE id = $1`, id).Scan(&productID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("photo with id %s: %w", id, ErrNotFound)
+	}
+	return productID, err
+}
+
+// replacePhotos rebuild gallery from legacy list of files, empty list clear it. Alt text, colour
+// and main flag of files that stay in the list are kept. Must be called in transaction with product locked
+func replacePhotos(ctx context.Context, db SqlRepo, productID string, files []string) error {
+	if files == nil {
+		// nil is NULL for postgres and nothing is deleted by ANY(NULL)
+		files = []string{}
+	}
+
+	current, err := queryPhotos(ctx, db, productID)
+	if err != nil {
+		return err
+	}
+
+	byFile := make(map[string][]views.ProductPhoto)
+	for _, p := range current {
+		byFile[p.File] = append(byFile[p.File], p)
+	}
+
+	if _, err := db.ExecContext(ctx, `DELETE FROM product_photos WHERE product_id = $1 AND NOT (file = ANY($2))`, productID, pq.Array(files)); err != nil {
+		return err
+	}
+
+	for i, f := range files {
+		if same := byFile[f]; len(same) > 0 {
+			byFile[f] = same[1:]
+			if _, err := db.ExecContext(ctx, `UPDATE product_photos SET position = $2 WHERE id = $1`, same[0].Id, i); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, err := db.ExecContext(ctx, `
+			INSERT INTO product_photos (id, product_id, file, position)
+			VALUES ($1, $2, $3, $4)
+		`, xid.New().String(), productID, f, i); err != nil {
+			return err
+		}
+	}
+
+	// дубликаты, которых больше нет в новом списке
+	for _, rest := range byFile {
+		for _, p := range rest {
+			if _, err := db.ExecContext(ctx, `DELETE FROM product_photos WHERE id = $1`, p.Id); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := ensureMainPhoto(ctx, db, productID); err != nil {
+		return err
+	}
+	return syncLegacyPhotos(ctx, db, productID)
+}
+
+func (d Driver) GetProductPhotos(ctx context.Context, productId string) ([]views.ProductPhoto, error) {
+	const op = "PostgresDb.GetProductPhotos"
+
+	list, err := queryPhotos(ctx, d.Driver, productId)
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+	return list, nil
+}
+
+// AddProductPhoto append photo to the end of gallery. First photo of product become main
+func (d Driver) AddProductPhoto(ctx context.Context, p *views.ProductPhoto) error {
+	const op = "PostgresDb.AddProductPhoto"
+
+	return format.Error(op, inTx(ctx, d.Driver, func(tx SqlRepo) error {
+		if err := lockProduct(ctx, tx, p.ProductId); err != nil {
+			return err
+		}
+
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO product_photos (id, product_id, file, position, alt, color_id)
+			VALUES ($1, $2, $3,
+				(SELECT COALESCE(MAX(position) + 1, 0) FROM product_photos WHERE product_id = $2),
+				$4, NULLIF($5, ''))
+		`, p.Id, p.ProductId, p.File, p.Alt, p.ColorId)
+		if err != nil {
+			return err
+		}
+
+		if p.IsMain {
+			err = setMainPhoto(ctx, tx, p.ProductId, p.Id)
+		} else {
+			err = ensureMainPhoto(ctx, tx, p.ProductId)
+		}
+		if err != nil {
+			return err
+		}
+
+		return syncLegacyPhotos(ctx, tx, p.ProductId)
+	}))
+}
+
+// UpdateProductPhoto change alt text, colour and main flag. File and position are not changed
+func (d Driver) UpdateProductPhoto(ctx context.Context, p *views.ProductPhoto) error {
+	const op = "PostgresDb.UpdateProductPhoto"
+
+	return format.Error(op, inTx(ctx, d.Driver, func(tx SqlRepo) error {
+		productId, err := photoProduct(ctx, tx, p.Id)
+		if err != nil {
+			return err
+		}
+		if err := lockProduct(ctx, tx, productId); err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE product_photos SET alt = $2, color_id = NULLIF($3, '')
+			WHERE id = $1
+		`, p.Id, p.Alt, p.ColorId); err != nil {
+			return err
+		}
+
+		if p.IsMain {
+			return setMainPhoto(ctx, tx, productId, p.Id)
+		}
+		return nil
+	}))
+}
+
+// ReorderProductPhotos set positions by order of ids. ids must contain every photo of product
+func (d Driver) ReorderProductPhotos(ctx context.Context, productId string, ids []string) error {
+	const op = "PostgresDb.ReorderProductPhotos"
+
+	return format.Error(op, inTx(ctx, d.Driver, func(tx SqlRepo) error {
+		if err := lockProduct(ctx, tx, productId); err != nil {
+			return err
+		}
+
+		current, err := queryPhotos(ctx, tx, productId)
+		if err != nil {
+			return err
+		}
+
+		known := make(map[string]bool, len(current))
+		for _, p := range current {
+			known[p.Id] = true
+		}
+		if len(ids) != len(current) {
+			return fmt.Errorf("expected %d photo ids, got %d", len(current), len(ids))
+		}
+		for _, id := range ids {
+			if !known[id] {
+				return fmt.Errorf("photo %s does not belong to product %s or repeated", id, productId)
+			}
+			delete(known, id)
+		}
+
+		// уникальность позиций проверяется при коммите, поэтому меняем их по одной
+		for i, id := range ids {
+			if _, err := tx.ExecContext(ctx, `UPDATE product_photos SET position = $3 WHERE id = $1 AND product_id = $2`, id, productId, i); err != nil {
+				return err
+			}
+		}
+
+		return syncLegacyPhotos(ctx, tx, productId)
+	}))
+}
+
+// RemoveProductPhoto delete photo from gallery and return it, so caller can free the file
+func (d Driver) RemoveProductPhoto(ctx context.Context, id string) (*views.ProductPhoto, error) {
+	const op = "PostgresDb.RemoveProductPhoto"
+
+	var p views.ProductPhoto
+	err := inTx(ctx, d.Driver, func(tx SqlRepo) error {
+		productId, err := photoProduct(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if err := lockProduct(ctx, tx, productId); err != nil {
+			return err
+		}
+
+		err = tx.QueryRowContext(ctx, `
+			DELETE FROM product_photos WHERE id = $1
+			RETURNING id, product_id, file, position, alt, is_main, COALESCE(color_id, '')
+		`, id).Scan(&p.Id, &p.ProductId, &p.File, &p.Position, &p.Alt, &p.IsMain, &p.ColorId)
+		if err != nil {
+			return err
+		}
+
+		if p.IsMain {
+			if err := ensureMainPhoto(ctx, tx, p.ProductId); err != nil {
+				return err
+			}
+		}
+		return syncLegacyPhotos(ctx, tx, p.ProductId)
+	})
+	if err != nil {
+		return nil, format.Error(op, err)
+	}
+
+	return &p, nil
+}
@@ -1,10 +1,12 @@
 package psql
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"productService/internal/pkg/validate"
 	"productService/internal/utils/format"
 	"productService/internal/views"
 	"strings"
@@ -13,91 +15,104 @@ import (
 )
 
 type Repository interface {
-	GetAllColors() ([]views.Color, error)
-	CreateColor(c *views.Color) error
-	UpdateColor(c *views.Color, id string) error
-	DeleteColor(id string) error
-	GetAllMaterials() ([]views.Material, error)
-	CreateMaterial(m *views.Material) error
-	UpdateMaterial(m *views.Material, id string) error
-	DeleteMaterial(id string) error
-	GetAllCountries() ([]views.Country, error)
-	GetDictionariesByCategory(id string) (*views.Dictionaries, error)
-	CreateCountry(c *views.Country) error
-	UpdateCountry(c *views.Country, id string) error
-	DeleteCountry(id string) error
-	GetAllCategories() ([]views.Category, error)
-	CreateCategory(c *views.Category) error
-	UpdateCategory(c *views.Category, id string) error
-	DeleteCategory(id string) error
-	GetAllBrands() ([]views.Brand, error)
-	CreateBrand(b *views.Brand) error
-	UpdateBrand(b *views.Brand, id string) error
-	DeleteBrand(id string) error
-	GetAllProducts(start, end int) ([]views.Product, error)
-	GetProductById(id string) (*views.Product, error)
-	CreateProduct(p *views.ProductId) error
-	UpdateProduct(p *views.ProductId, id string) error
-	DeleteProduct(id string) error
-	FilterProducts(filter *views.ProductFilter) ([]views.Product, error)
-	GetDictionaries() (*views.Dictionaries, error)
-	SearchProducts(filter *views.ProductSearch) ([]views.Product, error)
-	GetAllProductColorPhotos() ([]views.ProductColorPhotos, error)
-	CreateProductColorPhotos(pcp *views.ProductColorPhotos) error
-	UpdateProductColorPhotos(pcp *views.ProductColorPhotos) error
-	DeleteProductColorPhotos(productId, colorId string) error
-	GetPhotosByProductAndColor(productId, colorId string) ([]string, error)
+	GetAllColors(ctx context.Context) ([]views.Color, error)
+	CreateColor(ctx context.Context, c *views.Color) error
+	UpdateColor(ctx context.Context, c *views.Color, id string) error
+	DeleteColor(ctx context.Context, id string) error
+	GetAllMaterials(ctx context.Context) ([]views.Material, error)
+	CreateMaterial(ctx context.Context, m *views.Material) error
+	UpdateMaterial(ctx context.Context, m *views.Material, id string) error
+	DeleteMaterial(ctx context.Context, id string) error
+	GetAllCountries(ctx context.Context) ([]views.Country, error)
+	GetDictionariesByCategory(ctx context.Context, id string) (*views.Dictionaries, error)
+	CreateCountry(ctx context.Context, c *views.Country) error
+	UpdateCountry(ctx context.Context, c *views.Country, id string) error
+	DeleteCountry(ctx context.Context, id string) error
+	GetAllCategories(ctx context.Context) ([]views.Category, error)
+	CreateCategory(ctx context.Context, c *views.Category) error
+	UpdateCategory(ctx context.Context, c *views.Category, id string) error
+	DeleteCategory(ctx context.Context, id string) error
+	GetAllBrands(ctx context.Context) ([]views.Brand, error)
+	CreateBrand(ctx context.Context, b *views.Brand) error
+	UpdateBrand(ctx context.Context, b *views.Brand, id string) error
+	DeleteBrand(ctx context.Context, id string) error
+	GetAllProducts(ctx context.Context, start, end int) ([]views.Product, error)
+	GetProductById(ctx context.Context, id string) (*views.Product, error)
+	CreateProduct(ctx context.Context, p *views.ProductId) error
+	UpdateProduct(ctx context.Context, p *views.ProductId, id string) error
+	DeleteProduct(ctx context.Context, id string) error
+	FilterProducts(ctx context.Context, filter *views.ProductFilter) ([]views.Product, error)
+	GetDictionaries(ctx context.Context) (*views.Dictionaries, error)
+	SearchProducts(ctx context.Context, filter *views.ProductSearch) ([]views.Product, error)
+	GetAllProductColorPhotos(ctx context.Context) ([]views.ProductColorPhotos, error)
+	CreateProductColorPhotos(ctx context.Context, pcp *views.ProductColorPhotos) error
+	UpdateProductColorPhotos(ctx context.Context, pcp *views.ProductColorPhotos) error
+	DeleteProductColorPhotos(ctx context.Context, productId, colorId string) error
+	GetPhotosByProductAndColor(ctx context.Context, productId, colorId string) ([]string, error)
+	GetProductPhotos(ctx context.Context, productId string) ([]views.ProductPhoto, error)
+	AddProductPhoto(ctx context.Context, p *views.ProductPhoto) error
+	UpdateProductPhoto(ctx context.Context, p *views.ProductPhoto) error
+	ReorderProductPhotos(ctx context.Context, productId string, ids []string) error
+	RemoveProductPhoto(ctx context.Context, id string) (*views.ProductPhoto, error)
+	GetAdmin(ctx context.Context, login string) (*views.AdminUser, error)
+	GetAllAdmins(ctx context.Context) ([]views.AdminUser, error)
+	CreateAdmin(ctx context.Context, u *views.AdminUser) error
+	UpdateAdmin(ctx context.Context, u *views.AdminUser) error
+	DeleteAdmin(ctx context.Context, login string) error
+	CheckProduct(ctx context.Context, p *views.ProductId, v *validate.Errors) error
+	CheckRefs(ctx context.Context, v *validate.Errors, ref Ref, field string, ids ...string) error
 }
 
+// SqlRepo is *sql.DB or *sql.Tx. Only context methods are used, so cancelled call stop its queries
 type SqlRepo interface {
-	Query(query string, args ...any) (*sql.Rows, error)
-	Exec(query string, args ...any) (sql.Result, error)
-	QueryRow(query string, args ...any) *sql.Row
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
 }
 
 type Driver struct {
 	Driver SqlRepo
 }
 
-func fetchBrand(db SqlRepo, id string) views.Brand {
+func fetchBrand(ctx context.Context, db SqlRepo, id string) views.Brand {
 	var b views.Brand
-	err := db.QueryRow("SELECT id, name FROM brands WHERE id = $1", id).Scan(&b.Id, &b.Name)
+	err := db.QueryRowContext(ctx, "SELECT id, name FROM brands WHERE id = $1", id).Scan(&b.Id, &b.Name)
 	if err != nil {
-		log.Printf("fetchBrand error: %v", err)
+		slog.ErrorContext(ctx, "fetchBrand failed", "err", err)
 		return views.Brand{}
 	}
 	return b
 }
 
-func fetchCategory(db SqlRepo, id string) views.Category {
+func fetchCategory(ctx context.Context, db SqlRepo, id string) views.Category {
 	var c views.Category
-	err := db.QueryRow("SELECT id, title, uri FROM categories WHERE id = $1", id).Scan(&c.Id, &c.Title, &c.Uri)
+	err := db.QueryRowContext(ctx, "SELECT id, title, uri FROM categories WHERE id = $1", id).Scan(&c.Id, &c.Title, &c.Uri)
 	if err != nil {
-		log.Printf("fetchCategory error: %v", err)
+		slog.ErrorContext(ctx, "fetchCategory failed", "err", err)
 		return views.Category{}
 	}
 	return c
 }
 
-func fetchCountry(db SqlRepo, id string) views.Country {
+func fetchCountry(ctx context.Context, db SqlRepo, id string) views.Country {
 	var c views.Country
-	err := db.QueryRow("SELECT id, title, friendly FROM countries WHERE id = $1", id).Scan(&c.Id, &c.Title, &c.Friendly)
+	err := db.QueryRowContext(ctx, "SELECT id, title, friendly FROM countries WHERE id = $1", id).Scan(&c.Id, &c.Title, &c.Friendly)
 	if err != nil {
-		log.Printf("fetchCountry error: %v", err)
+		slog.ErrorContext(ctx, "fetchCountry failed", "err", err)
 		return views.Country{}
 	}
 	return c
 }
 
-func fetchMaterialsByProductID(db SqlRepo, productID string) []views.Material {
-	rows, err := db.Query(`
+func fetchMaterialsByProductID(ctx context.Context, db SqlRepo, productID string) []views.Material {
+	rows, err := db.QueryContext(ctx, `
 		SELECT m.id, m.title
 		FROM product_materials pm
 		JOIN materials m ON m.id = pm.material_id
 		WHERE pm.product_id = $1
 	`, productID)
 	if err != nil {
-		log.Printf("fetchMaterials error: %v", err)
+		slog.ErrorContext(ctx, "fetchMaterials failed", "err", err)
 		return nil
 	}
 	defer rows.Close()
@@ -106,7 +121,7 @@ func fetchMaterialsByProductID(db SqlRepo, productID string) []views.Material {
 	for rows.Next() {
 		var m views.Material
 		if err := rows.Scan(&m.Id, &m.Title); err != nil {
-			log.Printf("fetchMaterials scan error: %v", err)
+			slog.ErrorContext(ctx, "fetchMaterials scan failed", "err", err)
 			continue
 		}
 		out = append(out, m)
@@ -114,15 +129,15 @@ func fetchMaterialsByProductID(db SqlRepo, productID string) []views.Material {
 	return out
 }
 
-func fetchColorsByProductID(db SqlRepo, productID string) []views.Color {
-	rows, err := db.Query(`
+func fetchColorsByProductID(ctx context.Context, db SqlRepo, productID string) []views.Color {
+	rows, err := db.QueryContext(ctx, `
 		SELECT c.id, c.name, c.hex
 		FROM product_colors pc
 		JOIN colors c ON c.id = pc.color_id
 		WHERE pc.product_id = $1
 	`, productID)
 	if err != nil {
-		log.Printf("fetchColors error: %v", err)
+		slog.ErrorContext(ctx, "fetchColors failed", "err", err)
 		return nil
 	}
 	defer rows.Close()
@@ -131,7 +146,7 @@ func fetchColorsByProductID(db SqlRepo, productID string) []views.Color {
 	for rows.Next() {
 		var c views.Color
 		if err := rows.Scan(&c.Id, &c.Name, &c.Hex); err != nil {
-			log.Printf("fetchColors scan error: %v", err)
+			slog.ErrorContext(ctx, "fetchColors scan failed", "err", err)
 			continue
 		}
 		out = append(out, c)
@@ -139,18 +154,18 @@ func fetchColorsByProductID(db SqlRepo, productID string) []views.Color {
 	return out
 }
 
-func fetchSimilarProducts(db SqlRepo, productID string) []views.Product {
+func fetchSimilarProducts(ctx context.Context, db SqlRepo, productID string) []views.Product {
 	const query = `
 		SELECT p.id, p.title, p.article, p.brand_id, p.category_id, p.country_id,
-			   p.width, p.height, p.depth, p.photos, p.price, p.description
+			   p.width, p.height, p.depth, p.photos, p.price, p.description, p.version, extract(epoch FROM p.updated_at)::bigint
 		FROM product_seems ps
 		JOIN products p ON p.id = ps.similar_product_id
 		WHERE ps.product_id = $1
 	`
 
-	rows, err := db.Query(query, productID)
+	rows, err := db.QueryContext(ctx, query, productID)
 	if err != nil {
-		log.Printf("fetchSimilarProducts error: %v", err)
+		slog.ErrorContext(ctx, "fetchSimilarProducts failed", "err", err)
 		return nil
 	}
 	defer rows.Close()
@@ -168,8 +183,8 @@ func fetchSimilarProducts(db SqlRepo, productID string) []views.Product {
 		if err := rows.Scan(&rp.product.Id, &rp.product.Title, &rp.product.Article,
 			&rp.brandID, &rp.categoryID, &rp.countryID,
 			&rp.product.Width, &rp.product.Height, &rp.product.Depth,
-			pq.Array(&rp.product.Photos), &rp.product.Price, &rp.product.Description); err != nil {
-			log.Printf("fetchSimilarProducts scan error: %v", err)
+			pq.Array(&rp.product.Photos), &rp.product.Price, &rp.product.Description, &rp.product.Version, &rp.product.UpdatedAt); err != nil {
+			slog.ErrorContext(ctx, "fetchSimilarProducts scan failed", "err", err)
 			continue
 		}
 		rawList = append(rawList, rp)
@@ -177,11 +192,11 @@ func fetchSimilarProducts(db SqlRepo, productID string) []views.Product {
 
 	var result []views.Product
 	for _, r := range rawList {
-		r.product.Brand = fetchBrand(db, r.brandID)
-		r.product.Category = fetchCategory(db, r.categoryID)
-		r.product.Country = fetchCountry(db, r.countryID)
-		r.product.Materials = fetchMaterialsByProductID(db, r.product.Id)
-		r.product.Colors = fetchColorsByProductID(db, r.product.Id)
+		r.product.Brand = fetchBrand(ctx, db, r.brandID)
+		r.product.Category = fetchCategory(ctx, db, r.categoryID)
+		r.product.Country = fetchCountry(ctx, db, r.countryID)
+		r.product.Materials = fetchMaterialsByProductID(ctx, db, r.product.Id)
+		r.product.Colors = fetchColorsByProductID(ctx, db, r.product.Id)
 		r.product.Seems = nil // ⚠️ избегаем рекурсии
 		result = append(result, r.product)
 	}
@@ -189,7 +204,7 @@ func fetchSimilarProducts(db SqlRepo, productID string) []views.Product {
 	return result
 }
 
-func (d Driver) SearchProducts(filter *views.ProductSearch) ([]views.Product, error) {
+func (d Driver) SearchProducts(ctx context.Context, filter *views.ProductSearch) ([]views.Product, error) {
 	const op = "PostgresDb.SearchProducts"
 
 	var (
@@ -200,24 +215,24 @@ func (d Driver) SearchProducts(filter *views.ProductSearch) ([]views.Product, er
 	switch {
 	case filter.Id != "":
 		query = `SELECT id, title, article, brand_id, category_id, country_id,
-				 width, height, depth, photos, price, description
+				 width, height, depth, photos, price, description, version, extract(epoch FROM updated_at)::bigint
 				 FROM products WHERE id = $1`
 		args = append(args, filter.Id)
 	case filter.Article != "":
 		query = `SELECT id, title, article, brand_id, category_id, country_id,
-				 width, height, depth, photos, price, description
+				 width, height, depth, photos, price, description, version, extract(epoch FROM updated_at)::bigint
 				 FROM products WHERE article = $1`
 		args = append(args, filter.Article)
 	case filter.Title != "":
 		query = `SELECT id, title, article, brand_id, category_id, country_id,
-				 width, height, depth, photos, price, description
+				 width, height, depth, photos, price, description, version, extract(epoch FROM updated_at)::bigint
 				 FROM products WHERE title ILIKE $1`
 		args = append(args, "%"+filter.Title+"%")
 	default:
-		return nil, format.Error(op, errors.New("no search parameter provided"))
+		return nil, format.Error(op, fmt.Errorf("no search parameter provided: %w", ErrInvalid))
 	}
 
-	rows, err := d.Driver.Query(query, args...)
+	rows, err := d.Driver.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, format.Error(op, err)
 	}
@@ -229,17 +244,18 @@ func (d Driver) SearchProducts(filter *views.ProductSearch) ([]views.Product, er
 		var brandID, categoryID, countryID string
 		var p views.Product
 		if err := rows.Scan(&p.Id, &p.Title, &p.Article, &brandID, &categoryID, &countryID,
-			&p.Width, &p.Height, &p.Depth, pq.Array(&p.Photos), &p.Price, &p.Description); err != nil {
-			log.Println(format.Error(op, err))
+			&p.Width, &p.Height, &p.Depth, pq.Array(&p.Photos), &p.Price, &p.Description, &p.Version, &p.UpdatedAt); err != nil {
+			slog.ErrorContext(ctx, op, "err", err)
 			continue
 		}
 
-		p.Brand = fetchBrand(d.Driver, brandID)
-		p.Category = fetchCategory(d.Driver, categoryID)
-		p.Country = fetchCountry(d.Driver, countryID)
-		p.Materials = fetchMaterialsByProductID(d.Driver, p.Id)
-		p.Colors = fetchColorsByProductID(d.Driver, p.Id)
-		p.Seems = fetchSimilarProducts(d.Driver, p.Id)
+		p.Brand = fetchBrand(ctx, d.Driver, brandID)
+		p.Category = fetchCategory(ctx, d.Driver, categoryID)
+		p.Country = fetchCountry(ctx, d.Driver, countryID)
+		p.Materials = fetchMaterialsByProductID(ctx, d.Driver, p.Id)
+		p.Colors = fetchColorsByProductID(ctx, d.Driver, p.Id)
+		p.Gallery = fetchPhotosByProductID(ctx, d.Driver, p.Id)
+		p.Seems = fetchSimilarProducts(ctx, d.Driver, p.Id)
 
 		products = append(products, p)
 	}
@@ -247,12 +263,12 @@ func (d Driver) SearchProducts(filter *views.ProductSearch) ([]views.Product, er
 	return products, nil
 }
 
-func (d Driver) GetProductById(id string) (*views.Product, error) {
+func (d Driver) GetProductById(ctx context.Context, id string) (*views.Product, error) {
 	const op = "PostgresDb.getProductById"
 
 	const query = `
 		SELECT id, title, article, brand_id, category_id, country_id,
-		       width, height, depth, photos, price, description
+		       width, height, depth, photos, price, description, version, extract(epoch FROM updated_at)::bigint
 		FROM products
 		WHERE id = $1
 	`
@@ -262,7 +278,7 @@ func (d Driver) GetProductById(id string) (*views.Product, error) {
 		p                              views.Product
 	)
 
-	err := d.Driver.QueryRow(query, id).Scan(
+	err := d.Driver.QueryRowContext(ctx, query, id).Scan(
 		&p.Id,
 		&p.Title,
 		&p.Article,
@@ -275,25 +291,28 @@ func (d Driver) GetProductById(id string) (*views.Product, error) {
 		pq.Array(&p.Photos),
 		&p.Price,
 		&p.Description,
+		&p.Version,
+		&p.UpdatedAt,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("product not find")
+			return nil, format.Error(op, fmt.Errorf("product with id %s: %w", id, ErrNotFound))
 		}
 		return nil, format.Error(op, err)
 	}
 
-	p.Brand = fetchBrand(d.Driver, brandID)
-	p.Category = fetchCategory(d.Driver, categoryID)
-	p.Country = fetchCountry(d.Driver, countryID)
-	p.Materials = fetchMaterialsByProductID(d.Driver, p.Id)
-	p.Colors = fetchColorsByProductID(d.Driver, p.Id)
-	p.Seems = fetchSimilarProducts(d.Driver, p.Id)
+	p.Brand = fetchBrand(ctx, d.Driver, brandID)
+	p.Category = fetchCategory(ctx, d.Driver, categoryID)
+	p.Country = fetchCountry(ctx, d.Driver, countryID)
+	p.Materials = fetchMaterialsByProductID(ctx, d.Driver, p.Id)
+	p.Colors = fetchColorsByProductID(ctx, d.Driver, p.Id)
+	p.Gallery = fetchPhotosByProductID(ctx, d.Driver, p.Id)
+	p.Seems = fetchSimilarProducts(ctx, d.Driver, p.Id)
 
 	return &p, nil
 }
 
-func (d Driver) GetAllProducts(start, end int) ([]views.Product, error) {
+func (d Driver) GetAllProducts(ctx context.Context, start, end int) ([]views.Product, error) {
 	const op = "PostgresDb.GetAllProducts"
 
 	if end <= start {
@@ -302,9 +321,9 @@ func (d Driver) GetAllProducts(start, end int) ([]views.Product, error) {
 
 	limit := end - start
 
-	rows, err := d.Driver.Query(`
+	rows, err := d.Driver.QueryContext(ctx, `
 		SELECT id, title, article, brand_id, category_id, country_id,
-			   width, height, depth, photos, price, description
+			   width, height, depth, photos, price, description, version, extract(epoch FROM updated_at)::bigint
 		FROM products
 		ORDER BY id
 		LIMIT $1 OFFSET $2
@@ -338,8 +357,10 @@ func (d Driver) GetAllProducts(start, end int) ([]views.Product, error) {
 			pq.Array(&rp.product.Photos),
 			&rp.product.Price,
 			&rp.product.Description,
+			&rp.product.Version,
+			&rp.product.UpdatedAt,
 		); err != nil {
-			log.Println(format.Error(op, err))
+			slog.ErrorContext(ctx, op, "err", err)
 			continue
 		}
 		rawList = append(rawList, rp)
@@ -351,12 +372,13 @@ func (d Driver) GetAllProducts(start, end int) ([]views.Product, error) {
 
 	var result []views.Product
 	for _, r := range rawList {
-		r.product.Brand = fetchBrand(d.Driver, r.brandID)
-		r.product.Category = fetchCategory(d.Driver, r.categoryID)
-		r.product.Country = fetchCountry(d.Driver, r.countryID)
-		r.product.Materials = fetchMaterialsByProductID(d.Driver, r.product.Id)
-		r.product.Colors = fetchColorsByProductID(d.Driver, r.product.Id)
-		r.product.Seems = fetchSimilarProducts(d.Driver, r.product.Id)
+		r.product.Brand = fetchBrand(ctx, d.Driver, r.brandID)
+		r.product.Category = fetchCategory(ctx, d.Driver, r.categoryID)
+		r.product.Country = fetchCountry(ctx, d.Driver, r.countryID)
+		r.product.Materials = fetchMaterialsByProductID(ctx, d.Driver, r.product.Id)
+		r.product.Colors = fetchColorsByProductID(ctx, d.Driver, r.product.Id)
+		r.product.Gallery = fetchPhotosByProductID(ctx, d.Driver, r.product.Id)
+		r.product.Seems = fetchSimilarProducts(ctx, d.Driver, r.product.Id)
 		result = append(result, r.product)
 	}
 
@@ -364,108 +386,111 @@ func (d Driver) GetAllProducts(start, end int) ([]views.Product, error) {
 }
 
 // CreateProduct product
-func (d Driver) CreateProduct(p *views.ProductId) error {
+func (d Driver) CreateProduct(ctx context.Context, p *views.ProductId) error {
 	const op = "PostgresDb.CreateProduct"
 
-	_, err := d.Driver.Exec(`
-		INSERT INTO products (
-			id, title, article, brand_id, category_id, country_id, 
-			width, height, depth, photos, price, description
-		)
-		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12)
-	`, p.Id, p.Title, p.Article, p.Brand, p.Category, p.Country,
-		p.Width, p.Height, p.Depth, pq.Array(p.Photos), p.Price, p.Description)
-	if err != nil {
-		return format.Error(op, err)
-	}
-
-	for _, m := range p.Materials {
-		_, err := d.Driver.Exec(`INSERT INTO product_materials (product_id, material_id) VALUES ($1, $2)`, p.Id, m)
+	return format.Error(op, inTx(ctx, d.Driver, func(tx SqlRepo) error {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO products (
+				id, title, article, brand_id, category_id, country_id, 
+				width, height, depth, photos, price, description
+			)
+			VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12)
+		`, p.Id, p.Title, p.Article, p.Brand, p.Category, p.Country,
+			p.Width, p.Height, p.Depth, pq.Array(p.Photos), p.Price, p.Description)
 		if err != nil {
-			return format.Error(op, err)
+			return err
 		}
-	}
 
-	for _, c := range p.Colors {
-		_, err := d.Driver.Exec(`INSERT INTO product_colors (product_id, color_id) VALUES ($1, $2)`, p.Id, c)
-		if err != nil {
-			return format.Error(op, err)
+		for _, m := range p.Materials {
+			if _, err := tx.ExecContext(ctx, `INSERT INTO product_materials (product_id, material_id) VALUES ($1, $2)`, p.Id, m); err != nil {
+				return err
+			}
+		}
+		for _, c := range p.Colors {
+			if _, err := tx.ExecContext(ctx, `INSERT INTO product_colors (product_id, color_id) VALUES ($1, $2)`, p.Id, c); err != nil {
+				return err
+			}
 		}
-	}
-	if len(p.Seems) != 0 {
 		for _, s := range p.Seems {
-			_, err := d.Driver.Exec(`INSERT INTO product_seems (product_id, similar_product_id) VALUES ($1, $2)`, p.Id, s)
-			if err != nil {
-				return format.Error(op, err)
+			if _, err := tx.ExecContext(ctx, `INSERT INTO product_seems (product_id, similar_product_id) VALUES ($1, $2)`, p.Id, s); err != nil {
+				return err
 			}
 		}
-	}
 
-	return nil
+		if len(p.Photos) != 0 {
+			return replacePhotos(ctx, tx, p.Id, p.Photos)
+		}
+		return nil
+	}))
 }
 
-// UpdateProduct product
-func (d Driver) UpdateProduct(p *views.ProductId, id string) error {
+// UpdateProduct product. When p.ReplacePhotos is set gallery is rebuilt from Photos as before galleries,
+// so empty Photos clear it. Without it gallery is changed only by photo methods.
+// Everything is done in one transaction, so version is not bumped when relations fail to save
+func (d Driver) UpdateProduct(ctx context.Context, p *views.ProductId, id string) error {
 	const op = "PostgresDb.UpdateProduct"
 
-	_, err := d.Driver.Exec(`
-		UPDATE products SET 
-			title = $2, article = $3, brand_id = $4, category_id = $5,
-			country_id = $6, width = $7, height = $8, depth = $9,
-			photos = $10, price = $11, description = $12
-		WHERE id = $1
-	`, id, p.Title, p.Article, p.Brand, p.Category, p.Country,
-		p.Width, p.Height, p.Depth, pq.Array(p.Photos), p.Price, p.Description)
-	if err != nil {
-		return format.Error(op, err)
-	}
-
-	// Сначала удаляем старые связи
-	_, err = d.Driver.Exec(`DELETE FROM product_materials WHERE product_id = $1`, id)
-	if err != nil {
-		return format.Error(op, err)
-	}
-	_, err = d.Driver.Exec(`DELETE FROM product_colors WHERE product_id = $1`, id)
-	if err != nil {
-		return format.Error(op, err)
-	}
-	_, err = d.Driver.Exec(`DELETE FROM product_seems WHERE product_id = $1`, id)
-	if err != nil {
-		return format.Error(op, err)
-	}
-	// Добавляем новые
-	for _, m := range p.Materials {
-		_, err := d.Driver.Exec(`INSERT INTO product_materials (product_id, material_id) VALUES ($1, $2)`, id, m)
+	return format.Error(op, inTx(ctx, d.Driver, func(tx SqlRepo) error {
+		result, err := tx.ExecContext(ctx, `
+			UPDATE products SET 
+				title = $2, article = $3, brand_id = $4, category_id = $5,
+				country_id = $6, width = $7, height = $8, depth = $9,
+				price = $10, description = $11, version = version + 1
+			WHERE id = $1 AND version = $12
+		`, id, p.Title, p.Article, p.Brand, p.Category, p.Country,
+			p.Width, p.Height, p.Depth, p.Price, p.Description, p.Version)
 		if err != nil {
-			return format.Error(op, err)
+			return err
 		}
-	}
-
-	for _, c := range p.Colors {
-		_, err := d.Driver.Exec(`INSERT INTO product_colors (product_id, color_id) VALUES ($1, $2)`, id, c)
+		rowsAffected, err := result.RowsAffected()
 		if err != nil {
-			return format.Error(op, err)
+			return err
+		}
+		if rowsAffected == 0 {
+			return checkVersion(ctx, tx, "products", id, fmt.Errorf("product with id %s: %w", id, ErrNotFound))
 		}
-	}
 
-	for _, s := range p.Seems {
-		_, err := d.Driver.Exec(`INSERT INTO product_seems (product_id, similar_product_id) VALUES ($1, $2)`, id, s)
-		if err != nil {
-			return format.Error(op, err)
+		if p.ReplacePhotos {
+			if err := replacePhotos(ctx, tx, id, p.Photos); err != nil {
+				return err
+			}
 		}
-	}
 
-	return nil
+		// Сначала удаляем старые связи
+		for _, table := range []string{"product_materials", "product_colors", "product_seems"} {
+			if _, err := tx.ExecContext(ctx, `DELETE FROM `+table+` WHERE product_id = $1`, id); err != nil {
+				return err
+			}
+		}
+		// Добавляем новые
+		for _, m := range p.Materials {
+			if _, err := tx.ExecContext(ctx, `INSERT INTO product_materials (product_id, material_id) VALUES ($1, $2)`, id, m); err != nil {
+				return err
+			}
+		}
+		for _, c := range p.Colors {
+			if _, err := tx.ExecContext(ctx, `INSERT INTO product_colors (product_id, color_id) VALUES ($1, $2)`, id, c); err != nil {
+				return err
+			}
+		}
+		for _, s := range p.Seems {
+			if _, err := tx.ExecContext(ctx, `INSERT INTO product_seems (product_id, similar_product_id) VALUES ($1, $2)`, id, s); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
 }
 
 // DeleteProduct product
-func (d Driver) DeleteProduct(id string) error {
+func (d Driver) DeleteProduct(ctx context.Context, id string) error {
 	const op = "PostgresDb.DeleteProduct"
-	_, err := d.Driver.Exec(`DELETE FROM products WHERE id = $1`, id)
+	_, err := d.Driver.ExecContext(ctx, `DELETE FROM products WHERE id = $1`, id)
 	return format.Error(op, err)
 }
 
-func (d Driver) FilterProducts(filter *views.ProductFilter) ([]views.Product, error) {
+func (d Driver) FilterProducts(ctx context.Context, filter *views.ProductFilter) ([]views.Product, error) {
 	const op = "PostgresDb.FilterProducts"
 
 	var conditions []string
@@ -543,7 +568,7 @@ func (d Driver) FilterProducts(filter *views.ProductFilter) ([]views.Product, er
 
 	query := `
 		SELECT id, title, article, brand_id, category_id, country_id,
-			   width, height, depth, photos, price, description
+			   width, height, depth, photos, price, description, version, extract(epoch FROM updated_at)::bigint
 		FROM products
 	`
 	if len(conditions) > 0 {
@@ -574,7 +599,7 @@ func (d Driver) FilterProducts(filter *views.ProductFilter) ([]views.Product, er
 	}
 
 	// Выполнение запроса
-	rows, err := d.Driver.Query(query, args...)
+	rows, err := d.Driver.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, format.Error(op, err)
 	}
@@ -594,8 +619,8 @@ func (d Driver) FilterProducts(filter *views.ProductFilter) ([]views.Product, er
 		if err := rows.Scan(&rp.product.Id, &rp.product.Title, &rp.product.Article,
 			&rp.brandID, &rp.categoryID, &rp.countryID,
 			&rp.product.Width, &rp.product.Height, &rp.product.Depth,
-			pq.Array(&rp.product.Photos), &rp.product.Price, &rp.product.Description); err != nil {
-			log.Println(format.Error(op, err))
+			pq.Array(&rp.product.Photos), &rp.product.Price, &rp.product.Description, &rp.product.Version, &rp.product.UpdatedAt); err != nil {
+			slog.ErrorContext(ctx, op, "err", err)
 			continue
 		}
 		rawList = append(rawList, rp)
@@ -603,11 +628,12 @@ func (d Driver) FilterProducts(filter *views.ProductFilter) ([]views.Product, er
 
 	var result []views.Product
 	for _, r := range rawList {
-		r.product.Brand = fetchBrand(d.Driver, r.brandID)
-		r.product.Category = fetchCategory(d.Driver, r.categoryID)
-		r.product.Country = fetchCountry(d.Driver, r.countryID)
-		r.product.Materials = fetchMaterialsByProductID(d.Driver, r.product.Id)
-		r.product.Colors = fetchColorsByProductID(d.Driver, r.product.Id)
+		r.product.Brand = fetchBrand(ctx, d.Driver, r.brandID)
+		r.product.Category = fetchCategory(ctx, d.Driver, r.categoryID)
+		r.product.Country = fetchCountry(ctx, d.Driver, r.countryID)
+		r.product.Materials = fetchMaterialsByProductID(ctx, d.Driver, r.product.Id)
+		r.product.Colors = fetchColorsByProductID(ctx, d.Driver, r.product.Id)
+		r.product.Gallery = fetchPhotosByProductID(ctx, d.Driver, r.product.Id)
 		r.product.Seems = nil
 		result = append(result, r.product)
 	}
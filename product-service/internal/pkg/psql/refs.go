@@ -0,0 +1,104 @@
+package psql
+
+import (
+	"context"
+	"fmt"
+	"productService/internal/pkg/validate"
+	"productService/internal/utils/format"
+	"productService/internal/views"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// Ref is table which other entities refer to
+type Ref string
+
+const (
+	RefProducts   Ref = "products"
+	RefBrands     Ref = "brands"
+	RefCategories Ref = "categories"
+	RefCountries  Ref = "countries"
+	RefMaterials  Ref = "materials"
+	RefColors     Ref = "colors"
+)
+
+// CheckProduct add violations of product which need database: article taken by other product
+// and references to missing entities. Error is returned only if database fail
+func (d Driver) CheckProduct(ctx context.Context, p *views.ProductId, v *validate.Errors) error {
+	const op = "PostgresDb.CheckProduct"
+
+	var taken bool
+	err := d.Driver.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM products WHERE article = $1 AND id <> $2)`,
+		p.Article, p.Id).Scan(&taken)
+	if err != nil {
+		return format.Error(op, err)
+	}
+	if taken {
+		v.Add("article", "is already used by other product")
+	}
+
+	for _, c := range []struct {
+		ref   Ref
+		field string
+		ids   []string
+	}{
+		{RefBrands, "brand", []string{p.Brand}},
+		{RefCategories, "category", []string{p.Category}},
+		{RefCountries, "country", []string{p.Country}},
+		{RefMaterials, "materials[%d]", p.Materials},
+		{RefColors, "colors[%d]", p.Colors},
+		{RefProducts, "seems[%d]", p.Seems},
+	} {
+		if err := d.CheckRefs(ctx, v, c.ref, c.field, c.ids...); err != nil {
+			return format.Error(op, err)
+		}
+	}
+	return nil
+}
+
+// CheckRefs add violation for every id missing in table of ref. Empty ids are skipped, they are
+// checked by validate. Field of list has place for index, like "colors[%d]"
+func (d Driver) CheckRefs(ctx context.Context, v *validate.Errors, ref Ref, field string, ids ...string) error {
+	const op = "PostgresDb.CheckRefs"
+
+	var lookup []string
+	for _, id := range ids {
+		if id != "" {
+			lookup = append(lookup, id)
+		}
+	}
+	if len(lookup) == 0 {
+		return nil
+	}
+
+	rows, err := d.Driver.QueryContext(ctx, `SELECT id FROM `+string(ref)+` WHERE id = ANY($1)`, pq.Array(lookup))
+	if err != nil {
+		return format.Error(op, err)
+	}
+	defer rows.Close()
+
+	found := make(map[string]bool, len(lookup))
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return format.Error(op, err)
+		}
+		found[id] = true
+	}
+	if err := rows.Err(); err != nil {
+		return format.Error(op, err)
+	}
+
+	for i, id := range ids {
+		if id == "" || found[id] {
+			continue
+		}
+		name := field
+		if strings.Contains(field, "%d") {
+			name = fmt.Sprintf(field, i)
+		}
+		v.Add(name, fmt.Sprintf("%s not found", id))
+	}
+	return nil
+}
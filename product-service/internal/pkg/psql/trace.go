@@ -0,0 +1,65 @@
+package psql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("productService/psql")
+
+// Traced wrap db so every query is span with its statement. So N+1 queries of one call are
+// seen in trace one by one
+func Traced(db SqlRepo) SqlRepo {
+	return tracedRepo{db: db}
+}
+
+type tracedRepo struct {
+	db SqlRepo
+}
+
+func (t tracedRepo) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	ctx, span := startQuery(ctx, "db.Query", query)
+	defer span.End()
+	rows, err := t.db.QueryContext(ctx, query, args...)
+	endQuery(span, err)
+	return rows, err
+}
+
+func (t tracedRepo) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	ctx, span := startQuery(ctx, "db.Exec", query)
+	defer span.End()
+	res, err := t.db.ExecContext(ctx, query, args...)
+	endQuery(span, err)
+	return res, err
+}
+
+// QueryRowContext span cover only execution of query, error of row is known after Scan
+func (t tracedRepo) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	ctx, span := startQuery(ctx, "db.QueryRow", query)
+	defer span.End()
+	return t.db.QueryRowContext(ctx, query, args...)
+}
+
+func startQuery(ctx context.Context, name, query string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system.name", "postgresql"),
+			attribute.String("db.query.text", strings.Join(strings.Fields(query), " ")),
+		),
+	)
+}
+
+func endQuery(span trace.Span, err error) {
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
@@ -0,0 +1,44 @@
+package psql
+
+import (
+	"context"
+	"database/sql"
+)
+
+// inTx run fn in transaction, it is committed when fn return nil and rolled back otherwise.
+// When db is already transaction (tests use *sql.Tx) fn just run in it
+func inTx(ctx context.Context, db SqlRepo, fn func(tx SqlRepo) error) error {
+	repo, tx, err := beginTx(ctx, db)
+	if err != nil {
+		return err
+	}
+	if tx == nil {
+		return fn(repo)
+	}
+
+	if err := fn(repo); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// beginTx return repo working in new transaction and the transaction itself. tx is nil
+// when db can not begin transaction, then db is returned as is
+func beginTx(ctx context.Context, db SqlRepo) (SqlRepo, *sql.Tx, error) {
+	switch d := db.(type) {
+	case *sql.DB:
+		tx, err := d.BeginTx(ctx, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		return tx, tx, nil
+	case tracedRepo:
+		repo, tx, err := beginTx(ctx, d.db)
+		if err != nil || tx == nil {
+			return db, nil, err
+		}
+		return tracedRepo{db: repo}, tx, nil
+	}
+	return db, nil, nil
+}
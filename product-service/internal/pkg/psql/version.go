@@ -0,0 +1,31 @@
+package psql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// VersionConflict is returned by update when row was changed by somebody else since client read it
+type VersionConflict struct {
+	Current int64
+}
+
+func (e *VersionConflict) Error() string {
+	return fmt.Sprintf("version conflict, current version is %d", e.Current)
+}
+
+// checkVersion tell why versioned update changed no rows: row is missing (notFound is returned)
+// or it has other version
+func checkVersion(ctx context.Context, db SqlRepo, table, id string, notFound error) error {
+	var current int64
+	err := db.QueryRowContext(ctx, `SELECT version FROM `+table+` WHERE id = $1`, id).Scan(&current)
+	if errors.Is(err, sql.ErrNoRows) {
+		return notFound
+	}
+	if err != nil {
+		return err
+	}
+	return &VersionConflict{Current: current}
+}
@@ -0,0 +1,63 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+// Config of exporting spans. Spans are created and trace context is propagated even when
+// Endpoint is empty, then they are just not exported
+type Config struct {
+	// Endpoint is host:port of OTLP gRPC collector, like jaeger:4317
+	Endpoint string `mapstructure:"endpoint"`
+	// Insecure disable TLS to collector
+	Insecure bool `mapstructure:"insecure"`
+	// SampleRatio is part of traces which are recorded, from 0 to 1. Default is 1
+	SampleRatio float64 `mapstructure:"sample_ratio"`
+}
+
+// Setup make tracer provider global and return function which flush and stop it
+func Setup(ctx context.Context, cfg Config, service string) (func(context.Context) error, error) {
+	var opts []sdktrace.TracerProviderOption
+	if cfg.Endpoint != "" {
+		exp, err := otlptracegrpc.New(ctx, exporterOptions(cfg)...)
+		if err != nil {
+			return nil, fmt.Errorf("otlp exporter: %w", err)
+		}
+		opts = append(opts, sdktrace.WithBatcher(exp))
+	}
+
+	tp := NewProvider(service, cfg.SampleRatio, opts...)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return tp.Shutdown, nil
+}
+
+// NewProvider construct tracer provider of service. Tests pass in-memory exporter with
+// sdktrace.WithSyncer(tracetest.NewInMemoryExporter())
+func NewProvider(service string, ratio float64, opts ...sdktrace.TracerProviderOption) *sdktrace.TracerProvider {
+	if ratio <= 0 || ratio > 1 {
+		ratio = 1
+	}
+	res := resource.NewSchemaless(semconv.ServiceName(service))
+	opts = append([]sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+	}, opts...)
+	return sdktrace.NewTracerProvider(opts...)
+}
+
+func exporterOptions(cfg Config) []otlptracegrpc.Option {
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+	if cfg.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+	return opts
+}
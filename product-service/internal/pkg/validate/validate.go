@@ -0,0 +1,195 @@
+package validate
+
+import (
+	"fmt"
+	"productService/internal/views"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxTitle is max length of titles and names in runes
+const MaxTitle = 255
+
+var (
+	articleRe = regexp.MustCompile(`^[0-9]{8}$`)
+	hexRe     = regexp.MustCompile(`^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+	// uriRe is path of category in storefront: lowercase latin words with dashes, may be nested
+	uriRe = regexp.MustCompile(`^/?[a-z0-9]+(?:-[a-z0-9]+)*(?:/[a-z0-9]+(?:-[a-z0-9]+)*)*$`)
+)
+
+// Violation is problem with one field. Field is named as in gateway json, with index for lists
+type Violation struct {
+	Field   string
+	Message string
+}
+
+// Errors collect violations of request. It is returned as error only when not empty (see Err)
+type Errors struct {
+	Violations []Violation
+}
+
+func (e *Errors) Error() string {
+	parts := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		parts = append(parts, v.Field+": "+v.Message)
+	}
+	return "invalid request: " + strings.Join(parts, "; ")
+}
+
+func (e *Errors) Add(field, msg string) {
+	e.Violations = append(e.Violations, Violation{Field: field, Message: msg})
+}
+
+// Err return e if there are violations, otherwise nil
+func (e *Errors) Err() error {
+	if len(e.Violations) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Product check fields of product which can be checked without database
+func Product(p *views.ProductId) *Errors {
+	e := &Errors{}
+	required(e, "id", p.Id)
+	title(e, "title", p.Title)
+	if !articleRe.MatchString(p.Article) {
+		e.Add("article", "must be 8 digits")
+	}
+	required(e, "brand", p.Brand)
+	required(e, "category", p.Category)
+	required(e, "country", p.Country)
+	nonNegative(e, "price", p.Price)
+	nonNegative(e, "width", p.Width)
+	nonNegative(e, "height", p.Height)
+	nonNegative(e, "depth", p.Depth)
+	list(e, "materials", p.Materials)
+	list(e, "colors", p.Colors)
+	list(e, "seems", p.Seems)
+	for i, s := range p.Seems {
+		if s == p.Id {
+			e.Add(fmt.Sprintf("seems[%d]", i), "product can not be similar to itself")
+		}
+	}
+	return e
+}
+
+func Brand(b *views.Brand) *Errors {
+	e := &Errors{}
+	required(e, "id", b.Id)
+	title(e, "name", b.Name)
+	return e
+}
+
+func Category(c *views.Category) *Errors {
+	e := &Errors{}
+	required(e, "id", c.Id)
+	title(e, "title", c.Title)
+	if !uriRe.MatchString(c.Uri) {
+		e.Add("uri", "must be lowercase latin letters, digits and dashes, parts separated by /")
+	}
+	return e
+}
+
+func Country(c *views.Country) *Errors {
+	e := &Errors{}
+	required(e, "id", c.Id)
+	title(e, "title", c.Title)
+	if utf8.RuneCountInString(c.Friendly) > MaxTitle {
+		e.Add("friendly", fmt.Sprintf("must be at most %d characters", MaxTitle))
+	}
+	return e
+}
+
+func Material(m *views.Material) *Errors {
+	e := &Errors{}
+	required(e, "id", m.Id)
+	title(e, "title", m.Title)
+	return e
+}
+
+func Color(c *views.Color) *Errors {
+	e := &Errors{}
+	required(e, "id", c.Id)
+	title(e, "name", c.Name)
+	if !hexRe.MatchString(c.Hex) {
+		e.Add("hex", "must be hex color like #A1B2C3")
+	}
+	return e
+}
+
+func ProductColorPhotos(p *views.ProductColorPhotos) *Errors {
+	e := &Errors{}
+	required(e, "product_id", p.ProductId)
+	required(e, "color_id", p.ColorId)
+	return e
+}
+
+// ProductPhoto check photo on update, only id is needed there
+func ProductPhoto(p *views.ProductPhoto) *Errors {
+	e := &Errors{}
+	required(e, "id", p.Id)
+	return e
+}
+
+// NewProductPhoto check photo added to product
+func NewProductPhoto(p *views.ProductPhoto) *Errors {
+	e := ProductPhoto(p)
+	required(e, "product_id", p.ProductId)
+	required(e, "file", p.File)
+	return e
+}
+
+// AdminRoles are roles of gateway admins, same as in gateway/internal/pkg/auth/roles.go
+var AdminRoles = []string{"owner", "content", "pricing"}
+
+// Admin check admin on update, empty password hash keep old one there
+func Admin(u *views.AdminUser) *Errors {
+	e := &Errors{}
+	required(e, "login", u.Login)
+	if !slices.Contains(AdminRoles, u.Role) {
+		e.Add("role", "is unknown")
+	}
+	return e
+}
+
+func NewAdmin(u *views.AdminUser) *Errors {
+	e := Admin(u)
+	required(e, "password_hash", u.PasswordHash)
+	return e
+}
+
+func required(e *Errors, field, v string) {
+	if strings.TrimSpace(v) == "" {
+		e.Add(field, "is required")
+	}
+}
+
+func title(e *Errors, field, v string) {
+	required(e, field, v)
+	if utf8.RuneCountInString(v) > MaxTitle {
+		e.Add(field, fmt.Sprintf("must be at most %d characters", MaxTitle))
+	}
+}
+
+func nonNegative(e *Errors, field string, v int) {
+	if v < 0 {
+		e.Add(field, "must not be negative")
+	}
+}
+
+// list check that ids in list are set and not repeated
+func list(e *Errors, field string, ids []string) {
+	seen := make(map[string]bool, len(ids))
+	for i, id := range ids {
+		switch {
+		case id == "":
+			e.Add(fmt.Sprintf("%s[%d]", field, i), "is required")
+		case seen[id]:
+			e.Add(fmt.Sprintf("%s[%d]", field, i), "is repeated")
+		}
+		seen[id] = true
+	}
+}
@@ -0,0 +1,59 @@
+package validate
+
+import (
+	"productService/internal/views"
+	"reflect"
+	"testing"
+)
+
+func fields(e *Errors) []string {
+	var out []string
+	for _, v := range e.Violations {
+		out = append(out, v.Field)
+	}
+	return out
+}
+
+func TestProduct(t *testing.T) {
+	p := &views.ProductId{
+		Id: "p1", Title: "Sofa", Article: "12345678", Brand: "b1", Category: "c1", Country: "ct1",
+		Width: 10, Colors: []string{"red"}, Seems: []string{"p2"},
+	}
+	if err := Product(p).Err(); err != nil {
+		t.Fatalf("valid product: %v", err)
+	}
+
+	p.Article = "1234567a"
+	p.Price = -1
+	p.Depth = -5
+	p.Colors = []string{"red", "red"}
+	p.Seems = []string{"p1"}
+	p.Country = ""
+	want := []string{"article", "country", "price", "depth", "colors[1]", "seems[0]"}
+	if got := fields(Product(p)); !reflect.DeepEqual(got, want) {
+		t.Fatalf("fields = %v, want %v", got, want)
+	}
+}
+
+func TestDictionaries(t *testing.T) {
+	tests := []struct {
+		name string
+		e    *Errors
+		want []string
+	}{
+		{"color ok", Color(&views.Color{Id: "1", Name: "Red", Hex: "#FF0000"}), nil},
+		{"color short hex", Color(&views.Color{Id: "1", Name: "Red", Hex: "#f00"}), nil},
+		{"color bad hex", Color(&views.Color{Id: "1", Name: "Red", Hex: "red"}), []string{"hex"}},
+		{"category ok", Category(&views.Category{Id: "1", Title: "Sofas", Uri: "living-room/sofas"}), nil},
+		{"category bad uri", Category(&views.Category{Id: "1", Title: "Sofas", Uri: "Sofas and chairs"}), []string{"uri"}},
+		{"brand empty", Brand(&views.Brand{Id: "1", Name: " "}), []string{"name"}},
+		{"photo", NewProductPhoto(&views.ProductPhoto{Id: "1"}), []string{"product_id", "file"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fields(tt.e); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
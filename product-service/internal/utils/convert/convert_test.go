@@ -144,3 +144,19 @@ func TestToRPCProductSlice(t *testing.T) {
 		t.Errorf("ToRPCProductSlice failed: %+v", rpc)
 	}
 }
+
+func TestProductGalleryConversion(t *testing.T) {
+	original := &views.Product{
+		Id: "p1",
+		Gallery: []views.ProductPhoto{
+			{Id: "ph1", ProductId: "p1", File: "a.jpg", Position: 0, Alt: "front", IsMain: true},
+			{Id: "ph2", ProductId: "p1", File: "b.jpg", Position: 1, ColorId: "c1"},
+		},
+	}
+
+	converted := ToProductView(ToRPCProduct(original))
+
+	if !reflect.DeepEqual(original.Gallery, converted.Gallery) {
+		t.Errorf("Gallery conversion failed.\nOriginal: %+v\nConverted: %+v", original.Gallery, converted.Gallery)
+	}
+}
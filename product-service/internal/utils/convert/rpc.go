@@ -55,9 +55,12 @@ func ToProductList(list []views.Product) any {
 			Materials:   materials,
 			Colors:      colors,
 			Photos:      p.Photos,
+			Gallery:     ToRPCPhotoList(p.Gallery),
 			Seems:       ToRPCProductSlice(p.Seems),
 			Price:       int32(p.Price),
 			Description: p.Description,
+			Version:     p.Version,
+			UpdatedAt:   p.UpdatedAt,
 		})
 	}
 
@@ -89,9 +92,12 @@ func ToRPCProduct(p *views.Product) *productsRPC.Product {
 		Materials:   ToRPCMaterialList(p.Materials),
 		Colors:      ToRPCColorList(p.Colors),
 		Photos:      p.Photos,
+		Gallery:     ToRPCPhotoList(p.Gallery),
 		Seems:       ToRPCProductSlice(p.Seems),
 		Price:       int32(p.Price),
 		Description: p.Description,
+		Version:     p.Version,
+		UpdatedAt:   p.UpdatedAt,
 	}
 }
 
@@ -153,8 +159,9 @@ func ToBrandList(bv []views.Brand) any {
 
 	for _, b := range bv {
 		bl = append(bl, &productsRPC.Brand{
-			Id:   b.Id,
-			Name: b.Name,
+			Id:      b.Id,
+			Name:    b.Name,
+			Version: b.Version,
 		})
 	}
 	return &productsRPC.BrandList{Brands: bl}
@@ -165,10 +172,11 @@ func ToCategoryList(bv []views.Category) any {
 
 	for _, b := range bv {
 		bl = append(bl, &productsRPC.Category{
-			Id:    b.Id,
-			Title: b.Title,
-			Uri:   b.Uri,
-			Img:   b.Img,
+			Id:      b.Id,
+			Title:   b.Title,
+			Uri:     b.Uri,
+			Img:     b.Img,
+			Version: b.Version,
 		})
 	}
 	return &productsRPC.CategoryList{Categories: bl}
@@ -182,6 +190,7 @@ func ToCountryList(bv []views.Country) any {
 			Id:       b.Id,
 			Title:    b.Title,
 			Friendly: b.Friendly,
+			Version:  b.Version,
 		})
 	}
 	return &productsRPC.CountryList{Countries: bl}
@@ -192,8 +201,9 @@ func ToMaterialList(bv []views.Material) any {
 
 	for _, b := range bv {
 		bl = append(bl, &productsRPC.Material{
-			Id:    b.Id,
-			Title: b.Title,
+			Id:      b.Id,
+			Title:   b.Title,
+			Version: b.Version,
 		})
 	}
 	return &productsRPC.MaterialList{Materials: bl}
@@ -204,9 +214,10 @@ func ToColorList(bv []views.Color) any {
 
 	for _, b := range bv {
 		bl = append(bl, &productsRPC.Color{
-			Id:   b.Id,
-			Name: b.Name,
-			Hex:  b.Hex,
+			Id:      b.Id,
+			Name:    b.Name,
+			Hex:     b.Hex,
+			Version: b.Version,
 		})
 	}
 	return &productsRPC.ColorList{Colors: bl}
@@ -224,3 +235,44 @@ func ToProductColorList(bv []views.ProductColorPhotos) any {
 	}
 	return &productsRPC.ProductColorPhotosList{Items: bl}
 }
+
+func ToRPCPhoto(p *views.ProductPhoto) *productsRPC.ProductPhoto {
+	return &productsRPC.ProductPhoto{
+		Id:        p.Id,
+		ProductId: p.ProductId,
+		File:      p.File,
+		Position:  int32(p.Position),
+		Alt:       p.Alt,
+		IsMain:    p.IsMain,
+		ColorId:   p.ColorId,
+	}
+}
+
+func ToRPCPhotoList(list []views.ProductPhoto) []*productsRPC.ProductPhoto {
+	var out []*productsRPC.ProductPhoto
+	for _, p := range list {
+		out = append(out, ToRPCPhoto(&p))
+	}
+	return out
+}
+
+func ToProductPhotoList(list []views.ProductPhoto) any {
+	return &productsRPC.ProductPhotoList{Photos: ToRPCPhotoList(list)}
+}
+
+func ToRPCAdmin(u *views.AdminUser) *productsRPC.AdminUser {
+	return &productsRPC.AdminUser{
+		Login:        u.Login,
+		Role:         u.Role,
+		PasswordHash: u.PasswordHash,
+		CreatedAt:    u.CreatedAt,
+	}
+}
+
+func ToAdminList(list []views.AdminUser) any {
+	var out []*productsRPC.AdminUser
+	for _, u := range list {
+		out = append(out, ToRPCAdmin(&u))
+	}
+	return &productsRPC.AdminUserList{Admins: out}
+}
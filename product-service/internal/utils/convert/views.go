@@ -30,29 +30,34 @@ func ToProductView(req *productsRPC.Product) *views.Product {
 		Materials:   ToMaterialView(req.GetMaterials()),
 		Colors:      ToColorView(req.GetColors()),
 		Photos:      req.GetPhotos(),
+		Gallery:     ToPhotoView(req.GetGallery()),
 		Seems:       ToViewsProductSlice(req.GetSeems()),
 		Price:       int(req.GetPrice()),
 		Description: req.GetDescription(),
+		Version:     req.GetVersion(),
+		UpdatedAt:   req.GetUpdatedAt(),
 	}
 }
 
 func ToProductViewId(req *productsRPC.ProductId) *views.ProductId {
 	return &views.ProductId{
-		Id:          req.GetId(),
-		Title:       req.GetTitle(),
-		Article:     req.GetArticle(),
-		Brand:       req.GetBrand(),
-		Category:    req.GetCategory(),
-		Country:     req.GetCountry(),
-		Width:       int(req.GetWidth()),
-		Height:      int(req.GetHeight()),
-		Depth:       int(req.GetDepth()),
-		Materials:   req.GetMaterials(),
-		Colors:      req.GetColors(),
-		Photos:      req.GetPhotos(),
-		Seems:       req.GetSeems(),
-		Price:       int(req.GetPrice()),
-		Description: req.GetDescription(),
+		Id:            req.GetId(),
+		Title:         req.GetTitle(),
+		Article:       req.GetArticle(),
+		Brand:         req.GetBrand(),
+		Category:      req.GetCategory(),
+		Country:       req.GetCountry(),
+		Width:         int(req.GetWidth()),
+		Height:        int(req.GetHeight()),
+		Depth:         int(req.GetDepth()),
+		Materials:     req.GetMaterials(),
+		Colors:        req.GetColors(),
+		Photos:        req.GetPhotos(),
+		Seems:         req.GetSeems(),
+		Price:         int(req.GetPrice()),
+		Description:   req.GetDescription(),
+		Version:       req.GetVersion(),
+		ReplacePhotos: req.GetReplacePhotos(),
 	}
 }
 
@@ -73,6 +78,7 @@ func ToProductViewIdFromProduct(req *productsRPC.Product) *views.ProductId {
 		Seems:       ToViewsProductIdSlice(req.GetSeems()),
 		Price:       int(req.GetPrice()),
 		Description: req.GetDescription(),
+		Version:     req.GetVersion(),
 	}
 }
 
@@ -130,3 +136,32 @@ func ToStringIds[T interface{ GetId() string }](in []T) []string {
 	}
 	return ids
 }
+
+func ToProductPhotoView(p *productsRPC.ProductPhoto) *views.ProductPhoto {
+	return &views.ProductPhoto{
+		Id:        p.GetId(),
+		ProductId: p.GetProductId(),
+		File:      p.GetFile(),
+		Position:  int(p.GetPosition()),
+		Alt:       p.GetAlt(),
+		IsMain:    p.GetIsMain(),
+		ColorId:   p.GetColorId(),
+	}
+}
+
+func ToPhotoView(in []*productsRPC.ProductPhoto) []views.ProductPhoto {
+	var out []views.ProductPhoto
+	for _, p := range in {
+		out = append(out, *ToProductPhotoView(p))
+	}
+	return out
+}
+
+func ToAdminView(u *productsRPC.AdminUser) *views.AdminUser {
+	return &views.AdminUser{
+		Login:        u.GetLogin(),
+		Role:         u.GetRole(),
+		PasswordHash: u.GetPasswordHash(),
+		CreatedAt:    u.GetCreatedAt(),
+	}
+}
@@ -1,7 +1,6 @@
 package format
 
 import (
-	"encoding/json"
 	"fmt"
 )
 
@@ -12,15 +11,3 @@ func Error(op string, err error) error {
 	}
 	return fmt.Errorf("OP: %s: ERROR: %w", op, err)
 }
-
-func Struct(in any) string {
-	j, err := json.MarshalIndent(in, "", "🦷")
-	if err != nil {
-		return ""
-	}
-	return string(j)
-}
-
-func String(op string, input any) string {
-	return fmt.Sprintf("%s: %s", op, fmt.Sprint(input))
-}
@@ -13,9 +13,14 @@ type Product struct {
 	Materials   []Material
 	Colors      []Color
 	Photos      []string
+	Gallery     []ProductPhoto
 	Seems       []Product
 	Price       int
 	Description string
+	// Version grow on every change of product itself, UpdatedAt (unix seconds) on any change
+	// of what is returned with product
+	Version   int64
+	UpdatedAt int64
 }
 
 type ProductId struct {
@@ -34,33 +39,42 @@ type ProductId struct {
 	Seems       []string
 	Price       int
 	Description string
+	// Version is version of product update is based on
+	Version int64
+	// ReplacePhotos tell update to rebuild gallery from Photos, without it gallery is not touched
+	ReplacePhotos bool
 }
 type Brand struct {
-	Id   string
-	Name string
+	Id      string
+	Name    string
+	Version int64
 }
 type Category struct {
-	Id    string
-	Title string
-	Uri   string
-	Img   string
+	Id      string
+	Title   string
+	Uri     string
+	Img     string
+	Version int64
 }
 
 type Country struct {
 	Id       string
 	Title    string
 	Friendly string
+	Version  int64
 }
 
 type Material struct {
-	Id    string
-	Title string
+	Id      string
+	Title   string
+	Version int64
 }
 
 type Color struct {
-	Id   string
-	Name string
-	Hex  string
+	Id      string
+	Name    string
+	Hex     string
+	Version int64
 }
 
 type ProductFilter struct {
@@ -98,6 +112,10 @@ type Dictionaries struct {
 	MaxHeight int
 	MinDepth  int
 	MaxDepth  int
+
+	// Version and UpdatedAt (unix seconds) of whole catalog
+	Version   int64
+	UpdatedAt int64
 }
 
 type ProductSearch struct {
@@ -110,3 +128,21 @@ type ProductColorPhotos struct {
 	ColorId   string
 	Photos    []string
 }
+
+type AdminUser struct {
+	Login        string
+	Role         string
+	PasswordHash string
+	// CreatedAt is unix seconds
+	CreatedAt int64
+}
+
+type ProductPhoto struct {
+	Id        string
+	ProductId string
+	File      string
+	Position  int
+	Alt       string
+	IsMain    bool
+	ColorId   string
+}
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS product_photos
+(
+    id         TEXT PRIMARY KEY,
+    product_id TEXT    NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    file       TEXT    NOT NULL,
+    position   INT     NOT NULL DEFAULT 0,
+    alt        TEXT    NOT NULL DEFAULT '',
+    is_main    BOOLEAN NOT NULL DEFAULT FALSE,
+    color_id   TEXT    REFERENCES colors (id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS product_photos_product_idx ON product_photos (product_id, position);
+CREATE UNIQUE INDEX IF NOT EXISTS product_photos_main_idx ON product_photos (product_id) WHERE is_main;
+
+-- переносим старый список photos, первая фотография становится главной.
+-- id собирается из md5 так, чтобы он оставался корректным xid (20 символов base32hex)
+INSERT INTO product_photos (id, product_id, file, position, is_main)
+SELECT left(md5(p.id || ':' || ph.ord), 19) || '0', p.id, ph.file, ph.ord - 1, ph.ord = 1
+FROM products p,
+     unnest(p.photos) WITH ORDINALITY AS ph(file, ord);
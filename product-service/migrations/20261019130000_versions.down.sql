@@ -0,0 +1,29 @@
+DROP TRIGGER IF EXISTS products_catalog_state ON products;
+DROP TRIGGER IF EXISTS colors_catalog_state ON colors;
+DROP TRIGGER IF EXISTS materials_catalog_state ON materials;
+DROP TRIGGER IF EXISTS countries_catalog_state ON countries;
+DROP TRIGGER IF EXISTS categories_catalog_state ON categories;
+DROP TRIGGER IF EXISTS brands_catalog_state ON brands;
+DROP TRIGGER IF EXISTS colors_touch_products ON colors;
+DROP TRIGGER IF EXISTS materials_touch_products ON materials;
+DROP TRIGGER IF EXISTS countries_touch_products ON countries;
+DROP TRIGGER IF EXISTS categories_touch_products ON categories;
+DROP TRIGGER IF EXISTS brands_touch_products ON brands;
+DROP TRIGGER IF EXISTS product_seems_touch ON product_seems;
+DROP TRIGGER IF EXISTS product_colors_touch ON product_colors;
+DROP TRIGGER IF EXISTS product_materials_touch ON product_materials;
+DROP TRIGGER IF EXISTS product_photos_touch ON product_photos;
+DROP TRIGGER IF EXISTS products_touch_seems ON products;
+DROP TRIGGER IF EXISTS products_touch ON products;
+
+DROP FUNCTION IF EXISTS touch_products_with_seem();
+DROP FUNCTION IF EXISTS touch_products_of_dict();
+DROP FUNCTION IF EXISTS touch_product_of_row();
+DROP FUNCTION IF EXISTS bump_catalog_state();
+DROP FUNCTION IF EXISTS touch_updated_at();
+
+DROP TABLE IF EXISTS catalog_state;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS version;
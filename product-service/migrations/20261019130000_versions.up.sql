@@ -0,0 +1,126 @@
+-- version меняется только при изменении самого продукта (используется для конкурентных правок),
+-- updated_at меняется при любом изменении того, что попадает в ответ с продуктом
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS version    BIGINT      NOT NULL DEFAULT 1,
+    ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+-- состояние всего каталога, по нему отдаются версии справочников
+CREATE TABLE IF NOT EXISTS catalog_state
+(
+    id         INT PRIMARY KEY CHECK (id = 1),
+    version    BIGINT      NOT NULL DEFAULT 1,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+INSERT INTO catalog_state (id) VALUES (1) ON CONFLICT DO NOTHING;
+
+CREATE OR REPLACE FUNCTION touch_updated_at() RETURNS trigger AS
+$$
+BEGIN
+    NEW.updated_at = now();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION bump_catalog_state() RETURNS trigger AS
+$$
+BEGIN
+    UPDATE catalog_state SET version = version + 1, updated_at = now() WHERE id = 1;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- изменение связанных с продуктом строк (фото, материалы, цвета, похожие) трогает продукт
+CREATE OR REPLACE FUNCTION touch_product_of_row() RETURNS trigger AS
+$$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        UPDATE products SET updated_at = now() WHERE id = OLD.product_id;
+    ELSE
+        UPDATE products SET updated_at = now() WHERE id = NEW.product_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- изменение справочника трогает все продукты, в которые он встроен. TG_ARGV[0] колонка в products,
+-- TG_ARGV[1] и TG_ARGV[2] таблица связи и её колонка, если справочник связан через неё
+CREATE OR REPLACE FUNCTION touch_products_of_dict() RETURNS trigger AS
+$$
+BEGIN
+    IF TG_ARGV[0] <> '' THEN
+        EXECUTE format('UPDATE products SET updated_at = now() WHERE %I = $1', TG_ARGV[0]) USING OLD.id;
+    ELSE
+        EXECUTE format('UPDATE products SET updated_at = now() WHERE id IN (SELECT product_id FROM %I WHERE %I = $1)',
+                       TG_ARGV[1], TG_ARGV[2]) USING OLD.id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- продукт встроен в похожие других продуктов. Глубина ограничена, так как похожие могут ссылаться друг на друга
+CREATE OR REPLACE FUNCTION touch_products_with_seem() RETURNS trigger AS
+$$
+BEGIN
+    IF pg_trigger_depth() > 1 THEN
+        RETURN NULL;
+    END IF;
+    UPDATE products SET updated_at = now()
+    WHERE id IN (SELECT product_id FROM product_seems WHERE similar_product_id = OLD.id);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER products_touch
+    BEFORE UPDATE ON products
+    FOR EACH ROW EXECUTE FUNCTION touch_updated_at();
+CREATE TRIGGER products_touch_seems
+    AFTER UPDATE OR DELETE ON products
+    FOR EACH ROW EXECUTE FUNCTION touch_products_with_seem();
+
+CREATE TRIGGER product_photos_touch
+    AFTER INSERT OR UPDATE OR DELETE ON product_photos
+    FOR EACH ROW EXECUTE FUNCTION touch_product_of_row();
+CREATE TRIGGER product_materials_touch
+    AFTER INSERT OR UPDATE OR DELETE ON product_materials
+    FOR EACH ROW EXECUTE FUNCTION touch_product_of_row();
+CREATE TRIGGER product_colors_touch
+    AFTER INSERT OR UPDATE OR DELETE ON product_colors
+    FOR EACH ROW EXECUTE FUNCTION touch_product_of_row();
+CREATE TRIGGER product_seems_touch
+    AFTER INSERT OR UPDATE OR DELETE ON product_seems
+    FOR EACH ROW EXECUTE FUNCTION touch_product_of_row();
+
+CREATE TRIGGER brands_touch_products
+    AFTER UPDATE ON brands
+    FOR EACH ROW EXECUTE FUNCTION touch_products_of_dict('brand_id');
+CREATE TRIGGER categories_touch_products
+    AFTER UPDATE ON categories
+    FOR EACH ROW EXECUTE FUNCTION touch_products_of_dict('category_id');
+CREATE TRIGGER countries_touch_products
+    AFTER UPDATE ON countries
+    FOR EACH ROW EXECUTE FUNCTION touch_products_of_dict('country_id');
+CREATE TRIGGER materials_touch_products
+    AFTER UPDATE ON materials
+    FOR EACH ROW EXECUTE FUNCTION touch_products_of_dict('', 'product_materials', 'material_id');
+CREATE TRIGGER colors_touch_products
+    AFTER UPDATE ON colors
+    FOR EACH ROW EXECUTE FUNCTION touch_products_of_dict('', 'product_colors', 'color_id');
+
+CREATE TRIGGER brands_catalog_state
+    AFTER INSERT OR UPDATE OR DELETE ON brands
+    FOR EACH STATEMENT EXECUTE FUNCTION bump_catalog_state();
+CREATE TRIGGER categories_catalog_state
+    AFTER INSERT OR UPDATE OR DELETE ON categories
+    FOR EACH STATEMENT EXECUTE FUNCTION bump_catalog_state();
+CREATE TRIGGER countries_catalog_state
+    AFTER INSERT OR UPDATE OR DELETE ON countries
+    FOR EACH STATEMENT EXECUTE FUNCTION bump_catalog_state();
+CREATE TRIGGER materials_catalog_state
+    AFTER INSERT OR UPDATE OR DELETE ON materials
+    FOR EACH STATEMENT EXECUTE FUNCTION bump_catalog_state();
+CREATE TRIGGER colors_catalog_state
+    AFTER INSERT OR UPDATE OR DELETE ON colors
+    FOR EACH STATEMENT EXECUTE FUNCTION bump_catalog_state();
+CREATE TRIGGER products_catalog_state
+    AFTER INSERT OR UPDATE OR DELETE ON products
+    FOR EACH STATEMENT EXECUTE FUNCTION bump_catalog_state();
@@ -0,0 +1,5 @@
+ALTER TABLE colors DROP COLUMN IF EXISTS version;
+ALTER TABLE materials DROP COLUMN IF EXISTS version;
+ALTER TABLE countries DROP COLUMN IF EXISTS version;
+ALTER TABLE categories DROP COLUMN IF EXISTS version;
+ALTER TABLE brands DROP COLUMN IF EXISTS version;
@@ -0,0 +1,6 @@
+-- версии справочников для защиты от одновременных правок, растут при каждом изменении строки
+ALTER TABLE brands ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE countries ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE materials ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE colors ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
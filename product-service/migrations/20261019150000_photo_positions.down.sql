@@ -0,0 +1,4 @@
+CREATE INDEX IF NOT EXISTS product_photos_product_idx ON product_photos (product_id, position);
+
+ALTER TABLE product_photos
+    DROP CONSTRAINT IF EXISTS product_photos_position_key;
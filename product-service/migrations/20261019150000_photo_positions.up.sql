@@ -0,0 +1,15 @@
+-- позиции внутри продукта уникальны. Перед этим нумеруем заново, одинаковые позиции могли
+-- получиться при одновременном добавлении фото. Ограничение отложенное, так как при
+-- переупорядочивании позиции меняются по одной
+UPDATE product_photos pp
+SET position = r.rn - 1
+FROM (SELECT id, ROW_NUMBER() OVER (PARTITION BY product_id ORDER BY position, id) AS rn
+      FROM product_photos) r
+WHERE pp.id = r.id
+  AND pp.position <> r.rn - 1;
+
+ALTER TABLE product_photos
+    ADD CONSTRAINT product_photos_position_key UNIQUE (product_id, position) DEFERRABLE INITIALLY DEFERRED;
+
+-- индекс ограничения заменяет старый
+DROP INDEX IF EXISTS product_photos_product_idx;
@@ -0,0 +1,9 @@
+-- администраторы gateway. Сессии и счётчики неудачных входов остаются в redis,
+-- они временные и живут с ttl
+CREATE TABLE IF NOT EXISTS admin_users
+(
+    login         TEXT PRIMARY KEY,
+    role          TEXT        NOT NULL CHECK (role IN ('owner', 'content', 'pricing')),
+    password_hash TEXT        NOT NULL,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT now()
+);
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS catalog_state
+(
+    id         INT PRIMARY KEY CHECK (id = 1),
+    version    BIGINT      NOT NULL DEFAULT 1,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+INSERT INTO catalog_state (id, version, updated_at)
+SELECT 1, COALESCE(SUM(weight), 1), COALESCE(MAX(changed_at), now())
+FROM catalog_changes
+ON CONFLICT DO NOTHING;
+
+CREATE OR REPLACE FUNCTION bump_catalog_state() RETURNS trigger AS
+$$
+BEGIN
+    UPDATE catalog_state SET version = version + 1, updated_at = now() WHERE id = 1;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TABLE IF EXISTS catalog_changes;
@@ -0,0 +1,39 @@
+-- одна строка catalog_state блокировалась каждой правкой каталога и выстраивала их в очередь.
+-- Вместо неё каждая правка добавляет строку в журнал, вставки друг друга не ждут. Версия каталога
+-- это сумма весов журнала, она растёт с каждым коммитом в любом порядке. Время изменения - последнее
+CREATE SEQUENCE IF NOT EXISTS catalog_changes_id_seq;
+
+CREATE TABLE IF NOT EXISTS catalog_changes
+(
+    id         BIGINT PRIMARY KEY   DEFAULT nextval('catalog_changes_id_seq'),
+    weight     BIGINT      NOT NULL DEFAULT 1,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+ALTER SEQUENCE catalog_changes_id_seq OWNED BY catalog_changes.id;
+
+INSERT INTO catalog_changes (weight, changed_at)
+SELECT version, updated_at
+FROM catalog_state;
+
+-- каждую тысячную правку журнал сворачивается в одну строку с отрицательным id, сумма весов
+-- не меняется. Строки незавершённых транзакций не видны и остаются как есть
+CREATE OR REPLACE FUNCTION bump_catalog_state() RETURNS trigger AS
+$$
+DECLARE
+    change_id BIGINT;
+BEGIN
+    INSERT INTO catalog_changes DEFAULT VALUES RETURNING id INTO change_id;
+    IF change_id % 1000 = 0 THEN
+        WITH gone AS (
+            DELETE FROM catalog_changes WHERE id < change_id RETURNING weight, changed_at
+        )
+        INSERT INTO catalog_changes (id, weight, changed_at)
+        SELECT -change_id, SUM(weight), MAX(changed_at)
+        FROM gone
+        HAVING COUNT(*) > 0;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TABLE IF EXISTS catalog_state;
@@ -0,0 +1,2530 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: products.proto
+
+package products
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Id struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Id) Reset() {
+	*x = Id{}
+	mi := &file_products_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Id) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Id) ProtoMessage() {}
+
+func (x *Id) ProtoReflect() protoreflect.Message {
+	mi := &file_products_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Id.ProtoReflect.Descriptor instead.
+func (*Id) Descriptor() ([]byte, []int) {
+	return file_products_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Id) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type Brand struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Version       int64                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Brand) Reset() {
+	*x = Brand{}
+	mi := &file_products_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Brand) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Brand) ProtoMessage() {}
+
+func (x *Brand) ProtoReflect() protoreflect.Message {
+	mi := &file_products_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Brand.ProtoReflect.Descriptor instead.
+func (*Brand) Descriptor() ([]byte, []int) {
+	return file_products_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Brand) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Brand) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Brand) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type Category struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Uri           string                 `protobuf:"bytes,3,opt,name=uri,proto3" json:"uri,omitempty"`
+	Img           string                 `protobuf:"bytes,4,opt,name=img,proto3" json:"img,omitempty"`
+	Version       int64                  `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Category) Reset() {
+	*x = Category{}
+	mi := &file_products_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Category) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Category) ProtoMessage() {}
+
+func (x *Category) ProtoReflect() protoreflect.Message {
+	mi := &file_products_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Category.ProtoReflect.Descriptor instead.
+func (*Category) Descriptor() ([]byte, []int) {
+	return file_products_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Category) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Category) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Category) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+func (x *Category) GetImg() string {
+	if x != nil {
+		return x.Img
+	}
+	return ""
+}
+
+func (x *Category) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type Country struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Friendly      string                 `protobuf:"bytes,3,opt,name=friendly,proto3" json:"friendly,omitempty"`
+	Version       int64                  `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Country) Reset() {
+	*x = Country{}
+	mi := &file_products_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Country) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Country) ProtoMessage() {}
+
+func (x *Country) ProtoReflect() protoreflect.Message {
+	mi := &file_products_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Country.ProtoReflect.Descriptor instead.
+func (*Country) Descriptor() ([]byte, []int) {
+	return file_products_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Country) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Country) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Country) GetFriendly() string {
+	if x != nil {
+		return x.Friendly
+	}
+	return ""
+}
+
+func (x *Country) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type Material struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Version       int64                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Material) Reset() {
+	*x = Material{}
+	mi := &file_products_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Material) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Material) ProtoMessage() {}
+
+func (x *Material) ProtoReflect() protoreflect.Message {
+	mi := &file_products_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Material.ProtoReflect.Descriptor instead.
+func (*Material) Descriptor() ([]byte, []int) {
+	return file_products_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Material) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Material) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Material) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type Color struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Hex           string                 `protobuf:"bytes,3,opt,name=hex,proto3" json:"hex,omitempty"`
+	Version       int64                  `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Color) Reset() {
+	*x = Color{}
+	mi := &file_products_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Color) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Color) ProtoMessage() {}
+
+func (x *Color) ProtoReflect() protoreflect.Message {
+	mi := &file_products_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Color.ProtoReflect.Descriptor instead.
+func (*Color) Descriptor() ([]byte, []int) {
+	return file_products_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Color) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Color) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Color) GetHex() string {
+	if x != nil {
+		return x.Hex
+	}
+	return ""
+}
+
+func (x *Color) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type Product struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Article       string                 `protobuf:"bytes,3,opt,name=article,proto3" json:"article,omitempty"`
+	Brand         *Brand                 `protobuf:"bytes,4,opt,name=brand,proto3" json:"brand,omitempty"`
+	Category      *Category              `protobuf:"bytes,5,opt,name=category,proto3" json:"category,omitempty"`
+	Country       *Country               `protobuf:"bytes,6,opt,name=country,proto3" json:"country,omitempty"`
+	Width         int32                  `protobuf:"varint,7,opt,name=width,proto3" json:"width,omitempty"`
+	Height        int32                  `protobuf:"varint,8,opt,name=height,proto3" json:"height,omitempty"`
+	Depth         int32                  `protobuf:"varint,9,opt,name=depth,proto3" json:"depth,omitempty"`
+	Materials     []*Material            `protobuf:"bytes,10,rep,name=materials,proto3" json:"materials,omitempty"`
+	Colors        []*Color               `protobuf:"bytes,11,rep,name=colors,proto3" json:"colors,omitempty"`
+	Photos        []string               `protobuf:"bytes,12,rep,name=photos,proto3" json:"photos,omitempty"`
+	Seems         []*Product             `protobuf:"bytes,13,rep,name=seems,proto3" json:"seems,omitempty"`
+	Price         int32                  `protobuf:"varint,14,opt,name=price,proto3" json:"price,omitempty"`
+	Description   string                 `protobuf:"bytes,15,opt,name=description,proto3" json:"description,omitempty"`
+	Gallery       []*ProductPhoto        `protobuf:"bytes,16,rep,name=gallery,proto3" json:"gallery,omitempty"`
+	Version       int64                  `protobuf:"varint,17,opt,name=version,proto3" json:"version,omitempty"`
+	UpdatedAt     int64                  `protobuf:"varint,18,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Product) Reset() {
+	*x = Product{}
+	mi := &file_products_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Product) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Product) ProtoMessage() {}
+
+func (x *Product) ProtoReflect() protoreflect.Message {
+	mi := &file_products_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Product.ProtoReflect.Descriptor instead.
+func (*Product) Descriptor() ([]byte, []int) {
+	return file_products_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Product) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Product) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Product) GetArticle() string {
+	if x != nil {
+		return x.Article
+	}
+	return ""
+}
+
+func (x *Product) GetBrand() *Brand {
+	if x != nil {
+		return x.Brand
+	}
+	return nil
+}
+
+func (x *Product) GetCategory() *Category {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+func (x *Product) GetCountry() *Country {
+	if x != nil {
+		return x.Country
+	}
+	return nil
+}
+
+func (x *Product) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *Product) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Product) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *Product) GetMaterials() []*Material {
+	if x != nil {
+		return x.Materials
+	}
+	return nil
+}
+
+func (x *Product) GetColors() []*Color {
+	if x != nil {
+		return x.Colors
+	}
+	return nil
+}
+
+func (x *Product) GetPhotos() []string {
+	if x != nil {
+		return x.Photos
+	}
+	return nil
+}
+
+func (x *Product) GetSeems() []*Product {
+	if x != nil {
+		return x.Seems
+	}
+	return nil
+}
+
+func (x *Product) GetPrice() int32 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Product) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Product) GetGallery() []*ProductPhoto {
+	if x != nil {
+		return x.Gallery
+	}
+	return nil
+}
+
+func (x *Product) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Product) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type ProductId struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Article       string                 `protobuf:"bytes,3,opt,name=article,proto3" json:"article,omitempty"`
+	Brand         string                 `protobuf:"bytes,4,opt,name=brand,proto3" json:"brand,omitempty"`
+	Category      string                 `protobuf:"bytes,5,opt,name=category,proto3" json:"category,omitempty"`
+	Country       string                 `protobuf:"bytes,6,opt,name=country,proto3" json:"country,omitempty"`
+	Width         int32                  `protobuf:"varint,7,opt,name=width,proto3" json:"width,omitempty"`
+	Height        int32                  `protobuf:"varint,8,opt,name=height,proto3" json:"height,omitempty"`
+	Depth         int32                  `protobuf:"varint,9,opt,name=depth,proto3" json:"depth,omitempty"`
+	Materials     []string               `protobuf:"bytes,10,rep,name=materials,proto3" json:"materials,omitempty"`
+	Colors        []string               `protobuf:"bytes,11,rep,name=colors,proto3" json:"colors,omitempty"`
+	Photos        []string               `protobuf:"bytes,12,rep,name=photos,proto3" json:"photos,omitempty"`
+	Seems         []string               `protobuf:"bytes,13,rep,name=seems,proto3" json:"seems,omitempty"`
+	Price         int32                  `protobuf:"varint,14,opt,name=price,proto3" json:"price,omitempty"`
+	Description   string                 `protobuf:"bytes,15,opt,name=description,proto3" json:"description,omitempty"`
+	Version       int64                  `protobuf:"varint,16,opt,name=version,proto3" json:"version,omitempty"`
+	ReplacePhotos bool                   `protobuf:"varint,17,opt,name=replace_photos,json=replacePhotos,proto3" json:"replace_photos,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductId) Reset() {
+	*x = ProductId{}
+	mi := &file_products_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductId) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductId) ProtoMessage() {}
+
+func (x *ProductId) ProtoReflect() protoreflect.Message {
+	mi := &file_products_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductId.ProtoReflect.Descriptor instead.
+func (*ProductId) Descriptor() ([]byte, []int) {
+	return file_products_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ProductId) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ProductId) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ProductId) GetArticle() string {
+	if x != nil {
+		return x.Article
+	}
+	return ""
+}
+
+func (x *ProductId) GetBrand() string {
+	if x != nil {
+		return x.Brand
+	}
+	return ""
+}
+
+func (x *ProductId) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *ProductId) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *ProductId) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *ProductId) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *ProductId) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *ProductId) GetMaterials() []string {
+	if x != nil {
+		return x.Materials
+	}
+	return nil
+}
+
+func (x *ProductId) GetColors() []string {
+	if x != nil {
+		return x.Colors
+	}
+	return nil
+}
+
+func (x *ProductId) GetPhotos() []string {
+	if x != nil {
+		return x.Photos
+	}
+	return nil
+}
+
+func (x *ProductId) GetSeems() []string {
+	if x != nil {
+		return x.Seems
+	}
+	return nil
+}
+
+func (x *ProductId) GetPrice() int32 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *ProductId) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ProductId) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ProductId) GetReplacePhotos() bool {
+	if x != nil {
+		return x.ReplacePhotos
+	}
+	return false
+}
+
+type ProductList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductList) Reset() {
+	*x = ProductList{}
+	mi := &file_products_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductList) ProtoMessage() {}
+
+func (x *ProductList) ProtoReflect() protoreflect.Message {
+	mi := &file_products_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductList.ProtoReflect.Descriptor instead.
+func (*ProductList) Descriptor() ([]byte, []int) {
+	return file_products_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ProductList) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+type BrandList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Brands        []*Brand               `protobuf:"bytes,1,rep,name=brands,proto3" json:"brands,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BrandList) Reset() {
+	*x = BrandList{}
+	mi := &file_products_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BrandList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BrandList) ProtoMessage() {}
+
+func (x *BrandList) ProtoReflect() protoreflect.Message {
+	mi := &file_products_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BrandList.ProtoReflect.Descriptor instead.
+func (*BrandList) Descriptor() ([]byte, []int) {
+	return file_products_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BrandList) GetBrands() []*Brand {
+	if x != nil {
+		return x.Brands
+	}
+	return nil
+}
+
+type CategoryList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Categories    []*Category            `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategoryList) Reset() {
+	*x = CategoryList{}
+	mi := &file_products_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryList) ProtoMessage() {}
+
+func (x *CategoryList) ProtoReflect() protoreflect.Message {
+	mi := &file_products_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryList.ProtoReflect.Descriptor instead.
+func (*CategoryList) Descriptor() ([]byte, []int) {
+	return file_products_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CategoryList) GetCategories() []*Category {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+type CountryList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Countries     []*Country             `protobuf:"bytes,1,rep,name=countries,proto3" json:"countries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountryList) Reset() {
+	*x = CountryList{}
+	mi := &file_products_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountryList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountryList) ProtoMessage() {}
+
+func (x *CountryList) ProtoReflect() protoreflect.Message {
+	mi := &file_products_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountryList.ProtoReflect.Descriptor instead.
+func (*CountryList) Descriptor() ([]byte, []int) {
+	return file_products_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CountryList) GetCountries() []*Country {
+	if x != nil {
+		return x.Countries
+	}
+	return nil
+}
+
+type MaterialList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Materials     []*Material            `protobuf:"bytes,1,rep,name=materials,proto3" json:"materials,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MaterialList) Reset() {
+	*x = MaterialList{}
+	mi := &file_products_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MaterialList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MaterialList) ProtoMessage() {}
+
+func (x *MaterialList) ProtoReflect() protoreflect.Message {
+	mi := &file_products_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MaterialList.ProtoReflect.Descriptor instead.
+func (*MaterialList) Descriptor() ([]byte, []int) {
+	return file_products_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *MaterialList) GetMaterials() []*Material {
+	if x != nil {
+		return x.Materials
+	}
+	return nil
+}
+
+type ColorList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Colors        []*Color               `protobuf:"bytes,1,rep,name=colors,proto3" json:"colors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ColorList) Reset() {
+	*x = ColorList{}
+	mi := &file_products_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ColorList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ColorList) ProtoMessage() {}
+
+func (x *ColorList) ProtoReflect() protoreflect.Message {
+	mi := &file_products_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ColorList.ProtoReflect.Descriptor instead.
+func (*ColorList) Descriptor() ([]byte, []int) {
+	return file_products_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ColorList) GetColors() []*Color {
+	if x != nil {
+		return x.Colors
+	}
+	return nil
+}
+
+type ProductFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Brand         []string               `protobuf:"bytes,1,rep,name=brand,proto3" json:"brand,omitempty"`
+	Country       []string               `protobuf:"bytes,2,rep,name=country,proto3" json:"country,omitempty"`
+	Category      []string               `protobuf:"bytes,3,rep,name=category,proto3" json:"category,omitempty"`
+	Materials     []string               `protobuf:"bytes,4,rep,name=materials,proto3" json:"materials,omitempty"`
+	Colors        []string               `protobuf:"bytes,5,rep,name=colors,proto3" json:"colors,omitempty"`
+	MinWidth      int32                  `protobuf:"varint,6,opt,name=min_width,json=minWidth,proto3" json:"min_width,omitempty"`
+	MaxWidth      int32                  `protobuf:"varint,7,opt,name=max_width,json=maxWidth,proto3" json:"max_width,omitempty"`
+	MinHeight     int32                  `protobuf:"varint,8,opt,name=min_height,json=minHeight,proto3" json:"min_height,omitempty"`
+	MaxHeight     int32                  `protobuf:"varint,9,opt,name=max_height,json=maxHeight,proto3" json:"max_height,omitempty"`
+	MinDepth      int32                  `protobuf:"varint,10,opt,name=min_depth,json=minDepth,proto3" json:"min_depth,omitempty"`
+	MaxDepth      int32                  `protobuf:"varint,11,opt,name=max_depth,json=maxDepth,proto3" json:"max_depth,omitempty"`
+	MinPrice      int32                  `protobuf:"varint,12,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"`
+	MaxPrice      int32                  `protobuf:"varint,13,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`
+	SortBy        string                 `protobuf:"bytes,14,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	SortOrder     string                 `protobuf:"bytes,15,opt,name=sort_order,json=sortOrder,proto3" json:"sort_order,omitempty"`
+	Offset        int32                  `protobuf:"varint,16,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit         int32                  `protobuf:"varint,17,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductFilter) Reset() {
+	*x = ProductFilter{}
+	mi := &file_products_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductFilter) ProtoMessage() {}
+
+func (x *ProductFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_products_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductFilter.ProtoReflect.Descriptor instead.
+func (*ProductFilter) Descriptor() ([]byte, []int) {
+	return file_products_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ProductFilter) GetBrand() []string {
+	if x != nil {
+		return x.Brand
+	}
+	return nil
+}
+
+func (x *ProductFilter) GetCountry() []string {
+	if x != nil {
+		return x.Country
+	}
+	return nil
+}
+
+func (x *ProductFilter) GetCategory() []string {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+func (x *ProductFilter) GetMaterials() []string {
+	if x != nil {
+		return x.Materials
+	}
+	return nil
+}
+
+func (x *ProductFilter) GetColors() []string {
+	if x != nil {
+		return x.Colors
+	}
+	return nil
+}
+
+func (x *ProductFilter) GetMinWidth() int32 {
+	if x != nil {
+		return x.MinWidth
+	}
+	return 0
+}
+
+func (x *ProductFilter) GetMaxWidth() int32 {
+	if x != nil {
+		return x.MaxWidth
+	}
+	return 0
+}
+
+func (x *ProductFilter) GetMinHeight() int32 {
+	if x != nil {
+		return x.MinHeight
+	}
+	return 0
+}
+
+func (x *ProductFilter) GetMaxHeight() int32 {
+	if x != nil {
+		return x.MaxHeight
+	}
+	return 0
+}
+
+func (x *ProductFilter) GetMinDepth() int32 {
+	if x != nil {
+		return x.MinDepth
+	}
+	return 0
+}
+
+func (x *ProductFilter) GetMaxDepth() int32 {
+	if x != nil {
+		return x.MaxDepth
+	}
+	return 0
+}
+
+func (x *ProductFilter) GetMinPrice() int32 {
+	if x != nil {
+		return x.MinPrice
+	}
+	return 0
+}
+
+func (x *ProductFilter) GetMaxPrice() int32 {
+	if x != nil {
+		return x.MaxPrice
+	}
+	return 0
+}
+
+func (x *ProductFilter) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *ProductFilter) GetSortOrder() string {
+	if x != nil {
+		return x.SortOrder
+	}
+	return ""
+}
+
+func (x *ProductFilter) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ProductFilter) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ProductSearch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Article       string                 `protobuf:"bytes,3,opt,name=article,proto3" json:"article,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductSearch) Reset() {
+	*x = ProductSearch{}
+	mi := &file_products_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductSearch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductSearch) ProtoMessage() {}
+
+func (x *ProductSearch) ProtoReflect() protoreflect.Message {
+	mi := &file_products_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductSearch.ProtoReflect.Descriptor instead.
+func (*ProductSearch) Descriptor() ([]byte, []int) {
+	return file_products_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ProductSearch) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ProductSearch) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ProductSearch) GetArticle() string {
+	if x != nil {
+		return x.Article
+	}
+	return ""
+}
+
+type Dictionaries struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Brands        *BrandList             `protobuf:"bytes,1,opt,name=brands,proto3" json:"brands,omitempty"`
+	Categories    *CategoryList          `protobuf:"bytes,2,opt,name=categories,proto3" json:"categories,omitempty"`
+	Countries     *CountryList           `protobuf:"bytes,3,opt,name=countries,proto3" json:"countries,omitempty"`
+	Materials     *MaterialList          `protobuf:"bytes,4,opt,name=materials,proto3" json:"materials,omitempty"`
+	Colors        *ColorList             `protobuf:"bytes,5,opt,name=colors,proto3" json:"colors,omitempty"`
+	MinPrice      int32                  `protobuf:"varint,6,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"`
+	MaxPrice      int32                  `protobuf:"varint,7,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`
+	MinWidth      int32                  `protobuf:"varint,8,opt,name=min_width,json=minWidth,proto3" json:"min_width,omitempty"`
+	MaxWidth      int32                  `protobuf:"varint,9,opt,name=max_width,json=maxWidth,proto3" json:"max_width,omitempty"`
+	MinHeight     int32                  `protobuf:"varint,10,opt,name=min_height,json=minHeight,proto3" json:"min_height,omitempty"`
+	MaxHeight     int32                  `protobuf:"varint,11,opt,name=max_height,json=maxHeight,proto3" json:"max_height,omitempty"`
+	MinDepth      int32                  `protobuf:"varint,12,opt,name=min_depth,json=minDepth,proto3" json:"min_depth,omitempty"`
+	MaxDepth      int32                  `protobuf:"varint,13,opt,name=max_depth,json=maxDepth,proto3" json:"max_depth,omitempty"`
+	Version       int64                  `protobuf:"varint,14,opt,name=version,proto3" json:"version,omitempty"`
+	UpdatedAt     int64                  `protobuf:"varint,15,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Dictionaries) Reset() {
+	*x = Dictionaries{}
+	mi := &file_products_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Dictionaries) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Dictionaries) ProtoMessage() {}
+
+func (x *Dictionaries) ProtoReflect() protoreflect.Message {
+	mi := &file_products_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Dictionaries.ProtoReflect.Descriptor instead.
+func (*Dictionaries) Descriptor() ([]byte, []int) {
+	return file_products_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Dictionaries) GetBrands() *BrandList {
+	if x != nil {
+		return x.Brands
+	}
+	return nil
+}
+
+func (x *Dictionaries) GetCategories() *CategoryList {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *Dictionaries) GetCountries() *CountryList {
+	if x != nil {
+		return x.Countries
+	}
+	return nil
+}
+
+func (x *Dictionaries) GetMaterials() *MaterialList {
+	if x != nil {
+		return x.Materials
+	}
+	return nil
+}
+
+func (x *Dictionaries) GetColors() *ColorList {
+	if x != nil {
+		return x.Colors
+	}
+	return nil
+}
+
+func (x *Dictionaries) GetMinPrice() int32 {
+	if x != nil {
+		return x.MinPrice
+	}
+	return 0
+}
+
+func (x *Dictionaries) GetMaxPrice() int32 {
+	if x != nil {
+		return x.MaxPrice
+	}
+	return 0
+}
+
+func (x *Dictionaries) GetMinWidth() int32 {
+	if x != nil {
+		return x.MinWidth
+	}
+	return 0
+}
+
+func (x *Dictionaries) GetMaxWidth() int32 {
+	if x != nil {
+		return x.MaxWidth
+	}
+	return 0
+}
+
+func (x *Dictionaries) GetMinHeight() int32 {
+	if x != nil {
+		return x.MinHeight
+	}
+	return 0
+}
+
+func (x *Dictionaries) GetMaxHeight() int32 {
+	if x != nil {
+		return x.MaxHeight
+	}
+	return 0
+}
+
+func (x *Dictionaries) GetMinDepth() int32 {
+	if x != nil {
+		return x.MinDepth
+	}
+	return 0
+}
+
+func (x *Dictionaries) GetMaxDepth() int32 {
+	if x != nil {
+		return x.MaxDepth
+	}
+	return 0
+}
+
+func (x *Dictionaries) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Dictionaries) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type DictionariesByCategory struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Brands        *BrandList             `protobuf:"bytes,1,opt,name=brands,proto3" json:"brands,omitempty"`
+	Countries     *CountryList           `protobuf:"bytes,2,opt,name=countries,proto3" json:"countries,omitempty"`
+	Materials     *MaterialList          `protobuf:"bytes,3,opt,name=materials,proto3" json:"materials,omitempty"`
+	Colors        *ColorList             `protobuf:"bytes,4,opt,name=colors,proto3" json:"colors,omitempty"`
+	MinPrice      int32                  `protobuf:"varint,5,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"`
+	MaxPrice      int32                  `protobuf:"varint,6,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`
+	MinWidth      int32                  `protobuf:"varint,7,opt,name=min_width,json=minWidth,proto3" json:"min_width,omitempty"`
+	MaxWidth      int32                  `protobuf:"varint,8,opt,name=max_width,json=maxWidth,proto3" json:"max_width,omitempty"`
+	MinHeight     int32                  `protobuf:"varint,9,opt,name=min_height,json=minHeight,proto3" json:"min_height,omitempty"`
+	MaxHeight     int32                  `protobuf:"varint,10,opt,name=max_height,json=maxHeight,proto3" json:"max_height,omitempty"`
+	MinDepth      int32                  `protobuf:"varint,11,opt,name=min_depth,json=minDepth,proto3" json:"min_depth,omitempty"`
+	MaxDepth      int32                  `protobuf:"varint,12,opt,name=max_depth,json=maxDepth,proto3" json:"max_depth,omitempty"`
+	Version       int64                  `protobuf:"varint,13,opt,name=version,proto3" json:"version,omitempty"`
+	UpdatedAt     int64                  `protobuf:"varint,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DictionariesByCategory) Reset() {
+	*x = DictionariesByCategory{}
+	mi := &file_products_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DictionariesByCategory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DictionariesByCategory) ProtoMessage() {}
+
+func (x *DictionariesByCategory) ProtoReflect() protoreflect.Message {
+	mi := &file_products_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DictionariesByCategory.ProtoReflect.Descriptor instead.
+func (*DictionariesByCategory) Descriptor() ([]byte, []int) {
+	return file_products_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DictionariesByCategory) GetBrands() *BrandList {
+	if x != nil {
+		return x.Brands
+	}
+	return nil
+}
+
+func (x *DictionariesByCategory) GetCountries() *CountryList {
+	if x != nil {
+		return x.Countries
+	}
+	return nil
+}
+
+func (x *DictionariesByCategory) GetMaterials() *MaterialList {
+	if x != nil {
+		return x.Materials
+	}
+	return nil
+}
+
+func (x *DictionariesByCategory) GetColors() *ColorList {
+	if x != nil {
+		return x.Colors
+	}
+	return nil
+}
+
+func (x *DictionariesByCategory) GetMinPrice() int32 {
+	if x != nil {
+		return x.MinPrice
+	}
+	return 0
+}
+
+func (x *DictionariesByCategory) GetMaxPrice() int32 {
+	if x != nil {
+		return x.MaxPrice
+	}
+	return 0
+}
+
+func (x *DictionariesByCategory) GetMinWidth() int32 {
+	if x != nil {
+		return x.MinWidth
+	}
+	return 0
+}
+
+func (x *DictionariesByCategory) GetMaxWidth() int32 {
+	if x != nil {
+		return x.MaxWidth
+	}
+	return 0
+}
+
+func (x *DictionariesByCategory) GetMinHeight() int32 {
+	if x != nil {
+		return x.MinHeight
+	}
+	return 0
+}
+
+func (x *DictionariesByCategory) GetMaxHeight() int32 {
+	if x != nil {
+		return x.MaxHeight
+	}
+	return 0
+}
+
+func (x *DictionariesByCategory) GetMinDepth() int32 {
+	if x != nil {
+		return x.MinDepth
+	}
+	return 0
+}
+
+func (x *DictionariesByCategory) GetMaxDepth() int32 {
+	if x != nil {
+		return x.MaxDepth
+	}
+	return 0
+}
+
+func (x *DictionariesByCategory) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *DictionariesByCategory) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type PhotoList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Photos        []string               `protobuf:"bytes,1,rep,name=photos,proto3" json:"photos,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PhotoList) Reset() {
+	*x = PhotoList{}
+	mi := &file_products_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PhotoList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PhotoList) ProtoMessage() {}
+
+func (x *PhotoList) ProtoReflect() protoreflect.Message {
+	mi := &file_products_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PhotoList.ProtoReflect.Descriptor instead.
+func (*PhotoList) Descriptor() ([]byte, []int) {
+	return file_products_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *PhotoList) GetPhotos() []string {
+	if x != nil {
+		return x.Photos
+	}
+	return nil
+}
+
+type ProductColorPhotos struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ColorId       string                 `protobuf:"bytes,2,opt,name=color_id,json=colorId,proto3" json:"color_id,omitempty"`
+	Photos        []string               `protobuf:"bytes,3,rep,name=photos,proto3" json:"photos,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductColorPhotos) Reset() {
+	*x = ProductColorPhotos{}
+	mi := &file_products_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductColorPhotos) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductColorPhotos) ProtoMessage() {}
+
+func (x *ProductColorPhotos) ProtoReflect() protoreflect.Message {
+	mi := &file_products_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductColorPhotos.ProtoReflect.Descriptor instead.
+func (*ProductColorPhotos) Descriptor() ([]byte, []int) {
+	return file_products_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ProductColorPhotos) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ProductColorPhotos) GetColorId() string {
+	if x != nil {
+		return x.ColorId
+	}
+	return ""
+}
+
+func (x *ProductColorPhotos) GetPhotos() []string {
+	if x != nil {
+		return x.Photos
+	}
+	return nil
+}
+
+type ProductColorPhotosId struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ColorId       string                 `protobuf:"bytes,2,opt,name=color_id,json=colorId,proto3" json:"color_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductColorPhotosId) Reset() {
+	*x = ProductColorPhotosId{}
+	mi := &file_products_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductColorPhotosId) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductColorPhotosId) ProtoMessage() {}
+
+func (x *ProductColorPhotosId) ProtoReflect() protoreflect.Message {
+	mi := &file_products_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductColorPhotosId.ProtoReflect.Descriptor instead.
+func (*ProductColorPhotosId) Descriptor() ([]byte, []int) {
+	return file_products_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ProductColorPhotosId) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ProductColorPhotosId) GetColorId() string {
+	if x != nil {
+		return x.ColorId
+	}
+	return ""
+}
+
+type ProductColorPhotosList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*ProductColorPhotos  `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductColorPhotosList) Reset() {
+	*x = ProductColorPhotosList{}
+	mi := &file_products_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductColorPhotosList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductColorPhotosList) ProtoMessage() {}
+
+func (x *ProductColorPhotosList) ProtoReflect() protoreflect.Message {
+	mi := &file_products_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductColorPhotosList.ProtoReflect.Descriptor instead.
+func (*ProductColorPhotosList) Descriptor() ([]byte, []int) {
+	return file_products_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ProductColorPhotosList) GetItems() []*ProductColorPhotos {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type GetAllProductsPagination struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         int32                  `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	End           int32                  `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAllProductsPagination) Reset() {
+	*x = GetAllProductsPagination{}
+	mi := &file_products_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAllProductsPagination) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAllProductsPagination) ProtoMessage() {}
+
+func (x *GetAllProductsPagination) ProtoReflect() protoreflect.Message {
+	mi := &file_products_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAllProductsPagination.ProtoReflect.Descriptor instead.
+func (*GetAllProductsPagination) Descriptor() ([]byte, []int) {
+	return file_products_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetAllProductsPagination) GetStart() int32 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *GetAllProductsPagination) GetEnd() int32 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+type ProductPhoto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	File          string                 `protobuf:"bytes,3,opt,name=file,proto3" json:"file,omitempty"`
+	Position      int32                  `protobuf:"varint,4,opt,name=position,proto3" json:"position,omitempty"`
+	Alt           string                 `protobuf:"bytes,5,opt,name=alt,proto3" json:"alt,omitempty"`
+	IsMain        bool                   `protobuf:"varint,6,opt,name=is_main,json=isMain,proto3" json:"is_main,omitempty"`
+	ColorId       string                 `protobuf:"bytes,7,opt,name=color_id,json=colorId,proto3" json:"color_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductPhoto) Reset() {
+	*x = ProductPhoto{}
+	mi := &file_products_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductPhoto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductPhoto) ProtoMessage() {}
+
+func (x *ProductPhoto) ProtoReflect() protoreflect.Message {
+	mi := &file_products_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductPhoto.ProtoReflect.Descriptor instead.
+func (*ProductPhoto) Descriptor() ([]byte, []int) {
+	return file_products_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ProductPhoto) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ProductPhoto) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ProductPhoto) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+func (x *ProductPhoto) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *ProductPhoto) GetAlt() string {
+	if x != nil {
+		return x.Alt
+	}
+	return ""
+}
+
+func (x *ProductPhoto) GetIsMain() bool {
+	if x != nil {
+		return x.IsMain
+	}
+	return false
+}
+
+func (x *ProductPhoto) GetColorId() string {
+	if x != nil {
+		return x.ColorId
+	}
+	return ""
+}
+
+type ProductPhotoList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Photos        []*ProductPhoto        `protobuf:"bytes,1,rep,name=photos,proto3" json:"photos,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductPhotoList) Reset() {
+	*x = ProductPhotoList{}
+	mi := &file_products_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductPhotoList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductPhotoList) ProtoMessage() {}
+
+func (x *ProductPhotoList) ProtoReflect() protoreflect.Message {
+	mi := &file_products_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductPhotoList.ProtoReflect.Descriptor instead.
+func (*ProductPhotoList) Descriptor() ([]byte, []int) {
+	return file_products_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ProductPhotoList) GetPhotos() []*ProductPhoto {
+	if x != nil {
+		return x.Photos
+	}
+	return nil
+}
+
+type AdminUser struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Login         string                 `protobuf:"bytes,1,opt,name=login,proto3" json:"login,omitempty"`
+	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	PasswordHash  string                 `protobuf:"bytes,3,opt,name=password_hash,json=passwordHash,proto3" json:"password_hash,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminUser) Reset() {
+	*x = AdminUser{}
+	mi := &file_products_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminUser) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminUser) ProtoMessage() {}
+
+func (x *AdminUser) ProtoReflect() protoreflect.Message {
+	mi := &file_products_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminUser.ProtoReflect.Descriptor instead.
+func (*AdminUser) Descriptor() ([]byte, []int) {
+	return file_products_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *AdminUser) GetLogin() string {
+	if x != nil {
+		return x.Login
+	}
+	return ""
+}
+
+func (x *AdminUser) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *AdminUser) GetPasswordHash() string {
+	if x != nil {
+		return x.PasswordHash
+	}
+	return ""
+}
+
+func (x *AdminUser) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type AdminUserList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Admins        []*AdminUser           `protobuf:"bytes,1,rep,name=admins,proto3" json:"admins,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminUserList) Reset() {
+	*x = AdminUserList{}
+	mi := &file_products_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminUserList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminUserList) ProtoMessage() {}
+
+func (x *AdminUserList) ProtoReflect() protoreflect.Message {
+	mi := &file_products_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminUserList.ProtoReflect.Descriptor instead.
+func (*AdminUserList) Descriptor() ([]byte, []int) {
+	return file_products_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *AdminUserList) GetAdmins() []*AdminUser {
+	if x != nil {
+		return x.Admins
+	}
+	return nil
+}
+
+type ProductPhotosOrder struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Ids           []string               `protobuf:"bytes,2,rep,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductPhotosOrder) Reset() {
+	*x = ProductPhotosOrder{}
+	mi := &file_products_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductPhotosOrder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductPhotosOrder) ProtoMessage() {}
+
+func (x *ProductPhotosOrder) ProtoReflect() protoreflect.Message {
+	mi := &file_products_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductPhotosOrder.ProtoReflect.Descriptor instead.
+func (*ProductPhotosOrder) Descriptor() ([]byte, []int) {
+	return file_products_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ProductPhotosOrder) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ProductPhotosOrder) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+var File_products_proto protoreflect.FileDescriptor
+
+const file_products_proto_rawDesc = "" +
+	"\n" +
+	"\x0eproducts.proto\x12\bproducts\x1a\x1bgoogle/protobuf/empty.proto\"\x14\n" +
+	"\x02Id\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"E\n" +
+	"\x05Brand\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x03R\aversion\"n\n" +
+	"\bCategory\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x10\n" +
+	"\x03uri\x18\x03 \x01(\tR\x03uri\x12\x10\n" +
+	"\x03img\x18\x04 \x01(\tR\x03img\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\x03R\aversion\"e\n" +
+	"\aCountry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x1a\n" +
+	"\bfriendly\x18\x03 \x01(\tR\bfriendly\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\x03R\aversion\"J\n" +
+	"\bMaterial\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x03R\aversion\"W\n" +
+	"\x05Color\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x10\n" +
+	"\x03hex\x18\x03 \x01(\tR\x03hex\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\x03R\aversion\"\xd0\x04\n" +
+	"\aProduct\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x18\n" +
+	"\aarticle\x18\x03 \x01(\tR\aarticle\x12%\n" +
+	"\x05brand\x18\x04 \x01(\v2\x0f.products.BrandR\x05brand\x12.\n" +
+	"\bcategory\x18\x05 \x01(\v2\x12.products.CategoryR\bcategory\x12+\n" +
+	"\acountry\x18\x06 \x01(\v2\x11.products.CountryR\acountry\x12\x14\n" +
+	"\x05width\x18\a \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\b \x01(\x05R\x06height\x12\x14\n" +
+	"\x05depth\x18\t \x01(\x05R\x05depth\x120\n" +
+	"\tmaterials\x18\n" +
+	" \x03(\v2\x12.products.MaterialR\tmaterials\x12'\n" +
+	"\x06colors\x18\v \x03(\v2\x0f.products.ColorR\x06colors\x12\x16\n" +
+	"\x06photos\x18\f \x03(\tR\x06photos\x12'\n" +
+	"\x05seems\x18\r \x03(\v2\x11.products.ProductR\x05seems\x12\x14\n" +
+	"\x05price\x18\x0e \x01(\x05R\x05price\x12 \n" +
+	"\vdescription\x18\x0f \x01(\tR\vdescription\x120\n" +
+	"\agallery\x18\x10 \x03(\v2\x16.products.ProductPhotoR\agallery\x12\x18\n" +
+	"\aversion\x18\x11 \x01(\x03R\aversion\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x12 \x01(\x03R\tupdatedAt\"\xb8\x03\n" +
+	"\tProductId\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x18\n" +
+	"\aarticle\x18\x03 \x01(\tR\aarticle\x12\x14\n" +
+	"\x05brand\x18\x04 \x01(\tR\x05brand\x12\x1a\n" +
+	"\bcategory\x18\x05 \x01(\tR\bcategory\x12\x18\n" +
+	"\acountry\x18\x06 \x01(\tR\acountry\x12\x14\n" +
+	"\x05width\x18\a \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\b \x01(\x05R\x06height\x12\x14\n" +
+	"\x05depth\x18\t \x01(\x05R\x05depth\x12\x1c\n" +
+	"\tmaterials\x18\n" +
+	" \x03(\tR\tmaterials\x12\x16\n" +
+	"\x06colors\x18\v \x03(\tR\x06colors\x12\x16\n" +
+	"\x06photos\x18\f \x03(\tR\x06photos\x12\x14\n" +
+	"\x05seems\x18\r \x03(\tR\x05seems\x12\x14\n" +
+	"\x05price\x18\x0e \x01(\x05R\x05price\x12 \n" +
+	"\vdescription\x18\x0f \x01(\tR\vdescription\x12\x18\n" +
+	"\aversion\x18\x10 \x01(\x03R\aversion\x12%\n" +
+	"\x0ereplace_photos\x18\x11 \x01(\bR\rreplacePhotos\"<\n" +
+	"\vProductList\x12-\n" +
+	"\bproducts\x18\x01 \x03(\v2\x11.products.ProductR\bproducts\"4\n" +
+	"\tBrandList\x12'\n" +
+	"\x06brands\x18\x01 \x03(\v2\x0f.products.BrandR\x06brands\"B\n" +
+	"\fCategoryList\x122\n" +
+	"\n" +
+	"categories\x18\x01 \x03(\v2\x12.products.CategoryR\n" +
+	"categories\">\n" +
+	"\vCountryList\x12/\n" +
+	"\tcountries\x18\x01 \x03(\v2\x11.products.CountryR\tcountries\"@\n" +
+	"\fMaterialList\x120\n" +
+	"\tmaterials\x18\x01 \x03(\v2\x12.products.MaterialR\tmaterials\"4\n" +
+	"\tColorList\x12'\n" +
+	"\x06colors\x18\x01 \x03(\v2\x0f.products.ColorR\x06colors\"\xe3\x03\n" +
+	"\rProductFilter\x12\x14\n" +
+	"\x05brand\x18\x01 \x03(\tR\x05brand\x12\x18\n" +
+	"\acountry\x18\x02 \x03(\tR\acountry\x12\x1a\n" +
+	"\bcategory\x18\x03 \x03(\tR\bcategory\x12\x1c\n" +
+	"\tmaterials\x18\x04 \x03(\tR\tmaterials\x12\x16\n" +
+	"\x06colors\x18\x05 \x03(\tR\x06colors\x12\x1b\n" +
+	"\tmin_width\x18\x06 \x01(\x05R\bminWidth\x12\x1b\n" +
+	"\tmax_width\x18\a \x01(\x05R\bmaxWidth\x12\x1d\n" +
+	"\n" +
+	"min_height\x18\b \x01(\x05R\tminHeight\x12\x1d\n" +
+	"\n" +
+	"max_height\x18\t \x01(\x05R\tmaxHeight\x12\x1b\n" +
+	"\tmin_depth\x18\n" +
+	" \x01(\x05R\bminDepth\x12\x1b\n" +
+	"\tmax_depth\x18\v \x01(\x05R\bmaxDepth\x12\x1b\n" +
+	"\tmin_price\x18\f \x01(\x05R\bminPrice\x12\x1b\n" +
+	"\tmax_price\x18\r \x01(\x05R\bmaxPrice\x12\x17\n" +
+	"\asort_by\x18\x0e \x01(\tR\x06sortBy\x12\x1d\n" +
+	"\n" +
+	"sort_order\x18\x0f \x01(\tR\tsortOrder\x12\x16\n" +
+	"\x06offset\x18\x10 \x01(\x05R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x11 \x01(\x05R\x05limit\"O\n" +
+	"\rProductSearch\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x18\n" +
+	"\aarticle\x18\x03 \x01(\tR\aarticle\"\xb0\x04\n" +
+	"\fDictionaries\x12+\n" +
+	"\x06brands\x18\x01 \x01(\v2\x13.products.BrandListR\x06brands\x126\n" +
+	"\n" +
+	"categories\x18\x02 \x01(\v2\x16.products.CategoryListR\n" +
+	"categories\x123\n" +
+	"\tcountries\x18\x03 \x01(\v2\x15.products.CountryListR\tcountries\x124\n" +
+	"\tmaterials\x18\x04 \x01(\v2\x16.products.MaterialListR\tmaterials\x12+\n" +
+	"\x06colors\x18\x05 \x01(\v2\x13.products.ColorListR\x06colors\x12\x1b\n" +
+	"\tmin_price\x18\x06 \x01(\x05R\bminPrice\x12\x1b\n" +
+	"\tmax_price\x18\a \x01(\x05R\bmaxPrice\x12\x1b\n" +
+	"\tmin_width\x18\b \x01(\x05R\bminWidth\x12\x1b\n" +
+	"\tmax_width\x18\t \x01(\x05R\bmaxWidth\x12\x1d\n" +
+	"\n" +
+	"min_height\x18\n" +
+	" \x01(\x05R\tminHeight\x12\x1d\n" +
+	"\n" +
+	"max_height\x18\v \x01(\x05R\tmaxHeight\x12\x1b\n" +
+	"\tmin_depth\x18\f \x01(\x05R\bminDepth\x12\x1b\n" +
+	"\tmax_depth\x18\r \x01(\x05R\bmaxDepth\x12\x18\n" +
+	"\aversion\x18\x0e \x01(\x03R\aversion\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x0f \x01(\x03R\tupdatedAt\"\x82\x04\n" +
+	"\x16DictionariesByCategory\x12+\n" +
+	"\x06brands\x18\x01 \x01(\v2\x13.products.BrandListR\x06brands\x123\n" +
+	"\tcountries\x18\x02 \x01(\v2\x15.products.CountryListR\tcountries\x124\n" +
+	"\tmaterials\x18\x03 \x01(\v2\x16.products.MaterialListR\tmaterials\x12+\n" +
+	"\x06colors\x18\x04 \x01(\v2\x13.products.ColorListR\x06colors\x12\x1b\n" +
+	"\tmin_price\x18\x05 \x01(\x05R\bminPrice\x12\x1b\n" +
+	"\tmax_price\x18\x06 \x01(\x05R\bmaxPrice\x12\x1b\n" +
+	"\tmin_width\x18\a \x01(\x05R\bminWidth\x12\x1b\n" +
+	"\tmax_width\x18\b \x01(\x05R\bmaxWidth\x12\x1d\n" +
+	"\n" +
+	"min_height\x18\t \x01(\x05R\tminHeight\x12\x1d\n" +
+	"\n" +
+	"max_height\x18\n" +
+	" \x01(\x05R\tmaxHeight\x12\x1b\n" +
+	"\tmin_depth\x18\v \x01(\x05R\bminDepth\x12\x1b\n" +
+	"\tmax_depth\x18\f \x01(\x05R\bmaxDepth\x12\x18\n" +
+	"\aversion\x18\r \x01(\x03R\aversion\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x0e \x01(\x03R\tupdatedAt\"#\n" +
+	"\tPhotoList\x12\x16\n" +
+	"\x06photos\x18\x01 \x03(\tR\x06photos\"f\n" +
+	"\x12ProductColorPhotos\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x19\n" +
+	"\bcolor_id\x18\x02 \x01(\tR\acolorId\x12\x16\n" +
+	"\x06photos\x18\x03 \x03(\tR\x06photos\"P\n" +
+	"\x14ProductColorPhotosId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x19\n" +
+	"\bcolor_id\x18\x02 \x01(\tR\acolorId\"L\n" +
+	"\x16ProductColorPhotosList\x122\n" +
+	"\x05items\x18\x01 \x03(\v2\x1c.products.ProductColorPhotosR\x05items\"B\n" +
+	"\x18GetAllProductsPagination\x12\x14\n" +
+	"\x05start\x18\x01 \x01(\x05R\x05start\x12\x10\n" +
+	"\x03end\x18\x02 \x01(\x05R\x03end\"\xb3\x01\n" +
+	"\fProductPhoto\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x12\n" +
+	"\x04file\x18\x03 \x01(\tR\x04file\x12\x1a\n" +
+	"\bposition\x18\x04 \x01(\x05R\bposition\x12\x10\n" +
+	"\x03alt\x18\x05 \x01(\tR\x03alt\x12\x17\n" +
+	"\ais_main\x18\x06 \x01(\bR\x06isMain\x12\x19\n" +
+	"\bcolor_id\x18\a \x01(\tR\acolorId\"B\n" +
+	"\x10ProductPhotoList\x12.\n" +
+	"\x06photos\x18\x01 \x03(\v2\x16.products.ProductPhotoR\x06photos\"y\n" +
+	"\tAdminUser\x12\x14\n" +
+	"\x05login\x18\x01 \x01(\tR\x05login\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\x12#\n" +
+	"\rpassword_hash\x18\x03 \x01(\tR\fpasswordHash\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\x03R\tcreatedAt\"<\n" +
+	"\rAdminUserList\x12+\n" +
+	"\x06admins\x18\x01 \x03(\v2\x13.products.AdminUserR\x06admins\"E\n" +
+	"\x12ProductPhotosOrder\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x10\n" +
+	"\x03ids\x18\x02 \x03(\tR\x03ids2\x80\x16\n" +
+	"\bProducts\x126\n" +
+	"\vCreateBrand\x12\x0f.products.Brand\x1a\x16.google.protobuf.Empty\x126\n" +
+	"\vUpdateBrand\x12\x0f.products.Brand\x1a\x16.google.protobuf.Empty\x123\n" +
+	"\vDeleteBrand\x12\f.products.Id\x1a\x16.google.protobuf.Empty\x12;\n" +
+	"\fGetAllBrands\x12\x16.google.protobuf.Empty\x1a\x13.products.BrandList\x12<\n" +
+	"\x0eCreateCategory\x12\x12.products.Category\x1a\x16.google.protobuf.Empty\x12<\n" +
+	"\x0eUpdateCategory\x12\x12.products.Category\x1a\x16.google.protobuf.Empty\x126\n" +
+	"\x0eDeleteCategory\x12\f.products.Id\x1a\x16.google.protobuf.Empty\x12B\n" +
+	"\x10GetAllCategories\x12\x16.google.protobuf.Empty\x1a\x16.products.CategoryList\x12:\n" +
+	"\rCreateCountry\x12\x11.products.Country\x1a\x16.google.protobuf.Empty\x12:\n" +
+	"\rUpdateCountry\x12\x11.products.Country\x1a\x16.google.protobuf.Empty\x125\n" +
+	"\rDeleteCountry\x12\f.products.Id\x1a\x16.google.protobuf.Empty\x12@\n" +
+	"\x0fGetAllCountries\x12\x16.google.protobuf.Empty\x1a\x15.products.CountryList\x12<\n" +
+	"\x0eCreateMaterial\x12\x12.products.Material\x1a\x16.google.protobuf.Empty\x12<\n" +
+	"\x0eUpdateMaterial\x12\x12.products.Material\x1a\x16.google.protobuf.Empty\x126\n" +
+	"\x0eDeleteMaterial\x12\f.products.Id\x1a\x16.google.protobuf.Empty\x12A\n" +
+	"\x0fGetAllMaterials\x12\x16.google.protobuf.Empty\x1a\x16.products.MaterialList\x126\n" +
+	"\vCreateColor\x12\x0f.products.Color\x1a\x16.google.protobuf.Empty\x126\n" +
+	"\vUpdateColor\x12\x0f.products.Color\x1a\x16.google.protobuf.Empty\x123\n" +
+	"\vDeleteColor\x12\f.products.Id\x1a\x16.google.protobuf.Empty\x12;\n" +
+	"\fGetAllColors\x12\x16.google.protobuf.Empty\x1a\x13.products.ColorList\x12<\n" +
+	"\rCreateProduct\x12\x13.products.ProductId\x1a\x16.google.protobuf.Empty\x12<\n" +
+	"\rUpdateProduct\x12\x13.products.ProductId\x1a\x16.google.protobuf.Empty\x125\n" +
+	"\rDeleteProduct\x12\f.products.Id\x1a\x16.google.protobuf.Empty\x12-\n" +
+	"\n" +
+	"GetProduct\x12\f.products.Id\x1a\x11.products.Product\x12K\n" +
+	"\x0eGetAllProducts\x12\".products.GetAllProductsPagination\x1a\x15.products.ProductList\x12@\n" +
+	"\x0eSearchProducts\x12\x17.products.ProductSearch\x1a\x15.products.ProductList\x12@\n" +
+	"\x0eFilterProducts\x12\x17.products.ProductFilter\x1a\x15.products.ProductList\x12A\n" +
+	"\x0fGetDictionaries\x12\x16.google.protobuf.Empty\x1a\x16.products.Dictionaries\x12K\n" +
+	"\x19GetDictionariesByCategory\x12\f.products.Id\x1a .products.DictionariesByCategory\x12P\n" +
+	"\x18CreateProductColorPhotos\x12\x1c.products.ProductColorPhotos\x1a\x16.google.protobuf.Empty\x12P\n" +
+	"\x18UpdateProductColorPhotos\x12\x1c.products.ProductColorPhotos\x1a\x16.google.protobuf.Empty\x12R\n" +
+	"\x18DeleteProductColorPhotos\x12\x1e.products.ProductColorPhotosId\x1a\x16.google.protobuf.Empty\x12T\n" +
+	"\x18GetAllProductColorPhotos\x12\x16.google.protobuf.Empty\x1a .products.ProductColorPhotosList\x12Q\n" +
+	"\x1aGetPhotosByProductAndColor\x12\x1e.products.ProductColorPhotosId\x1a\x13.products.PhotoList\x12<\n" +
+	"\x10GetProductPhotos\x12\f.products.Id\x1a\x1a.products.ProductPhotoList\x12A\n" +
+	"\x0fAddProductPhoto\x12\x16.products.ProductPhoto\x1a\x16.google.protobuf.Empty\x12D\n" +
+	"\x12UpdateProductPhoto\x12\x16.products.ProductPhoto\x1a\x16.google.protobuf.Empty\x12L\n" +
+	"\x14ReorderProductPhotos\x12\x1c.products.ProductPhotosOrder\x1a\x16.google.protobuf.Empty\x12:\n" +
+	"\x12RemoveProductPhoto\x12\f.products.Id\x1a\x16.products.ProductPhoto\x12-\n" +
+	"\bGetAdmin\x12\f.products.Id\x1a\x13.products.AdminUser\x12?\n" +
+	"\fGetAllAdmins\x12\x16.google.protobuf.Empty\x1a\x17.products.AdminUserList\x12:\n" +
+	"\vCreateAdmin\x12\x13.products.AdminUser\x1a\x16.google.protobuf.Empty\x12:\n" +
+	"\vUpdateAdmin\x12\x13.products.AdminUser\x1a\x16.google.protobuf.Empty\x123\n" +
+	"\vDeleteAdmin\x12\f.products.Id\x1a\x16.google.protobuf.EmptyB2Z0github.com/autumnterror/volha-proto/gen/productsb\x06proto3"
+
+var (
+	file_products_proto_rawDescOnce sync.Once
+	file_products_proto_rawDescData []byte
+)
+
+func file_products_proto_rawDescGZIP() []byte {
+	file_products_proto_rawDescOnce.Do(func() {
+		file_products_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_products_proto_rawDesc), len(file_products_proto_rawDesc)))
+	})
+	return file_products_proto_rawDescData
+}
+
+var file_products_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
+var file_products_proto_goTypes = []any{
+	(*Id)(nil),                       // 0: products.Id
+	(*Brand)(nil),                    // 1: products.Brand
+	(*Category)(nil),                 // 2: products.Category
+	(*Country)(nil),                  // 3: products.Country
+	(*Material)(nil),                 // 4: products.Material
+	(*Color)(nil),                    // 5: products.Color
+	(*Product)(nil),                  // 6: products.Product
+	(*ProductId)(nil),                // 7: products.ProductId
+	(*ProductList)(nil),              // 8: products.ProductList
+	(*BrandList)(nil),                // 9: products.BrandList
+	(*CategoryList)(nil),             // 10: products.CategoryList
+	(*CountryList)(nil),              // 11: products.CountryList
+	(*MaterialList)(nil),             // 12: products.MaterialList
+	(*ColorList)(nil),                // 13: products.ColorList
+	(*ProductFilter)(nil),            // 14: products.ProductFilter
+	(*ProductSearch)(nil),            // 15: products.ProductSearch
+	(*Dictionaries)(nil),             // 16: products.Dictionaries
+	(*DictionariesByCategory)(nil),   // 17: products.DictionariesByCategory
+	(*PhotoList)(nil),                // 18: products.PhotoList
+	(*ProductColorPhotos)(nil),       // 19: products.ProductColorPhotos
+	(*ProductColorPhotosId)(nil),     // 20: products.ProductColorPhotosId
+	(*ProductColorPhotosList)(nil),   // 21: products.ProductColorPhotosList
+	(*GetAllProductsPagination)(nil), // 22: products.GetAllProductsPagination
+	(*ProductPhoto)(nil),             // 23: products.ProductPhoto
+	(*ProductPhotoList)(nil),         // 24: products.ProductPhotoList
+	(*AdminUser)(nil),                // 25: products.AdminUser
+	(*AdminUserList)(nil),            // 26: products.AdminUserList
+	(*ProductPhotosOrder)(nil),       // 27: products.ProductPhotosOrder
+	(*emptypb.Empty)(nil),            // 28: google.protobuf.Empty
+}
+var file_products_proto_depIdxs = []int32{
+	1,  // 0: products.Product.brand:type_name -> products.Brand
+	2,  // 1: products.Product.category:type_name -> products.Category
+	3,  // 2: products.Product.country:type_name -> products.Country
+	4,  // 3: products.Product.materials:type_name -> products.Material
+	5,  // 4: products.Product.colors:type_name -> products.Color
+	6,  // 5: products.Product.seems:type_name -> products.Product
+	23, // 6: products.Product.gallery:type_name -> products.ProductPhoto
+	6,  // 7: products.ProductList.products:type_name -> products.Product
+	1,  // 8: products.BrandList.brands:type_name -> products.Brand
+	2,  // 9: products.CategoryList.categories:type_name -> products.Category
+	3,  // 10: products.CountryList.countries:type_name -> products.Country
+	4,  // 11: products.MaterialList.materials:type_name -> products.Material
+	5,  // 12: products.ColorList.colors:type_name -> products.Color
+	9,  // 13: products.Dictionaries.brands:type_name -> products.BrandList
+	10, // 14: products.Dictionaries.categories:type_name -> products.CategoryList
+	11, // 15: products.Dictionaries.countries:type_name -> products.CountryList
+	12, // 16: products.Dictionaries.materials:type_name -> products.MaterialList
+	13, // 17: products.Dictionaries.colors:type_name -> products.ColorList
+	9,  // 18: products.DictionariesByCategory.brands:type_name -> products.BrandList
+	11, // 19: products.DictionariesByCategory.countries:type_name -> products.CountryList
+	12, // 20: products.DictionariesByCategory.materials:type_name -> products.MaterialList
+	13, // 21: products.DictionariesByCategory.colors:type_name -> products.ColorList
+	19, // 22: products.ProductColorPhotosList.items:type_name -> products.ProductColorPhotos
+	23, // 23: products.ProductPhotoList.photos:type_name -> products.ProductPhoto
+	25, // 24: products.AdminUserList.admins:type_name -> products.AdminUser
+	1,  // 25: products.Products.CreateBrand:input_type -> products.Brand
+	1,  // 26: products.Products.UpdateBrand:input_type -> products.Brand
+	0,  // 27: products.Products.DeleteBrand:input_type -> products.Id
+	28, // 28: products.Products.GetAllBrands:input_type -> google.protobuf.Empty
+	2,  // 29: products.Products.CreateCategory:input_type -> products.Category
+	2,  // 30: products.Products.UpdateCategory:input_type -> products.Category
+	0,  // 31: products.Products.DeleteCategory:input_type -> products.Id
+	28, // 32: products.Products.GetAllCategories:input_type -> google.protobuf.Empty
+	3,  // 33: products.Products.CreateCountry:input_type -> products.Country
+	3,  // 34: products.Products.UpdateCountry:input_type -> products.Country
+	0,  // 35: products.Products.DeleteCountry:input_type -> products.Id
+	28, // 36: products.Products.GetAllCountries:input_type -> google.protobuf.Empty
+	4,  // 37: products.Products.CreateMaterial:input_type -> products.Material
+	4,  // 38: products.Products.UpdateMaterial:input_type -> products.Material
+	0,  // 39: products.Products.DeleteMaterial:input_type -> products.Id
+	28, // 40: products.Products.GetAllMaterials:input_type -> google.protobuf.Empty
+	5,  // 41: products.Products.CreateColor:input_type -> products.Color
+	5,  // 42: products.Products.UpdateColor:input_type -> products.Color
+	0,  // 43: products.Products.DeleteColor:input_type -> products.Id
+	28, // 44: products.Products.GetAllColors:input_type -> google.protobuf.Empty
+	7,  // 45: products.Products.CreateProduct:input_type -> products.ProductId
+	7,  // 46: products.Products.UpdateProduct:input_type -> products.ProductId
+	0,  // 47: products.Products.DeleteProduct:input_type -> products.Id
+	0,  // 48: products.Products.GetProduct:input_type -> products.Id
+	22, // 49: products.Products.GetAllProducts:input_type -> products.GetAllProductsPagination
+	15, // 50: products.Products.SearchProducts:input_type -> products.ProductSearch
+	14, // 51: products.Products.FilterProducts:input_type -> products.ProductFilter
+	28, // 52: products.Products.GetDictionaries:input_type -> google.protobuf.Empty
+	0,  // 53: products.Products.GetDictionariesByCategory:input_type -> products.Id
+	19, // 54: products.Products.CreateProductColorPhotos:input_type -> products.ProductColorPhotos
+	19, // 55: products.Products.UpdateProductColorPhotos:input_type -> products.ProductColorPhotos
+	20, // 56: products.Products.DeleteProductColorPhotos:input_type -> products.ProductColorPhotosId
+	28, // 57: products.Products.GetAllProductColorPhotos:input_type -> google.protobuf.Empty
+	20, // 58: products.Products.GetPhotosByProductAndColor:input_type -> products.ProductColorPhotosId
+	0,  // 59: products.Products.GetProductPhotos:input_type -> products.Id
+	23, // 60: products.Products.AddProductPhoto:input_type -> products.ProductPhoto
+	23, // 61: products.Products.UpdateProductPhoto:input_type -> products.ProductPhoto
+	27, // 62: products.Products.ReorderProductPhotos:input_type -> products.ProductPhotosOrder
+	0,  // 63: products.Products.RemoveProductPhoto:input_type -> products.Id
+	0,  // 64: products.Products.GetAdmin:input_type -> products.Id
+	28, // 65: products.Products.GetAllAdmins:input_type -> google.protobuf.Empty
+	25, // 66: products.Products.CreateAdmin:input_type -> products.AdminUser
+	25, // 67: products.Products.UpdateAdmin:input_type -> products.AdminUser
+	0,  // 68: products.Products.DeleteAdmin:input_type -> products.Id
+	28, // 69: products.Products.CreateBrand:output_type -> google.protobuf.Empty
+	28, // 70: products.Products.UpdateBrand:output_type -> google.protobuf.Empty
+	28, // 71: products.Products.DeleteBrand:output_type -> google.protobuf.Empty
+	9,  // 72: products.Products.GetAllBrands:output_type -> products.BrandList
+	28, // 73: products.Products.CreateCategory:output_type -> google.protobuf.Empty
+	28, // 74: products.Products.UpdateCategory:output_type -> google.protobuf.Empty
+	28, // 75: products.Products.DeleteCategory:output_type -> google.protobuf.Empty
+	10, // 76: products.Products.GetAllCategories:output_type -> products.CategoryList
+	28, // 77: products.Products.CreateCountry:output_type -> google.protobuf.Empty
+	28, // 78: products.Products.UpdateCountry:output_type -> google.protobuf.Empty
+	28, // 79: products.Products.DeleteCountry:output_type -> google.protobuf.Empty
+	11, // 80: products.Products.GetAllCountries:output_type -> products.CountryList
+	28, // 81: products.Products.CreateMaterial:output_type -> google.protobuf.Empty
+	28, // 82: products.Products.UpdateMaterial:output_type -> google.protobuf.Empty
+	28, // 83: products.Products.DeleteMaterial:output_type -> google.protobuf.Empty
+	12, // 84: products.Products.GetAllMaterials:output_type -> products.MaterialList
+	28, // 85: products.Products.CreateColor:output_type -> google.protobuf.Empty
+	28, // 86: products.Products.UpdateColor:output_type -> google.protobuf.Empty
+	28, // 87: products.Products.DeleteColor:output_type -> google.protobuf.Empty
+	13, // 88: products.Products.GetAllColors:output_type -> products.ColorList
+	28, // 89: products.Products.CreateProduct:output_type -> google.protobuf.Empty
+	28, // 90: products.Products.UpdateProduct:output_type -> google.protobuf.Empty
+	28, // 91: products.Products.DeleteProduct:output_type -> google.protobuf.Empty
+	6,  // 92: products.Products.GetProduct:output_type -> products.Product
+	8,  // 93: products.Products.GetAllProducts:output_type -> products.ProductList
+	8,  // 94: products.Products.SearchProducts:output_type -> products.ProductList
+	8,  // 95: products.Products.FilterProducts:output_type -> products.ProductList
+	16, // 96: products.Products.GetDictionaries:output_type -> products.Dictionaries
+	17, // 97: products.Products.GetDictionariesByCategory:output_type -> products.DictionariesByCategory
+	28, // 98: products.Products.CreateProductColorPhotos:output_type -> google.protobuf.Empty
+	28, // 99: products.Products.UpdateProductColorPhotos:output_type -> google.protobuf.Empty
+	28, // 100: products.Products.DeleteProductColorPhotos:output_type -> google.protobuf.Empty
+	21, // 101: products.Products.GetAllProductColorPhotos:output_type -> products.ProductColorPhotosList
+	18, // 102: products.Products.GetPhotosByProductAndColor:output_type -> products.PhotoList
+	24, // 103: products.Products.GetProductPhotos:output_type -> products.ProductPhotoList
+	28, // 104: products.Products.AddProductPhoto:output_type -> google.protobuf.Empty
+	28, // 105: products.Products.UpdateProductPhoto:output_type -> google.protobuf.Empty
+	28, // 106: products.Products.ReorderProductPhotos:output_type -> google.protobuf.Empty
+	23, // 107: products.Products.RemoveProductPhoto:output_type -> products.ProductPhoto
+	25, // 108: products.Products.GetAdmin:output_type -> products.AdminUser
+	26, // 109: products.Products.GetAllAdmins:output_type -> products.AdminUserList
+	28, // 110: products.Products.CreateAdmin:output_type -> google.protobuf.Empty
+	28, // 111: products.Products.UpdateAdmin:output_type -> google.protobuf.Empty
+	28, // 112: products.Products.DeleteAdmin:output_type -> google.protobuf.Empty
+	69, // [69:113] is the sub-list for method output_type
+	25, // [25:69] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
+}
+
+func init() { file_products_proto_init() }
+func file_products_proto_init() {
+	if File_products_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_products_proto_rawDesc), len(file_products_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   28,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_products_proto_goTypes,
+		DependencyIndexes: file_products_proto_depIdxs,
+		MessageInfos:      file_products_proto_msgTypes,
+	}.Build()
+	File_products_proto = out.File
+	file_products_proto_goTypes = nil
+	file_products_proto_depIdxs = nil
+}